@@ -15,9 +15,11 @@
 package v1alpha1
 
 import (
-	"errors"
+	"bytes"
+	"encoding/json"
 	"fmt"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -27,6 +29,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
+// ConversionDataAnnotation is set on the v1alpha1 collectors converted from v1beta1 collectors having fields which
+// can't be represented in v1alpha1.
+const ConversionDataAnnotation = "operator.opentelemetry.io/v1beta1-conversion-data"
+
 var _ conversion.Convertible = &OpenTelemetryCollector{}
 
 func (src *OpenTelemetryCollector) ConvertTo(dstRaw conversion.Hub) error {
@@ -37,6 +43,9 @@ func (src *OpenTelemetryCollector) ConvertTo(dstRaw conversion.Hub) error {
 		if err != nil {
 			return fmt.Errorf("failed to convert to v1beta1: %w", err)
 		}
+		if err := restoreConversionData(&convertedSrc); err != nil {
+			return fmt.Errorf("failed to convert to v1beta1: %w", err)
+		}
 		dst.ObjectMeta = convertedSrc.ObjectMeta
 		dst.Spec = convertedSrc.Spec
 		dst.Status = convertedSrc.Status
@@ -54,6 +63,9 @@ func (dst *OpenTelemetryCollector) ConvertFrom(srcRaw conversion.Hub) error {
 		if err != nil {
 			return fmt.Errorf("failed to convert to v1alpha1: %w", err)
 		}
+		if err := storeConversionData(*src, srcConverted); err != nil {
+			return fmt.Errorf("failed to convert to v1alpha1: %w", err)
+		}
 		dst.ObjectMeta = srcConverted.ObjectMeta
 		dst.Spec = srcConverted.Spec
 		dst.Status = srcConverted.Status
@@ -63,11 +75,145 @@ func (dst *OpenTelemetryCollector) ConvertFrom(srcRaw conversion.Hub) error {
 	return nil
 }
 
+// conversionData is the part of a v1beta1 OpenTelemetryCollector stored in the conversion data annotation.
+type conversionData struct {
+	Spec   v1beta1.OpenTelemetryCollectorSpec   `json:"spec"`
+	Status v1beta1.OpenTelemetryCollectorStatus `json:"status"`
+}
+
+// storeConversionData records in an annotation of the v1alpha1 collector the fields of the v1beta1 collector which
+// can't be represented in v1alpha1, e.g. the fields only available in v1beta1. They are restored when the collector
+// is converted back to v1beta1, so that updating a collector with v1alpha1 clients doesn't lose them.
+// The annotation holds a JSON merge patch from the v1beta1 collector converted back from v1alpha1 to the original
+// one, and is only set when some fields would be lost.
+func storeConversionData(src v1beta1.OpenTelemetryCollector, dst *OpenTelemetryCollector) error {
+	delete(dst.Annotations, ConversionDataAnnotation)
+	roundTrip, err := tov1beta1(*dst)
+	if err != nil {
+		return err
+	}
+	original, err := json.Marshal(&conversionData{Spec: src.Spec, Status: src.Status})
+	if err != nil {
+		return err
+	}
+	converted, err := json.Marshal(&conversionData{Spec: roundTrip.Spec, Status: roundTrip.Status})
+	if err != nil {
+		return err
+	}
+	patch, err := jsonpatch.CreateMergePatch(converted, original)
+	if err != nil {
+		return fmt.Errorf("failed to compute the conversion data: %w", err)
+	}
+	var lost map[string]interface{}
+	if err := json.Unmarshal(patch, &lost); err != nil {
+		return err
+	}
+	// the fields only removed by the patch are defaults set by the conversion, they don't need to be restored
+	if !hasValue(lost) {
+		return nil
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[ConversionDataAnnotation] = string(patch)
+	return nil
+}
+
+// restoreConversionData restores the fields recorded by storeConversionData in the v1beta1 collector converted from
+// v1alpha1.
+func restoreConversionData(dst *v1beta1.OpenTelemetryCollector) error {
+	patch, ok := dst.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(dst.Annotations, ConversionDataAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	converted, err := json.Marshal(&conversionData{Spec: dst.Spec, Status: dst.Status})
+	if err != nil {
+		return err
+	}
+	restored, err := jsonpatch.MergePatch(converted, []byte(patch))
+	if err != nil {
+		return fmt.Errorf("failed to apply the conversion data: %w", err)
+	}
+	data := conversionData{}
+	if err := json.Unmarshal(restored, &data); err != nil {
+		return fmt.Errorf("failed to apply the conversion data: %w", err)
+	}
+	dst.Spec = data.Spec
+	dst.Status = data.Status
+	return nil
+}
+
+// hasValue returns whether a JSON merge patch sets a value, rather than only removing fields.
+func hasValue(patch map[string]interface{}) bool {
+	for _, value := range patch {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			if hasValue(v) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// hasYAMLAnchors returns true when the YAML document has anchors, aliases or merge keys.
+func hasYAMLAnchors(config string) bool {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(config), &doc); err != nil {
+		return false
+	}
+	var visit func(node *yaml.Node) bool
+	visit = func(node *yaml.Node) bool {
+		if node.Anchor != "" || node.Kind == yaml.AliasNode || node.ShortTag() == "!!merge" {
+			return true
+		}
+		for _, child := range node.Content {
+			if visit(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(&doc)
+}
+
+// sameConfig returns true when the config string decodes to the given config.
+func sameConfig(config string, cfg v1beta1.Config) bool {
+	decoded := &v1beta1.Config{}
+	if err := yaml.Unmarshal([]byte(config), decoded); err != nil {
+		return false
+	}
+	expected, err := json.Marshal(&cfg)
+	if err != nil {
+		return false
+	}
+	actual, err := json.Marshal(decoded)
+	return err == nil && bytes.Equal(expected, actual)
+}
+
 func tov1beta1(in OpenTelemetryCollector) (v1beta1.OpenTelemetryCollector, error) {
 	copy := in.DeepCopy()
 	cfg := &v1beta1.Config{}
 	if err := yaml.Unmarshal([]byte(copy.Spec.Config), cfg); err != nil {
-		return v1beta1.OpenTelemetryCollector{}, errors.New("could not convert config json to v1beta1.Config")
+		return v1beta1.OpenTelemetryCollector{}, fmt.Errorf("could not convert config json to v1beta1.Config: %w", err)
+	}
+	// the anchors of the config are resolved in the structured config, the string is kept to be returned as is
+	delete(copy.Annotations, v1beta1.ConfigSourceAnnotation)
+	if hasYAMLAnchors(copy.Spec.Config) {
+		if copy.Annotations == nil {
+			copy.Annotations = map[string]string{}
+		}
+		copy.Annotations[v1beta1.ConfigSourceAnnotation] = copy.Spec.Config
+	} else if len(copy.Annotations) == 0 {
+		copy.Annotations = nil
 	}
 
 	return v1beta1.OpenTelemetryCollector{
@@ -298,6 +444,16 @@ func tov1alpha1(in v1beta1.OpenTelemetryCollector) (*OpenTelemetryCollector, err
 	if err != nil {
 		return nil, err
 	}
+	if source, ok := copy.Annotations[v1beta1.ConfigSourceAnnotation]; ok {
+		delete(copy.Annotations, v1beta1.ConfigSourceAnnotation)
+		if len(copy.Annotations) == 0 {
+			copy.Annotations = nil
+		}
+		// the config is returned as written, unless it was changed since by a v1beta1 client
+		if sameConfig(source, copy.Spec.Config) {
+			configYaml = source
+		}
+	}
 
 	return &OpenTelemetryCollector{
 		ObjectMeta: copy.ObjectMeta,
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+const fuzzIterations = 200
+
+// conversionFuzzer fills the collectors with random values. The config is not fuzzed, it has to be a valid collector
+// config to be converted.
+func conversionFuzzer(t *testing.T, seed int64) *fuzz.Fuzzer {
+	cfg := v1beta1.Config{}
+	require.NoError(t, yaml.Unmarshal([]byte(collectorCfg), &cfg))
+	return fuzz.NewWithSeed(seed).NilChance(0.3).NumElements(1, 2).Funcs(
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+		func(i *intstr.IntOrString, c fuzz.Continue) {
+			if c.RandBool() {
+				*i = intstr.FromInt32(c.Int31())
+			} else {
+				*i = intstr.FromString(c.RandString())
+			}
+		},
+		func(c *v1beta1.Config, _ fuzz.Continue) {
+			*c = *cfg.DeepCopy()
+		},
+		func(s *string, c fuzz.Continue) {
+			if c.RandBool() {
+				*s = c.RandString()
+			}
+		},
+		func(m *metav1.Time, c fuzz.Continue) {
+			// the times are serialized with a second precision
+			*m = metav1.Unix(c.Int63n(1<<31), 0)
+		},
+		func(a *v1beta1.AnyConfig, c fuzz.Continue) {
+			// gofuzz can't fill interface values, the parts of the config hold plain maps of strings
+			if c.RandBool() {
+				a.Object = map[string]interface{}{c.RandString(): map[string]interface{}{c.RandString(): c.RandString()}}
+			}
+		},
+		func(e *metav1.ManagedFieldsEntry, c fuzz.Continue) {
+			c.FuzzNoCustom(e)
+			// a nil fieldsV1 is dropped by the serialization, and the random bytes aren't valid JSON
+			e.FieldsV1 = &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{}}`)}
+		},
+		func(p *v1beta1.TargetAllocatorPrometheusCR, c fuzz.Continue) {
+			c.FuzzNoCustom(p)
+			// the conversion to v1beta1 always sets the selectors
+			if p.PodMonitorSelector == nil {
+				p.PodMonitorSelector = &metav1.LabelSelector{}
+			}
+			if p.ServiceMonitorSelector == nil {
+				p.ServiceMonitorSelector = &metav1.LabelSelector{}
+			}
+		},
+	)
+}
+
+func marshalSpecAndStatus(t *testing.T, otelcol *v1beta1.OpenTelemetryCollector) string {
+	data, err := json.Marshal(&conversionData{Spec: otelcol.Spec, Status: otelcol.Status})
+	require.NoError(t, err)
+	return string(data)
+}
+
+// TestFuzzConversionRoundTripHub verifies that a v1beta1 collector converted to v1alpha1 and back is unchanged, so
+// that v1alpha1 clients updating a collector don't lose the fields only available in v1beta1.
+func TestFuzzConversionRoundTripHub(t *testing.T) {
+	for i := 0; i < fuzzIterations; i++ {
+		f := conversionFuzzer(t, int64(i))
+		original := v1beta1.OpenTelemetryCollector{}
+		f.Fuzz(&original.Spec)
+		f.Fuzz(&original.Status)
+
+		spoke := OpenTelemetryCollector{}
+		require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+		hub := v1beta1.OpenTelemetryCollector{}
+		require.NoError(t, spoke.ConvertTo(&hub))
+
+		assert.JSONEq(t, marshalSpecAndStatus(t, &original), marshalSpecAndStatus(t, &hub), "seed %d", i)
+		assert.NotContains(t, hub.Annotations, ConversionDataAnnotation)
+	}
+}
+
+// TestFuzzConversionRoundTripSpoke verifies that a v1alpha1 collector converted to v1beta1 is unchanged by a round
+// trip through v1alpha1.
+func TestFuzzConversionRoundTripSpoke(t *testing.T) {
+	for i := 0; i < fuzzIterations; i++ {
+		f := conversionFuzzer(t, int64(i))
+		original := OpenTelemetryCollector{}
+		f.Fuzz(&original.Spec)
+		original.Spec.Config = collectorCfg
+
+		hub := v1beta1.OpenTelemetryCollector{}
+		require.NoError(t, original.ConvertTo(&hub))
+		spoke := OpenTelemetryCollector{}
+		require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+		roundTrip := v1beta1.OpenTelemetryCollector{}
+		require.NoError(t, spoke.ConvertTo(&roundTrip))
+
+		assert.JSONEq(t, marshalSpecAndStatus(t, &hub), marshalSpecAndStatus(t, &roundTrip), "seed %d", i)
+	}
+}
+
+func TestConversionPreservesV1beta1Fields(t *testing.T) {
+	original := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "otel",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				ServiceAccount: "otelcol",
+			},
+			DNSPolicy: "None",
+			TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+				PrometheusCR: v1beta1.TargetAllocatorPrometheusCR{
+					PodMonitorSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpExists}},
+					},
+					ServiceMonitorSelector: &metav1.LabelSelector{},
+				},
+			},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{
+			Conditions: []metav1.Condition{{Type: v1beta1.ConditionTypeDegraded, Status: metav1.ConditionFalse, Reason: "UpgradeSucceeded"}},
+		},
+	}
+
+	spoke := OpenTelemetryCollector{}
+	require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+	assert.Contains(t, spoke.Annotations, ConversionDataAnnotation)
+	assert.Equal(t, "bar", spoke.Annotations["foo"])
+
+	// fields available in v1alpha1 are updated by v1alpha1 clients
+	spoke.Spec.ServiceAccount = "updated"
+	hub := v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, spoke.ConvertTo(&hub))
+
+	assert.Equal(t, map[string]string{"foo": "bar"}, hub.Annotations)
+	assert.Equal(t, "updated", hub.Spec.ServiceAccount)
+	assert.Equal(t, original.Spec.DNSPolicy, hub.Spec.DNSPolicy)
+	assert.Equal(t, original.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector, hub.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector)
+	assert.Equal(t, original.Status.Conditions, hub.Status.Conditions)
+}
@@ -70,6 +70,108 @@ func Test_tov1beta1_config(t *testing.T) {
 		yamlCfg, err := yaml.Marshal(&cfgV2.Spec.Config)
 		assert.Nil(t, err)
 		assert.YAMLEq(t, collectorCfg, string(yamlCfg))
+		assert.Nil(t, cfgV2.Annotations)
+	})
+	t.Run("config with anchors and merge keys", func(t *testing.T) {
+		config := `---
+x-tls: &tls
+  ca_file: /etc/certs/ca.crt
+  cert_file: /etc/certs/tls.crt
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+        tls: *tls
+processors:
+  batch:
+exporters:
+  otlp/a:
+    endpoint: "a:4317"
+    tls:
+      <<: *tls
+      insecure_skip_verify: true
+  otlp/b: &otlp
+    endpoint: "b:4317"
+    tls: *tls
+  otlp/c:
+    <<: *otlp
+    endpoint: "c:4317"
+service:
+  pipelines:
+    traces: &pipeline
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [otlp/a, otlp/b, otlp/c]
+    metrics: *pipeline
+`
+		expected := `---
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+        tls:
+          ca_file: /etc/certs/ca.crt
+          cert_file: /etc/certs/tls.crt
+processors:
+  batch:
+exporters:
+  otlp/a:
+    endpoint: "a:4317"
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+      insecure_skip_verify: true
+  otlp/b:
+    endpoint: "b:4317"
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+  otlp/c:
+    endpoint: "c:4317"
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [otlp/a, otlp/b, otlp/c]
+    metrics:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [otlp/a, otlp/b, otlp/c]
+`
+		cfgV1 := OpenTelemetryCollector{
+			Spec: OpenTelemetryCollectorSpec{
+				Config: config,
+			},
+		}
+
+		cfgV2, err := tov1beta1(cfgV1)
+		require.NoError(t, err)
+
+		yamlCfg, err := cfgV2.Spec.Config.Yaml()
+		require.NoError(t, err)
+		assert.NotContains(t, yamlCfg, "<<")
+		assert.YAMLEq(t, expected, yamlCfg)
+		// the config is kept as written
+		assert.Equal(t, config, cfgV2.Annotations[v1beta1.ConfigSourceAnnotation])
+
+		cfgV1Back, err := tov1alpha1(cfgV2)
+		require.NoError(t, err)
+		assert.Equal(t, config, cfgV1Back.Spec.Config)
+		assert.NotContains(t, cfgV1Back.Annotations, v1beta1.ConfigSourceAnnotation)
+
+		// the config changed by a v1beta1 client is returned expanded
+		cfgV2.Spec.Config.Exporters.Object["otlp/d"] = map[string]interface{}{"endpoint": "d:4317"}
+		cfgV1Back, err = tov1alpha1(cfgV2)
+		require.NoError(t, err)
+		assert.NotContains(t, cfgV1Back.Spec.Config, "*tls")
+		assert.Contains(t, cfgV1Back.Spec.Config, "otlp/d")
+		assert.NotContains(t, cfgV1Back.Annotations, v1beta1.ConfigSourceAnnotation)
 	})
 	t.Run("invalid config", func(t *testing.T) {
 		config := `!!!`
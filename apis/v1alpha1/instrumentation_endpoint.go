@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const defaultOTLPGRPCPort int32 = 4317
+
+// defaultExporterEndpoint sets the exporter endpoint of an Instrumentation without endpoint to the first collector of
+// its namespace with an OTLP gRPC receiver. Deployment and statefulset collectors are reached through their Service and
+// are preferred over sidecars, reached on localhost. Collectors of the same mode are sorted by name. The selected
+// collector is recorded in the annotations, and reported in the status.
+func (w InstrumentationWebhook) defaultExporterEndpoint(ctx context.Context, r *Instrumentation) error {
+	if r.Spec.Exporter.Endpoint != "" {
+		if r.Spec.Exporter.Endpoint != r.Annotations[constants.AnnotationDefaultExporterEndpoint] {
+			// the endpoint was set by the user
+			delete(r.Annotations, constants.AnnotationDefaultExporterEndpoint)
+			delete(r.Annotations, constants.AnnotationDefaultExporterEndpointCollector)
+		}
+		return nil
+	}
+	if w.reader == nil {
+		return nil
+	}
+
+	collectors := &v1beta1.OpenTelemetryCollectorList{}
+	if err := w.reader.List(ctx, collectors, client.InNamespace(r.Namespace)); err != nil {
+		// the default is a best effort, the endpoint is left unset rather than rejecting the Instrumentation
+		w.logger.Error(err, "failed to list the collectors to default the exporter endpoint", "namespace", r.Namespace, "name", r.Name)
+		return nil
+	}
+	collector, endpoint := selectExporterEndpoint(collectors.Items)
+	if collector == "" {
+		return nil
+	}
+	r.Spec.Exporter.Endpoint = endpoint
+	if r.Annotations == nil {
+		r.Annotations = map[string]string{}
+	}
+	r.Annotations[constants.AnnotationDefaultExporterEndpoint] = endpoint
+	r.Annotations[constants.AnnotationDefaultExporterEndpointCollector] = collector
+	return nil
+}
+
+// selectExporterEndpoint returns the name of the collector the exporter endpoint is defaulted to, and the endpoint.
+func selectExporterEndpoint(collectors []v1beta1.OpenTelemetryCollector) (string, string) {
+	sort.Slice(collectors, func(i, j int) bool {
+		gatewayI, gatewayJ := collectors[i].Spec.Mode != v1beta1.ModeSidecar, collectors[j].Spec.Mode != v1beta1.ModeSidecar
+		if gatewayI != gatewayJ {
+			return gatewayI
+		}
+		return collectors[i].Name < collectors[j].Name
+	})
+	for _, collector := range collectors {
+		if collector.DeletionTimestamp != nil {
+			continue
+		}
+		port, ok := otlpPort(collector.Spec.Config)
+		if !ok {
+			continue
+		}
+		switch collector.Spec.Mode {
+		case v1beta1.ModeDeployment, v1beta1.ModeStatefulSet:
+			service := naming.Service(collector.Name)
+			// the Service is named after the resource name pinned for the collector, when it is set
+			if pinned := collector.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+				service = naming.DNSName(naming.Truncate("%s", 63, pinned))
+			}
+			return collector.Name, fmt.Sprintf("http://%s:%d", service, port)
+		case v1beta1.ModeSidecar:
+			return collector.Name, fmt.Sprintf("http://localhost:%d", port)
+		}
+	}
+	return "", ""
+}
+
+// otlpPort returns the gRPC port of the first OTLP receiver of the collector config with the gRPC protocol enabled,
+// which the endpoint of the exporter defaults to for all the languages. The receivers with only the HTTP protocol are
+// skipped, as the Java, Node.js, Apache HTTPD and Nginx auto-instrumentations export with OTLP/gRPC.
+func otlpPort(cfg v1beta1.Config) (int32, bool) {
+	names := make([]string, 0, len(cfg.Receivers.Object))
+	for name := range cfg.Receivers.Object {
+		if components.ComponentType(name) == "otlp" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		receiver, ok := cfg.Receivers.Object[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		protocols, ok := receiver["protocols"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		settings, enabled := protocols["grpc"]
+		if !enabled {
+			continue
+		}
+		if settings, ok := settings.(map[string]interface{}); ok {
+			if endpoint, ok := settings["endpoint"].(string); ok {
+				if port, err := components.PortFromEndpoint(endpoint); err == nil {
+					return port, true
+				}
+			}
+		}
+		return defaultOTLPGRPCPort, true
+	}
+	return 0, false
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+func collectorWithReceivers(name string, mode v1beta1.Mode, receivers map[string]interface{}) v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: mode,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: receivers},
+			},
+		},
+	}
+}
+
+func otlpReceiver(protocols map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"otlp": map[string]interface{}{"protocols": protocols},
+	}
+}
+
+func TestSelectExporterEndpoint(t *testing.T) {
+	for _, tt := range []struct {
+		desc              string
+		collectors        []v1beta1.OpenTelemetryCollector
+		expectedCollector string
+		expectedEndpoint  string
+	}{
+		{
+			desc: "no collector",
+		},
+		{
+			desc: "gateway preferred over sidecar",
+			collectors: []v1beta1.OpenTelemetryCollector{
+				collectorWithReceivers("a-sidecar", v1beta1.ModeSidecar, otlpReceiver(map[string]interface{}{"grpc": nil})),
+				collectorWithReceivers("z-gateway", v1beta1.ModeDeployment, otlpReceiver(map[string]interface{}{"grpc": nil})),
+			},
+			expectedCollector: "z-gateway",
+			expectedEndpoint:  "http://z-gateway-collector:4317",
+		},
+		{
+			desc: "gateways sorted by name",
+			collectors: []v1beta1.OpenTelemetryCollector{
+				collectorWithReceivers("b", v1beta1.ModeStatefulSet, otlpReceiver(map[string]interface{}{"grpc": nil})),
+				collectorWithReceivers("a", v1beta1.ModeDeployment, otlpReceiver(map[string]interface{}{"grpc": nil})),
+			},
+			expectedCollector: "a",
+			expectedEndpoint:  "http://a-collector:4317",
+		},
+		{
+			desc: "sidecar",
+			collectors: []v1beta1.OpenTelemetryCollector{
+				collectorWithReceivers("agent", v1beta1.ModeDaemonSet, otlpReceiver(map[string]interface{}{"grpc": nil})),
+				collectorWithReceivers("sidecar", v1beta1.ModeSidecar, otlpReceiver(map[string]interface{}{"grpc": nil})),
+			},
+			expectedCollector: "sidecar",
+			expectedEndpoint:  "http://localhost:4317",
+		},
+		{
+			desc: "grpc used over http",
+			collectors: []v1beta1.OpenTelemetryCollector{
+				collectorWithReceivers("gateway", v1beta1.ModeDeployment, otlpReceiver(map[string]interface{}{"grpc": nil, "http": nil})),
+			},
+			expectedCollector: "gateway",
+			expectedEndpoint:  "http://gateway-collector:4317",
+		},
+		{
+			desc: "http only",
+			collectors: []v1beta1.OpenTelemetryCollector{
+				collectorWithReceivers("gateway", v1beta1.ModeDeployment, otlpReceiver(map[string]interface{}{"http": nil})),
+			},
+		},
+		{
+			desc: "custom port and collector without otlp receiver",
+			collectors: []v1beta1.OpenTelemetryCollector{
+				collectorWithReceivers("a", v1beta1.ModeDeployment, map[string]interface{}{"jaeger": map[string]interface{}{}}),
+				collectorWithReceivers("b", v1beta1.ModeDeployment, map[string]interface{}{
+					"otlp/custom": map[string]interface{}{
+						"protocols": map[string]interface{}{
+							"grpc": map[string]interface{}{"endpoint": "0.0.0.0:14317"},
+						},
+					},
+				}),
+			},
+			expectedCollector: "b",
+			expectedEndpoint:  "http://b-collector:14317",
+		},
+		{
+			desc: "pinned resource name",
+			collectors: []v1beta1.OpenTelemetryCollector{
+				func() v1beta1.OpenTelemetryCollector {
+					otelcol := collectorWithReceivers("gateway", v1beta1.ModeDeployment, otlpReceiver(map[string]interface{}{"grpc": nil}))
+					otelcol.Annotations = map[string]string{v1beta1.ResourceNameAnnotation: "otel-default-gateway"}
+					return otelcol
+				}(),
+			},
+			expectedCollector: "gateway",
+			expectedEndpoint:  "http://otel-default-gateway:4317",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			collector, endpoint := selectExporterEndpoint(tt.collectors)
+			assert.Equal(t, tt.expectedCollector, collector)
+			assert.Equal(t, tt.expectedEndpoint, endpoint)
+		})
+	}
+}
+
+func TestDefaultExporterEndpoint(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	collector := collectorWithReceivers("gateway", v1beta1.ModeDeployment, otlpReceiver(map[string]interface{}{"grpc": nil}))
+	w := InstrumentationWebhook{reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&collector).Build()}
+
+	inst := &Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instrumentation",
+			Namespace: "default",
+		},
+	}
+	require.NoError(t, w.defaultExporterEndpoint(context.Background(), inst))
+	assert.Equal(t, "http://gateway-collector:4317", inst.Spec.Exporter.Endpoint)
+	assert.Equal(t, "gateway", inst.Annotations[constants.AnnotationDefaultExporterEndpointCollector])
+
+	// defaulting again keeps the endpoint
+	require.NoError(t, w.defaultExporterEndpoint(context.Background(), inst))
+	assert.Equal(t, "gateway", inst.Annotations[constants.AnnotationDefaultExporterEndpointCollector])
+
+	// the endpoint set by the user is not recorded as defaulted
+	inst.Spec.Exporter.Endpoint = "http://otel-collector:4317"
+	require.NoError(t, w.defaultExporterEndpoint(context.Background(), inst))
+	assert.Equal(t, "http://otel-collector:4317", inst.Spec.Exporter.Endpoint)
+	assert.NotContains(t, inst.Annotations, constants.AnnotationDefaultExporterEndpoint)
+	assert.NotContains(t, inst.Annotations, constants.AnnotationDefaultExporterEndpointCollector)
+}
+
+func TestDefaultExporterEndpointListFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			return errors.New("unavailable")
+		},
+	}).Build()
+	w := InstrumentationWebhook{logger: logr.Discard(), reader: reader}
+
+	inst := &Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instrumentation",
+			Namespace: "default",
+		},
+	}
+	// the Instrumentation is admitted without endpoint
+	require.NoError(t, w.defaultExporterEndpoint(context.Background(), inst))
+	assert.Empty(t, inst.Spec.Exporter.Endpoint)
+	assert.NotContains(t, inst.Annotations, constants.AnnotationDefaultExporterEndpointCollector)
+}
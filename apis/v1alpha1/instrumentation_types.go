@@ -298,8 +298,62 @@ type Nginx struct {
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
 }
 
+// DefaultExporterEndpoint is the collector the exporter endpoint was defaulted to.
+type DefaultExporterEndpoint struct {
+	// Collector is the name of the OpenTelemetryCollector, in the namespace of the Instrumentation.
+	Collector string `json:"collector"`
+	// Endpoint is the exporter endpoint, the Service of the collector or localhost for a sidecar.
+	Endpoint string `json:"endpoint"`
+}
+
 // InstrumentationStatus defines status of the instrumentation.
 type InstrumentationStatus struct {
+	// DefaultExporterEndpoint is set when the Instrumentation does not set an exporter endpoint and the endpoint was
+	// defaulted to a collector of its namespace.
+	// +optional
+	DefaultExporterEndpoint *DefaultExporterEndpoint `json:"defaultExporterEndpoint,omitempty"`
+	// Injection summarizes the workloads currently injected with the Instrumentation, and the auto-instrumentation
+	// images they run, e.g. to plan the upgrades of the images.
+	// +optional
+	Injection *InstrumentationInjection `json:"injection,omitempty"`
+}
+
+// InstrumentationInjection summarizes the workloads currently injected with the Instrumentation.
+type InstrumentationInjection struct {
+	// Namespaces are the namespaces of the injected workloads.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Workloads are the injected workloads.
+	// +optional
+	// +listType=atomic
+	Workloads []InjectedWorkload `json:"workloads,omitempty"`
+	// Agents are the auto-instrumentation images running in the injected pods.
+	// +optional
+	// +listType=atomic
+	Agents []InjectedAgent `json:"agents,omitempty"`
+}
+
+// InjectedWorkload is a workload whose pods are injected with the Instrumentation.
+type InjectedWorkload struct {
+	// Namespace is the namespace of the workload.
+	Namespace string `json:"namespace"`
+	// Kind is the kind of the workload, e.g. Deployment, or Pod for the pods without controller.
+	Kind string `json:"kind"`
+	// Name is the name of the workload.
+	Name string `json:"name"`
+	// Pods is the number of injected pods of the workload.
+	Pods int32 `json:"pods"`
+}
+
+// InjectedAgent is an auto-instrumentation image running in pods injected with the Instrumentation.
+type InjectedAgent struct {
+	// Language is the language of the auto-instrumentation, e.g. java.
+	Language string `json:"language"`
+	// Image is the auto-instrumentation image.
+	Image string `json:"image"`
+	// Pods is the number of injected pods running the image.
+	Pods int32 `json:"pods"`
 }
 
 // +kubebuilder:object:root=true
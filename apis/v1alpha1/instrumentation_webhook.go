@@ -17,14 +17,17 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -53,6 +56,9 @@ type InstrumentationWebhook struct {
 	logger logr.Logger
 	cfg    config.Config
 	scheme *runtime.Scheme
+	// reader lists the collectors the exporter endpoint is defaulted to, and the namespaces and the pods injecting the
+	// auto-instrumentation, to warn about the languages without image. Both are skipped when it is nil.
+	reader client.Reader
 }
 
 func (w InstrumentationWebhook) Default(ctx context.Context, obj runtime.Object) error {
@@ -60,6 +66,9 @@ func (w InstrumentationWebhook) Default(ctx context.Context, obj runtime.Object)
 	if !ok {
 		return fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
+	if err := w.defaultExporterEndpoint(ctx, instrumentation); err != nil {
+		return err
+	}
 	return w.defaulter(instrumentation)
 }
 
@@ -68,7 +77,11 @@ func (w InstrumentationWebhook) ValidateCreate(ctx context.Context, obj runtime.
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
-	return w.validate(inst)
+	warnings, err := w.validate(inst)
+	if err != nil {
+		return warnings, err
+	}
+	return append(warnings, w.validateImages(ctx, inst)...), nil
 }
 
 func (w InstrumentationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -76,7 +89,11 @@ func (w InstrumentationWebhook) ValidateUpdate(ctx context.Context, oldObj, newO
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", newObj)
 	}
-	return w.validate(inst)
+	warnings, err := w.validate(inst)
+	if err != nil {
+		return warnings, err
+	}
+	return append(warnings, w.validateImages(ctx, inst)...), nil
 }
 
 func (w InstrumentationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -236,13 +253,146 @@ func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings
 				return warnings, fmt.Errorf("spec.sampler.argument is not a valid argument for sampler %s: %w", r.Spec.Sampler.Type, err)
 			}
 		}
-	case AlwaysOn, AlwaysOff, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
+	case AlwaysOn, AlwaysOff, ParentBasedAlwaysOn, ParentBasedAlwaysOff:
+		if r.Spec.Sampler.Argument != "" {
+			warnings = append(warnings, fmt.Sprintf("spec.sampler.argument is ignored by sampler %s", r.Spec.Sampler.Type))
+		}
+	case XRaySampler:
 	default:
 		return warnings, fmt.Errorf("spec.sampler.type is not valid: %s", r.Spec.Sampler.Type)
 	}
+
+	for _, propagator := range r.Spec.Propagators {
+		switch propagator {
+		case TraceContext, Baggage, B3, B3Multi, Jaeger, XRay, OTTrace, None:
+		default:
+			return warnings, fmt.Errorf("spec.propagators contains an unknown propagator: %s", propagator)
+		}
+	}
+
+	if r.Spec.Exporter.Endpoint != "" {
+		if err := validateEndpoint(r.Spec.Exporter.Endpoint); err != nil {
+			return warnings, fmt.Errorf("spec.exporter.endpoint is not valid: %w", err)
+		}
+	}
+	paths, envs := []string{"spec.env"}, [][]corev1.EnvVar{r.Spec.Env}
+	for _, language := range instrumentationLanguages(r) {
+		paths = append(paths, fmt.Sprintf("spec.%s.env", language.field))
+		envs = append(envs, language.env)
+	}
+	for i := range envs {
+		for _, env := range envs[i] {
+			// the values referencing other variables are only known in the pod
+			if !isExporterEndpointEnv(env.Name) || env.ValueFrom != nil || env.Value == "" || strings.Contains(env.Value, "$(") {
+				continue
+			}
+			if err := validateEndpoint(env.Value); err != nil {
+				return warnings, fmt.Errorf("%s %s is not valid: %w", paths[i], env.Name, err)
+			}
+		}
+	}
 	return warnings, nil
 }
 
+// isExporterEndpointEnv returns whether the env var sets an OTLP exporter endpoint, e.g. OTEL_EXPORTER_OTLP_ENDPOINT
+// or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT.
+func isExporterEndpointEnv(name string) bool {
+	return strings.HasPrefix(name, "OTEL_EXPORTER_OTLP_") && strings.HasSuffix(name, "_ENDPOINT")
+}
+
+// validateEndpoint checks that an exporter endpoint is an URL with a http or https scheme, as expected by the SDKs.
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s should be an URL with a http or https scheme", endpoint)
+	}
+	return nil
+}
+
+// validateImages warns about the languages whose auto-instrumentation is injected with the Instrumentation, while
+// their image is not set. The annotations of all the namespaces, and of the pods in the namespace of the
+// Instrumentation are checked.
+func (w InstrumentationWebhook) validateImages(ctx context.Context, r *Instrumentation) admission.Warnings {
+	if w.reader == nil {
+		return nil
+	}
+	var missing []instrumentationLanguage
+	for _, language := range instrumentationLanguages(r) {
+		if language.image == "" {
+			missing = append(missing, language)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var objects []metav1.ObjectMeta
+	namespaces := &corev1.NamespaceList{}
+	if err := w.reader.List(ctx, namespaces); err != nil {
+		w.logger.Error(err, "failed to list the namespaces injecting the auto-instrumentation")
+	}
+	for _, ns := range namespaces.Items {
+		objects = append(objects, ns.ObjectMeta)
+	}
+	pods := &corev1.PodList{}
+	if err := w.reader.List(ctx, pods, client.InNamespace(r.Namespace)); err != nil {
+		w.logger.Error(err, "failed to list the pods injecting the auto-instrumentation")
+	}
+	for _, pod := range pods.Items {
+		objects = append(objects, pod.ObjectMeta)
+	}
+
+	var warnings admission.Warnings
+	for _, language := range missing {
+		for _, obj := range objects {
+			// namespaces are cluster-scoped, the namespace of a namespace is its name
+			namespace := obj.Namespace
+			if namespace == "" {
+				namespace = obj.Name
+			}
+			if injects(obj.Annotations[language.annotation], r, namespace == r.Namespace) {
+				warnings = append(warnings, fmt.Sprintf("spec.%s.image is not set, while the %s annotation injects the Instrumentation", language.field, language.annotation))
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+// injects returns whether the value of an inject annotation refers to the Instrumentation. The "true" value and the
+// name of the Instrumentation only refer to an Instrumentation in the same namespace.
+func injects(value string, r *Instrumentation, sameNamespace bool) bool {
+	switch value {
+	case "", "false":
+		return false
+	case "true", r.Name:
+		return sameNamespace
+	}
+	return value == r.Namespace+"/"+r.Name
+}
+
+type instrumentationLanguage struct {
+	field      string
+	annotation string
+	image      string
+	env        []corev1.EnvVar
+}
+
+func instrumentationLanguages(r *Instrumentation) []instrumentationLanguage {
+	return []instrumentationLanguage{
+		{field: "java", annotation: constants.AnnotationInjectJava, image: r.Spec.Java.Image, env: r.Spec.Java.Env},
+		{field: "nodejs", annotation: constants.AnnotationInjectNodeJS, image: r.Spec.NodeJS.Image, env: r.Spec.NodeJS.Env},
+		{field: "python", annotation: constants.AnnotationInjectPython, image: r.Spec.Python.Image, env: r.Spec.Python.Env},
+		{field: "dotnet", annotation: constants.AnnotationInjectDotNet, image: r.Spec.DotNet.Image, env: r.Spec.DotNet.Env},
+		{field: "go", annotation: constants.AnnotationInjectGo, image: r.Spec.Go.Image, env: r.Spec.Go.Env},
+		{field: "apacheHttpd", annotation: constants.AnnotationInjectApacheHttpd, image: r.Spec.ApacheHttpd.Image, env: r.Spec.ApacheHttpd.Env},
+		{field: "nginx", annotation: constants.AnnotationInjectNginx, image: r.Spec.Nginx.Image, env: r.Spec.Nginx.Env},
+	}
+}
+
 func validateJaegerRemoteSamplerArgument(argument string) error {
 	parts := strings.Split(argument, ",")
 
@@ -257,6 +407,9 @@ func validateJaegerRemoteSamplerArgument(argument string) error {
 			if kv[1] == "" {
 				return fmt.Errorf("endpoint cannot be empty")
 			}
+			if err := validateEndpoint(kv[1]); err != nil {
+				return fmt.Errorf("invalid endpoint: %w", err)
+			}
 		case "pollingIntervalMs":
 			if _, err := strconv.Atoi(kv[1]); err != nil {
 				return fmt.Errorf("invalid pollingIntervalMs: %s", kv[1])
@@ -269,6 +422,8 @@ func validateJaegerRemoteSamplerArgument(argument string) error {
 			if rate < 0 || rate > 1 {
 				return fmt.Errorf("initialSamplingRate should be in rage [0..1]: %s", kv[1])
 			}
+		default:
+			return fmt.Errorf("unknown argument: %s, the supported arguments are endpoint, pollingIntervalMs and initialSamplingRate", kv[0])
 		}
 	}
 	return nil
@@ -288,6 +443,7 @@ func SetupInstrumentationWebhook(mgr ctrl.Manager, cfg config.Config) error {
 		mgr.GetScheme(),
 		cfg,
 	)
+	ivw.reader = mgr.GetClient()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&Instrumentation{}).
 		WithValidator(ivw).
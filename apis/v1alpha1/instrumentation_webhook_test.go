@@ -19,9 +19,16 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
 func TestInstrumentationDefaultingWebhook(t *testing.T) {
@@ -113,6 +120,68 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "argument is ignored",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type:     AlwaysOn,
+						Argument: "0.5",
+					},
+				},
+			},
+			warnings: []string{"spec.sampler.argument is ignored by sampler always_on"},
+		},
+		{
+			name: "unknown propagator",
+			err:  "spec.propagators contains an unknown propagator: w3c",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler:     Sampler{Type: AlwaysOn},
+					Propagators: []Propagator{TraceContext, "w3c"},
+				},
+			},
+		},
+		{
+			name: "exporter endpoint without scheme",
+			err:  "spec.exporter.endpoint is not valid: otel-collector:4317 should be an URL with a http or https scheme",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler:  Sampler{Type: AlwaysOn},
+					Exporter: Exporter{Endpoint: "otel-collector:4317"},
+				},
+			},
+		},
+		{
+			name: "exporter endpoint",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler:  Sampler{Type: AlwaysOn},
+					Exporter: Exporter{Endpoint: "http://otel-collector:4317"},
+				},
+			},
+		},
+		{
+			name: "language exporter endpoint is not an url",
+			err:  "spec.python.env OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is not valid",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{Type: AlwaysOn},
+					Python: Python{
+						Env: []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: "otel-collector"}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter endpoint referencing a variable",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{Type: AlwaysOn},
+					Env:     []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "$(COLLECTOR_ENDPOINT)"}},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -159,6 +228,16 @@ func TestInstrumentationJaegerRemote(t *testing.T) {
 			err:  "endpoint cannot be empty",
 			arg:  "endpoint=",
 		},
+		{
+			name: "endpoint is not an url",
+			err:  "invalid endpoint",
+			arg:  "endpoint=jaeger-collector:14250",
+		},
+		{
+			name: "unknown argument",
+			err:  "unknown argument: samplingRate",
+			arg:  "endpoint=http://jaeger-collector:14250/,samplingRate=0.5",
+		},
 		{
 			name: "correct jaeger remote sampler configuration",
 			arg:  "endpoint=http://jaeger-collector:14250/,initialSamplingRate=0.99,pollingIntervalMs=1000",
@@ -198,3 +277,58 @@ func TestInstrumentationJaegerRemote(t *testing.T) {
 		}
 	}
 }
+
+func TestInstrumentationImagesWarning(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "apps",
+				Annotations: map[string]string{constants.AnnotationInjectPython: "observability/my-instrumentation"},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "other",
+				Annotations: map[string]string{constants.AnnotationInjectNodeJS: "true"},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "app",
+				Namespace:   "observability",
+				Annotations: map[string]string{constants.AnnotationInjectGo: "my-instrumentation"},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "disabled",
+				Namespace:   "observability",
+				Annotations: map[string]string{constants.AnnotationInjectJava: "false"},
+			},
+		},
+	).Build()
+
+	inst := &Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instrumentation",
+			Namespace: "observability",
+		},
+		Spec: InstrumentationSpec{
+			Sampler: Sampler{Type: AlwaysOn},
+		},
+	}
+	warnings, err := InstrumentationWebhook{reader: reader}.ValidateCreate(context.Background(), inst)
+	require.NoError(t, err)
+	assert.Equal(t, admission.Warnings{
+		"spec.python.image is not set, while the instrumentation.opentelemetry.io/inject-python annotation injects the Instrumentation",
+		"spec.go.image is not set, while the instrumentation.opentelemetry.io/inject-go annotation injects the Instrumentation",
+	}, warnings)
+
+	inst.Spec.Python.Image = "python-img:1"
+	inst.Spec.Go.Image = "go-img:1"
+	warnings, err = InstrumentationWebhook{reader: reader}.ValidateUpdate(context.Background(), nil, inst)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
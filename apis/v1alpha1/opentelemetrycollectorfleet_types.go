@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type (
+	// FleetClusterProvider represents how the managed clusters are discovered and how the collectors are
+	// distributed to them.
+	// +kubebuilder:validation:Enum=ocm;clusterapi
+	FleetClusterProvider string
+
+	// FleetClusterState represents the rollout state of the collector in a managed cluster.
+	FleetClusterState string
+)
+
+const (
+	// FleetClusterProviderOCM selects Open Cluster Management ManagedClusters and distributes the collector
+	// with a ManifestWork in each cluster namespace.
+	FleetClusterProviderOCM FleetClusterProvider = "ocm"
+	// FleetClusterProviderClusterAPI selects Cluster API Clusters and distributes the collector with a
+	// ClusterResourceSet in each namespace containing selected clusters.
+	FleetClusterProviderClusterAPI FleetClusterProvider = "clusterapi"
+
+	// FleetClusterStatePending means that the collector has not been applied to the cluster yet.
+	FleetClusterStatePending FleetClusterState = "Pending"
+	// FleetClusterStateApplied means that the collector has been applied to the cluster.
+	FleetClusterStateApplied FleetClusterState = "Applied"
+	// FleetClusterStateAvailable means that the collector has been applied to the cluster and is available.
+	FleetClusterStateAvailable FleetClusterState = "Available"
+	// FleetClusterStateDegraded means that the collector could not be applied to the cluster, or is not available.
+	FleetClusterStateDegraded FleetClusterState = "Degraded"
+
+	// FleetConditionReady is the condition type set when all the selected clusters run the collector.
+	FleetConditionReady = "Ready"
+)
+
+// OpenTelemetryCollectorFleetSpec defines the desired state of OpenTelemetryCollectorFleet.
+type OpenTelemetryCollectorFleetSpec struct {
+	// Provider determines how the managed clusters are selected and how the collector is distributed to them.
+	// +optional
+	// +kubebuilder:default:=ocm
+	Provider FleetClusterProvider `json:"provider,omitempty"`
+	// ClusterSelector selects the managed clusters the collector is rolled out to, based on the labels of the
+	// ManagedClusters (ocm) or of the Clusters (clusterapi). An empty selector selects all the ManagedClusters,
+	// and is not supported with clusterapi.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// Template is the OpenTelemetryCollector created in every selected cluster.
+	// +required
+	Template OpenTelemetryCollectorFleetTemplate `json:"template"`
+}
+
+// OpenTelemetryCollectorFleetTemplate describes the OpenTelemetryCollector created in every selected cluster.
+type OpenTelemetryCollectorFleetTemplate struct {
+	// Metadata of the OpenTelemetryCollector.
+	// +required
+	Metadata FleetTemplateMetadata `json:"metadata"`
+	// Spec of the OpenTelemetryCollector, as defined by the opentelemetry.io/v1beta1 API.
+	// +required
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// FleetTemplateMetadata is the metadata of the OpenTelemetryCollector created in every selected cluster.
+type FleetTemplateMetadata struct {
+	// Name of the OpenTelemetryCollector. Defaults to the name of the fleet.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace of the OpenTelemetryCollector in the managed clusters.
+	// +required
+	Namespace string `json:"namespace"`
+	// Labels to set on the OpenTelemetryCollector.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations to set on the OpenTelemetryCollector.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// FleetClusterStatus is the rollout status of the collector in a managed cluster.
+type FleetClusterStatus struct {
+	// Name of the managed cluster.
+	Name string `json:"name"`
+	// Namespace of the managed cluster, for the providers with namespaced clusters.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// State of the collector in the managed cluster.
+	State FleetClusterState `json:"state"`
+	// Message gives details about the state, e.g. why the collector is degraded.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OpenTelemetryCollectorFleetStatus defines the observed state of OpenTelemetryCollectorFleet.
+type OpenTelemetryCollectorFleetStatus struct {
+	// ObservedGeneration is the generation of the fleet the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Rollout summarizes the rollout of the collector, in the form of "ready/selected" clusters. A cluster is ready
+	// when the collector is available (ocm) or applied (clusterapi).
+	// +optional
+	Rollout string `json:"rollout,omitempty"`
+	// SelectedClusters is the number of managed clusters selected by the fleet.
+	// +optional
+	SelectedClusters int32 `json:"selectedClusters,omitempty"`
+	// AppliedClusters is the number of managed clusters the collector has been applied to.
+	// +optional
+	AppliedClusters int32 `json:"appliedClusters,omitempty"`
+	// AvailableClusters is the number of managed clusters where the collector is available.
+	// +optional
+	AvailableClusters int32 `json:"availableClusters,omitempty"`
+	// Clusters is the rollout status of the collector in each selected cluster.
+	// +optional
+	// +listType=atomic
+	Clusters []FleetClusterStatus `json:"clusters,omitempty"`
+	// Conditions represent the latest available observations of the fleet.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=otelcolfleet;otelcolfleets
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.provider"
+// +kubebuilder:printcolumn:name="Rollout",type="string",JSONPath=".status.rollout",description="Clusters where the collector is ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector Fleet"
+
+// OpenTelemetryCollectorFleet is the Schema for the opentelemetrycollectorfleets API. It rolls out an
+// OpenTelemetryCollector to the managed clusters of a hub cluster.
+type OpenTelemetryCollectorFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenTelemetryCollectorFleetSpec   `json:"spec,omitempty"`
+	Status OpenTelemetryCollectorFleetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenTelemetryCollectorFleetList contains a list of OpenTelemetryCollectorFleet.
+type OpenTelemetryCollectorFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenTelemetryCollectorFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenTelemetryCollectorFleet{}, &OpenTelemetryCollectorFleetList{})
+}
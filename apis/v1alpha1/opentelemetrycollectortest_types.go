@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// CollectorTestSignal represents a type of telemetry data.
+	// +kubebuilder:validation:Enum=traces;metrics;logs
+	CollectorTestSignal string
+
+	// CollectorTestProtocol represents the OTLP protocol used to send the synthetic data.
+	// +kubebuilder:validation:Enum=grpc;http
+	CollectorTestProtocol string
+
+	// CollectorTestPhase represents the progress of a test.
+	CollectorTestPhase string
+)
+
+const (
+	CollectorTestSignalTraces  CollectorTestSignal = "traces"
+	CollectorTestSignalMetrics CollectorTestSignal = "metrics"
+	CollectorTestSignalLogs    CollectorTestSignal = "logs"
+
+	CollectorTestProtocolGRPC CollectorTestProtocol = "grpc"
+	CollectorTestProtocolHTTP CollectorTestProtocol = "http"
+
+	// CollectorTestPhaseRunning means that the collectors are starting or that the synthetic data is being sent.
+	CollectorTestPhaseRunning CollectorTestPhase = "Running"
+	// CollectorTestPhasePassed means that all the expectations were met.
+	CollectorTestPhasePassed CollectorTestPhase = "Passed"
+	// CollectorTestPhaseFailed means that an expectation was not met, or that the test could not run.
+	CollectorTestPhaseFailed CollectorTestPhase = "Failed"
+)
+
+// OpenTelemetryCollectorTestSpec defines the desired state of OpenTelemetryCollectorTest.
+type OpenTelemetryCollectorTestSpec struct {
+	// Config is the candidate collector configuration, in YAML. The exporters of its pipelines are replaced by an
+	// exporter sending the data to the sink, the connectors are kept.
+	// +required
+	Config string `json:"config"`
+	// Image of the candidate collector. Defaults to the collector image of the operator.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Inputs are the synthetic data sent to the candidate collector.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Inputs []CollectorTestInput `json:"inputs"`
+	// Expectations on the data received by the sink. When empty, the sink is expected to receive data for every
+	// signal of the inputs.
+	// +optional
+	// +listType=atomic
+	Expectations []CollectorTestExpectation `json:"expectations,omitempty"`
+	// Timeout of the test, including the start of the collectors.
+	// +optional
+	// +kubebuilder:default:="5m"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// KeepResources keeps the collectors and the job sending the data once the test completed, for troubleshooting.
+	// By default, they are deleted when the test completes.
+	// +optional
+	KeepResources bool `json:"keepResources,omitempty"`
+}
+
+// CollectorTestInput is synthetic data sent to the candidate collector.
+type CollectorTestInput struct {
+	// Signal of the data.
+	// +required
+	Signal CollectorTestSignal `json:"signal"`
+	// Count is the number of traces, metrics or logs to send.
+	// +optional
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count,omitempty"`
+	// Protocol used to send the data.
+	// +optional
+	// +kubebuilder:default:=grpc
+	Protocol CollectorTestProtocol `json:"protocol,omitempty"`
+	// Endpoint of the candidate collector the data is sent to, as host:port. Defaults to the OTLP port of the
+	// candidate collector Service for the protocol.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Attributes set on the telemetry items.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// CollectorTestExpectation is an expectation on the number of items received by the sink for a signal. The items
+// are the spans, the metric points or the log records.
+type CollectorTestExpectation struct {
+	// Signal of the data.
+	// +required
+	Signal CollectorTestSignal `json:"signal"`
+	// MinItems is the minimum number of items the sink has to receive.
+	// +optional
+	MinItems *int64 `json:"minItems,omitempty"`
+	// MaxItems is the maximum number of items the sink can receive, e.g. 0 to verify that the data is filtered out.
+	// +optional
+	MaxItems *int64 `json:"maxItems,omitempty"`
+}
+
+// CollectorTestResult is the outcome of an expectation.
+type CollectorTestResult struct {
+	// Signal of the data.
+	Signal CollectorTestSignal `json:"signal"`
+	// ReceivedItems is the number of items received by the sink.
+	ReceivedItems int64 `json:"receivedItems"`
+	// Passed is true when the expectation is met.
+	Passed bool `json:"passed"`
+	// Message describes the expectation.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OpenTelemetryCollectorTestStatus defines the observed state of OpenTelemetryCollectorTest.
+type OpenTelemetryCollectorTestStatus struct {
+	// Phase of the test.
+	// +optional
+	Phase CollectorTestPhase `json:"phase,omitempty"`
+	// Message gives details about the phase, e.g. why the test failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the test started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the test passed or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Results of the expectations.
+	// +optional
+	// +listType=atomic
+	Results []CollectorTestResult `json:"results,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=otelcoltest;otelcoltests
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Collector Test"
+// +operator-sdk:csv:customresourcedefinitions:resources={{OpenTelemetryCollector,opentelemetry.io/v1beta1},{Job,batch/v1}}
+
+// OpenTelemetryCollectorTest is the Schema for the opentelemetrycollectortests API. It runs a candidate collector
+// configuration against synthetic data, and verifies the data received by a sink collector.
+type OpenTelemetryCollectorTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenTelemetryCollectorTestSpec   `json:"spec,omitempty"`
+	Status OpenTelemetryCollectorTestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenTelemetryCollectorTestList contains a list of OpenTelemetryCollectorTest.
+type OpenTelemetryCollectorTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenTelemetryCollectorTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenTelemetryCollectorTest{}, &OpenTelemetryCollectorTestList{})
+}
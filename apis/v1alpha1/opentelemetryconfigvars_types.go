@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenTelemetryConfigVarsSpec defines the variables of an OpenTelemetryConfigVars.
+type OpenTelemetryConfigVarsSpec struct {
+	// Vars are the values of the variables, indexed by name. The configs of the OpenTelemetryCollectors reference
+	// them as ${var:NAME}, and the operator replaces the references with the values when rendering the configs.
+	// The names start with a letter or an underscore, followed by letters, digits, underscores, dots or dashes,
+	// and are unique across the OpenTelemetryConfigVars.
+	// +optional
+	Vars map[string]string `json:"vars,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=otelconfigvars
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Config Vars"
+// +operator-sdk:csv:customresourcedefinitions:resources={{OpenTelemetryCollector,opentelemetry.io/v1beta1}}
+
+// OpenTelemetryConfigVars is the Schema for the opentelemetryconfigvars API. It holds variables shared by the
+// configs of the OpenTelemetryCollectors of the cluster, e.g. backend endpoints or tenant IDs, so that changing a
+// value updates all the collectors referencing it.
+type OpenTelemetryConfigVars struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OpenTelemetryConfigVarsSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenTelemetryConfigVarsList contains a list of OpenTelemetryConfigVars.
+type OpenTelemetryConfigVarsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenTelemetryConfigVars `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenTelemetryConfigVars{}, &OpenTelemetryConfigVarsList{})
+}
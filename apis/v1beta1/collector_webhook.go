@@ -16,26 +16,47 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/hashicorp/cronexpr"
+	admissionv1 "k8s.io/api/admission/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
+// configVarScheme is the scheme of the references to the variables of the OpenTelemetryConfigVars.
+const configVarScheme = "var"
+
 var (
 	_ admission.CustomValidator = &CollectorWebhook{}
 	_ admission.CustomDefaulter = &CollectorWebhook{}
+	// providerSchemeRegexp matches the URI schemes of the confmap providers.
+	providerSchemeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*$`)
+	// configProviderReferenceRegexp matches the ${<scheme>:<value>} references of a config, but not the escaped $${.
+	configProviderReferenceRegexp = regexp.MustCompile(`(^|[^$])\$\{([a-zA-Z][a-zA-Z0-9+.-]*):`)
+	// reservedPortGroupNames are the suffixes of the Services generated next to the base Service of the collector.
+	reservedPortGroupNames = []string{"headless", "monitoring"}
 	// targetAllocatorCRPolicyRules are the policy rules required for the CR functionality.
 
 	targetAllocatorCRPolicyRules = []*rbacv1.PolicyRule{
@@ -79,11 +100,19 @@ type CollectorWebhook struct {
 	metrics  *Metrics
 }
 
-func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
+func (c CollectorWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	otelcol, ok := obj.(*OpenTelemetryCollector)
 	if !ok {
 		return fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
+	// the defaults are applied to nested maps of the config, the collector is snapshotted as JSON to diff them
+	var snapshot json.RawMessage
+	if featuregate.EnableAuditLog.IsEnabled() {
+		var err error
+		if snapshot, err = json.Marshal(otelcol); err != nil {
+			return fmt.Errorf("failed to snapshot the collector: %w", err)
+		}
+	}
 	if len(otelcol.Spec.Mode) == 0 {
 		otelcol.Spec.Mode = ModeDeployment
 	}
@@ -159,6 +188,77 @@ func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
 	if len(otelcol.Spec.ManagementState) == 0 {
 		otelcol.Spec.ManagementState = ManagementStateManaged
 	}
+	if err := c.pinResourceName(ctx, otelcol); err != nil {
+		return err
+	}
+	if snapshot != nil {
+		return annotateDefaulting(snapshot, otelcol)
+	}
+	return nil
+}
+
+// annotateDefaulting adds the patch applied by the defaulting to the DefaultingAnnotation of the collector, which is
+// recorded in the audit log of the collector by its next reconciliation. The defaultings made since the last
+// reconciliation are all kept, and nothing is added when nothing was defaulted.
+func annotateDefaulting(snapshot json.RawMessage, otelcol *OpenTelemetryCollector) error {
+	patch, err := auditlog.Diff(snapshot, otelcol)
+	if err != nil {
+		return fmt.Errorf("failed to diff the defaulted collector: %w", err)
+	}
+	if patch == "" {
+		return nil
+	}
+	return auditlog.AddDefaulting(otelcol, patch)
+}
+
+// pinResourceName sets the ResourceNameAnnotation of the new collectors to the name rendered by the collector name
+// template, and keeps the one of the existing collectors when a client replacing them drops it. The annotation can
+// still be changed, to migrate the resources of the collector to another name.
+func (c CollectorWebhook) pinResourceName(ctx context.Context, otelcol *OpenTelemetryCollector) error {
+	if _, ok := otelcol.Annotations[ResourceNameAnnotation]; ok {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		// not defaulting an admission request
+		return nil
+	}
+
+	var name string
+	switch req.Operation {
+	case admissionv1.Create:
+		// the names generated from generateName aren't known yet, these collectors keep the default names
+		if !c.cfg.CollectorNameTemplate().IsSet() || otelcol.Name == "" {
+			return nil
+		}
+		namespace := otelcol.Namespace
+		if namespace == "" {
+			namespace = req.Namespace
+		}
+		name, err = c.cfg.CollectorNameTemplate().Render(config.CollectorNameData{
+			Name:      otelcol.Name,
+			Namespace: namespace,
+			Mode:      string(otelcol.Spec.Mode),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to render the collector name template: %w", err)
+		}
+	case admissionv1.Update:
+		old := &OpenTelemetryCollector{}
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return fmt.Errorf("failed to decode the previous collector: %w", err)
+		}
+		if name = old.Annotations[ResourceNameAnnotation]; name == "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if otelcol.Annotations == nil {
+		otelcol.Annotations = map[string]string{}
+	}
+	otelcol.Annotations[ResourceNameAnnotation] = name
 	return nil
 }
 
@@ -228,6 +328,13 @@ func (c CollectorWebhook) validate(ctx context.Context, r *OpenTelemetryCollecto
 		warnings = append(warnings, fmt.Sprintf("Collector config spec.config has null objects: %s. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.", strings.Join(nullObjects, ", ")))
 	}
 
+	// validate the pinned resource name
+	if name, ok := r.Annotations[ResourceNameAnnotation]; ok {
+		if nameErrs := validation.IsDNS1123Label(name); len(nameErrs) > 0 {
+			return warnings, fmt.Errorf("the %s annotation is incorrect, %q is not a valid DNS label: %s", ResourceNameAnnotation, name, strings.Join(nameErrs, ", "))
+		}
+	}
+
 	// validate volumeClaimTemplates
 	if r.Spec.Mode != ModeStatefulSet && len(r.Spec.VolumeClaimTemplates) > 0 {
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'volumeClaimTemplates'", r.Spec.Mode)
@@ -252,6 +359,178 @@ func (c CollectorWebhook) validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'AdditionalContainers'", r.Spec.Mode)
 	}
 
+	if r.Spec.Mode == ModeSidecar && r.Spec.ServiceMesh != "" {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'serviceMesh'", r.Spec.Mode)
+	}
+
+	if r.Spec.Mode == ModeSidecar && (r.Spec.DNSPolicy != "" || r.Spec.DNSConfig != nil || len(r.Spec.HostAliases) > 0) {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attributes 'dnsPolicy', 'dnsConfig' and 'hostAliases'", r.Spec.Mode)
+	}
+
+	if r.Spec.DNSPolicy == v1.DNSNone && (r.Spec.DNSConfig == nil || len(r.Spec.DNSConfig.Nameservers) == 0) {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec dnsPolicy is set to %s, which requires at least one nameserver in dnsConfig", r.Spec.DNSPolicy)
+	}
+
+	if r.Spec.ConfigMode == ConfigModeStdin {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the config mode %s", r.Spec.Mode, r.Spec.ConfigMode)
+		}
+		if len(r.Spec.Command) == 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec configMode is set to %s, which requires the command to be set", r.Spec.ConfigMode)
+		}
+		// the image can't be inspected, the requirement is only reported
+		warnings = append(warnings, fmt.Sprintf("the configMode %s runs the command with /bin/sh to redirect the config to its standard input, the image must provide it, which the distroless images, e.g. the default collector images, don't", r.Spec.ConfigMode))
+	}
+
+	if err := validateArgs(r); err != nil {
+		return warnings, err
+	}
+
+	if r.Spec.ZoneSpread != nil {
+		if r.Spec.Mode != ModeDeployment {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'zoneSpread'", r.Spec.Mode)
+		}
+		if len(r.Spec.ZoneSpread.Zones) > 0 && r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec zoneSpread configuration is incorrect, zones can't be used with the autoscaler")
+		}
+		if len(r.Spec.ZoneSpread.Zones) == 0 && r.Spec.Replicas != nil && *r.Spec.Replicas < r.Spec.ZoneSpread.MinPerZone {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec zoneSpread configuration is incorrect, replicas must not be lower than minPerZone")
+		}
+	}
+
+	if r.Spec.BlueGreen != nil {
+		if r.Spec.Mode != ModeDeployment {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'blueGreen'", r.Spec.Mode)
+		}
+		if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec blueGreen configuration is incorrect, blue/green rollouts can't be used with the autoscaler")
+		}
+		if r.Spec.ZoneSpread != nil && len(r.Spec.ZoneSpread.Zones) > 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec blueGreen configuration is incorrect, blue/green rollouts can't be used with zones")
+		}
+		if r.Spec.BlueGreen.VerificationWindow != nil && r.Spec.BlueGreen.VerificationWindow.Duration < 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec blueGreen configuration is incorrect, verificationWindow should not be negative")
+		}
+	}
+
+	if r.Spec.ContainerName != "" {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'containerName'", r.Spec.Mode)
+		}
+		if nameErrs := validation.IsDNS1123Label(r.Spec.ContainerName); len(nameErrs) > 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec containerName configuration is incorrect, %s", strings.Join(nameErrs, ", "))
+		}
+	}
+
+	if r.Spec.SplitPipelinesIntoContainers != nil && r.Spec.SplitPipelinesIntoContainers.Enabled {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'splitPipelinesIntoContainers'", r.Spec.Mode)
+		}
+		if err := validateSplitPipelines(r); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec splitPipelinesIntoContainers configuration is incorrect, %w", err)
+		}
+	}
+
+	if len(r.Spec.ConfigSchedules) > 0 {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'configSchedules'", r.Spec.Mode)
+		}
+		if err := validateConfigSchedules(r); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec configSchedules configuration is incorrect, %w", err)
+		}
+	}
+
+	if r.Spec.ExporterFailover != nil {
+		if err := validateExporterFailover(r); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec exporterFailover configuration is incorrect, %w", err)
+		}
+	}
+
+	if r.Spec.ScaleDownDrain != nil {
+		if r.Spec.Mode != ModeStatefulSet {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'scaleDownDrain'", r.Spec.Mode)
+		}
+		if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec scaleDownDrain configuration is incorrect, it can't be used with the autoscaler")
+		}
+		if r.Spec.ScaleDownDrain.Timeout != nil && r.Spec.ScaleDownDrain.Timeout.Duration <= 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec scaleDownDrain configuration is incorrect, timeout should be greater than zero")
+		}
+	}
+
+	if err := validatePortNaming(c.logger, r); err != nil {
+		return warnings, err
+	}
+
+	if r.Spec.JaegerRemoteSampling != nil {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'jaegerRemoteSampling'", r.Spec.Mode)
+		}
+		if (r.Spec.JaegerRemoteSampling.Strategies == nil) == (r.Spec.JaegerRemoteSampling.ConfigMap == "") {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec jaegerRemoteSampling configuration is incorrect, exactly one of strategies and configMap should be set")
+		}
+		if r.Spec.JaegerRemoteSampling.ReloadInterval != nil && r.Spec.JaegerRemoteSampling.ReloadInterval.Duration <= 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec jaegerRemoteSampling configuration is incorrect, reloadInterval should be greater than zero")
+		}
+		if !hasJaegerRemoteSamplingExtension(r.Spec.Config) {
+			warnings = append(warnings, "jaegerRemoteSampling is set, but the config has no jaegerremotesampling extension")
+		}
+	}
+
+	if r.Spec.ExporterCheck != nil && r.Spec.ExporterCheck.Enabled {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'exporterCheck'", r.Spec.Mode)
+		}
+		if r.Spec.ExporterCheck.Timeout != nil && r.Spec.ExporterCheck.Timeout.Duration < time.Second {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec exporterCheck configuration is incorrect, timeout should be at least one second")
+		}
+	}
+
+	// the config variables are only resolved by the operator with the feature gate, the collector doesn't know them
+	if !featuregate.EnableConfigVars.IsEnabled() {
+		if err := validateConfigVarReferences(r); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec config configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate configProviders
+	if r.Spec.ConfigProviders != nil {
+		if err := validateConfigProviders(r, c.cfg); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec configProviders configuration is incorrect, %w", err)
+		}
+	}
+
+	// validate ipFamilies
+	if len(r.Spec.IPFamilies) > 2 {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec ipFamilies configuration is incorrect, at most two IP families can be specified")
+	}
+	if len(r.Spec.IPFamilies) == 2 {
+		if r.Spec.IPFamilies[0] == r.Spec.IPFamilies[1] {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec ipFamilies configuration is incorrect, IP family %s is specified twice", r.Spec.IPFamilies[0])
+		}
+		if r.Spec.IPFamilyPolicy != nil && *r.Spec.IPFamilyPolicy == v1.IPFamilyPolicySingleStack {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec ipFamilies configuration is incorrect, ipFamilyPolicy %s does not support multiple IP families", v1.IPFamilyPolicySingleStack)
+		}
+	}
+
+	if err := validatePortGroups(r.Spec.Services.PortGroups); err != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec services configuration is incorrect, %w", err)
+	}
+
+	// validate skipServiceCreation
+	if r.Spec.SkipServiceCreation.Enabled && r.Spec.Ingress.Type != "" {
+		// the ingress and the routes point to the Service of the collector, which isn't created
+		return warnings, fmt.Errorf("the OpenTelemetry Spec skipServiceCreation configuration is incorrect, the ingress type %s can't be used when the Services aren't created by the operator", r.Spec.Ingress.Type)
+	}
+	if r.Spec.SkipServiceCreation.ServiceSelector != nil {
+		if !r.Spec.SkipServiceCreation.Enabled {
+			warnings = append(warnings, "skipServiceCreation.serviceSelector is only used when skipServiceCreation is enabled")
+		}
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.SkipServiceCreation.ServiceSelector); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec skipServiceCreation configuration is incorrect, invalid serviceSelector: %w", err)
+		}
+	}
+
 	// validate target allocator configs
 	if r.Spec.TargetAllocator.Enabled {
 		taWarnings, err := c.validateTargetAllocatorConfig(ctx, r)
@@ -344,9 +623,35 @@ func (c CollectorWebhook) validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'deploymentUpdateStrategy'", r.Spec.Mode)
 	}
 
+	// warn about receivers with inline credentials
+	warnings = append(warnings, inlineCredentialWarnings(c.logger, r)...)
+
+	// warn about settings inconsistent with the resources
+	warnings = append(warnings, evaluateResourceHeuristics(r)...)
+
 	return warnings, nil
 }
 
+// inlineCredentialWarnings warns about the passwords written as is in the config of the receivers, which should be
+// read from a Secret mounted with a ${file:/etc/otelcol-secrets/<secret>/<key>} reference instead.
+func inlineCredentialWarnings(logger logr.Logger, r *OpenTelemetryCollector) admission.Warnings {
+	configYaml, err := r.Spec.Config.Yaml()
+	if err != nil {
+		return nil
+	}
+	configFromString, err := adapters.ConfigFromString(configYaml)
+	if err != nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, credential := range adapters.ConfigToCredentials(logger, configFromString) {
+		if credential.Inline {
+			warnings = append(warnings, fmt.Sprintf("receiver %s has an inline password in spec.config field %s, it is recommended to read it from a Secret with ${file:/etc/otelcol-secrets/<secret>/<key>}", credential.Receiver, credential.Field))
+		}
+	}
+	return warnings
+}
+
 func (c CollectorWebhook) validateTargetAllocatorConfig(ctx context.Context, r *OpenTelemetryCollector) (admission.Warnings, error) {
 	if r.Spec.Mode != ModeStatefulSet && r.Spec.Mode != ModeDaemonSet {
 		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
@@ -413,6 +718,233 @@ func validateProbe(probeName string, probe *Probe) error {
 	return nil
 }
 
+// validateArgs checks that the args can be passed to the collector along the arguments generated by the operator.
+func validateArgs(r *OpenTelemetryCollector) error {
+	for name := range r.Spec.Args {
+		if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, "= ") {
+			return fmt.Errorf("the OpenTelemetry Spec args configuration is incorrect, %q is not a valid argument name, it should be given without the leading dashes", name)
+		}
+		// the config argument is generated by the operator, except when the config is read from the standard input
+		if name == "config" && r.Spec.ConfigMode != ConfigModeStdin {
+			return fmt.Errorf("the OpenTelemetry Spec args configuration is incorrect, the config argument conflicts with the one generated by the operator, use the stdin configMode to pass it")
+		}
+	}
+	return nil
+}
+
+// validateSplitPipelines checks that the pipelines of each signal can run in a separate container of the pod.
+func validateSplitPipelines(r *OpenTelemetryCollector) error {
+	if r.Spec.ConfigMode != "" && r.Spec.ConfigMode != ConfigModeFile {
+		return fmt.Errorf("it requires the %s configMode", ConfigModeFile)
+	}
+	if errs := r.Spec.Config.splitPipelinesErrors(); len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+
+	signals := map[string]struct{}{}
+	for name := range r.Spec.Config.Service.Pipelines {
+		signals[PipelineSignal(name)] = struct{}{}
+	}
+	container := r.Spec.ContainerName
+	if container == "" {
+		container = naming.Container()
+	}
+	for signal := range signals {
+		if nameErrs := validation.IsDNS1123Label(naming.SignalContainer(container, signal)); len(nameErrs) > 0 {
+			return fmt.Errorf("the container name of the %s pipelines is invalid: %s", signal, strings.Join(nameErrs, ", "))
+		}
+	}
+	for signal := range r.Spec.SplitPipelinesIntoContainers.Resources {
+		if _, ok := signals[signal]; !ok {
+			return fmt.Errorf("resources are set for the %s signal, which has no pipelines", signal)
+		}
+	}
+	return nil
+}
+
+// validateConfigSchedules checks that the schedules are valid cron expressions, and that their config can be merged
+// into the config of the collector.
+func validateConfigSchedules(r *OpenTelemetryCollector) error {
+	for _, schedule := range r.Spec.ConfigSchedules {
+		if _, err := cronexpr.Parse(schedule.Schedule); err != nil {
+			return fmt.Errorf("the schedule of %s is invalid: %w", schedule.Name, err)
+		}
+		if schedule.Duration.Duration <= 0 {
+			return fmt.Errorf("the duration of %s should be greater than zero", schedule.Name)
+		}
+		if schedule.TimeZone != "" {
+			if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+				return fmt.Errorf("the time zone of %s is invalid: %w", schedule.Name, err)
+			}
+		}
+		if _, err := r.Spec.Config.WithOverlay(schedule.Config); err != nil {
+			return fmt.Errorf("the config of %s can't be merged into the config: %w", schedule.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateExporterFailover checks that the failover has several endpoints, and that it can be added to the pipelines
+// of the config.
+func validateExporterFailover(r *OpenTelemetryCollector) error {
+	failover := r.Spec.ExporterFailover
+	if len(failover.Endpoints) < 2 {
+		return fmt.Errorf("at least two endpoints are required")
+	}
+	for i, endpoint := range failover.Endpoints {
+		if endpoint.Endpoint == "" {
+			return fmt.Errorf("the endpoint %d is empty", i)
+		}
+	}
+	for _, pipeline := range failover.Pipelines {
+		if r.Spec.Config.Service.Pipelines[pipeline] == nil {
+			return fmt.Errorf("the %s pipeline isn't in the config", pipeline)
+		}
+	}
+	_, err := r.Spec.Config.WithExporterFailover(*failover)
+	return err
+}
+
+// validatePortNaming checks that the port name overrides are valid and unique port names, that the overrides indexed
+// by component name are for components opening a single port, and that the overridden names aren't the names of
+// other ports of the collector, e.g. the names of the hashSuffix strategy.
+func validatePortNaming(logger logr.Logger, r *OpenTelemetryCollector) error {
+	portNaming := r.Spec.PortNaming
+	components := map[string]string{}
+	for key, name := range portNaming.Overrides {
+		if key == "" || strings.HasPrefix(key, ":") {
+			return fmt.Errorf("the OpenTelemetry Spec portNaming configuration is incorrect, the overrides have to be indexed by a component name")
+		}
+		if nameErrs := validation.IsValidPortName(name); len(nameErrs) > 0 {
+			return fmt.Errorf("the OpenTelemetry Spec portNaming configuration is incorrect, %q of %s is not a valid port name: %s", name, key, strings.Join(nameErrs, ", "))
+		}
+		if other, ok := components[name]; ok {
+			return fmt.Errorf("the OpenTelemetry Spec portNaming configuration is incorrect, the port name %q is used by both %s and %s", name, other, key)
+		}
+		components[name] = key
+	}
+	if len(portNaming.Overrides) == 0 {
+		return nil
+	}
+
+	// the config itself is validated separately
+	configYaml, err := r.Spec.Config.Yaml()
+	if err != nil {
+		return nil
+	}
+	configFromString, err := adapters.ConfigFromString(configYaml)
+	if err != nil {
+		return nil
+	}
+	named := adapters.PortNaming{HashSuffix: portNaming.Strategy == PortNamingStrategyHashSuffix, Overrides: portNaming.Overrides}
+	ports := map[string]string{}
+	for _, cType := range []adapters.ComponentType{adapters.ComponentTypeReceiver, adapters.ComponentTypeExporter} {
+		componentPorts, err := adapters.ConfigToPortsByComponent(logger, cType, configFromString, named)
+		if err != nil {
+			continue
+		}
+		cmptNames := make([]string, 0, len(componentPorts))
+		for cmptName := range componentPorts {
+			cmptNames = append(cmptNames, cmptName)
+		}
+		sort.Strings(cmptNames)
+		for _, cmptName := range cmptNames {
+			cmptPorts := componentPorts[cmptName]
+			if _, ok := portNaming.Overrides[cmptName]; ok && len(cmptPorts) > 1 {
+				return fmt.Errorf("the OpenTelemetry Spec portNaming configuration is incorrect, the %s %s opens %d ports, its overrides have to be indexed by port number, e.g. %s:%d", cType, cmptName, len(cmptPorts), cmptName, cmptPorts[0].Port)
+			}
+			for _, port := range cmptPorts {
+				key := fmt.Sprintf("%s %s:%d", cType, cmptName, port.Port)
+				if other, ok := ports[port.Name]; ok && components[port.Name] != "" {
+					return fmt.Errorf("the OpenTelemetry Spec portNaming configuration is incorrect, the port name %q is used by both %s and %s", port.Name, other, key)
+				}
+				ports[port.Name] = key
+			}
+		}
+	}
+	return nil
+}
+
+// validateConfigProviders checks that the providers are available in the image of the collector, when it is the
+// default one, and that the config only references the allowed providers.
+func validateConfigProviders(r *OpenTelemetryCollector, cfg config.Config) error {
+	providers := r.Spec.ConfigProviders.Providers
+	if r.Spec.ConfigProviders.ExpandConverter != nil && !*r.Spec.ConfigProviders.ExpandConverter &&
+		strings.Contains(r.Spec.Args["feature-gates"], "-"+UnifyEnvVarExpansionFeatureGate) {
+		return fmt.Errorf("the expandConverter can't be disabled when the %s feature gate is disabled in args", UnifyEnvVarExpansionFeatureGate)
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+
+	allowed := map[string]struct{}{}
+	for _, provider := range providers {
+		if !providerSchemeRegexp.MatchString(provider) {
+			return fmt.Errorf("provider '%s' is not a valid URI scheme", provider)
+		}
+		allowed[provider] = struct{}{}
+	}
+
+	// the providers of custom images are unknown
+	if (len(r.Spec.Image) == 0 || r.Spec.Image == cfg.CollectorImage()) && len(cfg.CollectorConfigProviders()) > 0 {
+		available := map[string]struct{}{}
+		for _, provider := range cfg.CollectorConfigProviders() {
+			available[provider] = struct{}{}
+		}
+		for _, provider := range providers {
+			if _, ok := available[provider]; !ok {
+				return fmt.Errorf("provider '%s' is not available in the collector image, the available providers are %s", provider, strings.Join(cfg.CollectorConfigProviders(), ", "))
+			}
+		}
+	}
+
+	if _, ok := allowed["env"]; !ok && r.Spec.ConfigMode == ConfigModeEnv {
+		return fmt.Errorf("the env provider is required by the %s configMode", ConfigModeEnv)
+	}
+
+	configYaml, err := r.Spec.Config.Yaml()
+	if err != nil {
+		return err
+	}
+	for _, match := range configProviderReferenceRegexp.FindAllStringSubmatch(configYaml, -1) {
+		// the config variables are resolved by the operator
+		if match[2] == configVarScheme && featuregate.EnableConfigVars.IsEnabled() {
+			continue
+		}
+		if _, ok := allowed[match[2]]; !ok {
+			return fmt.Errorf("the config references the provider '%s', which is not in providers", match[2])
+		}
+	}
+	return nil
+}
+
+// validateConfigVarReferences checks that the config doesn't reference config variables.
+func validateConfigVarReferences(r *OpenTelemetryCollector) error {
+	configYaml, err := r.Spec.Config.Yaml()
+	if err != nil {
+		return err
+	}
+	for _, match := range configProviderReferenceRegexp.FindAllStringSubmatch(configYaml, -1) {
+		if match[2] == configVarScheme {
+			return fmt.Errorf("the config references config variables, which requires the %s feature gate of the operator", featuregate.EnableConfigVars.ID())
+		}
+	}
+	return nil
+}
+
+// hasJaegerRemoteSamplingExtension returns true when the config has a jaegerremotesampling extension.
+func hasJaegerRemoteSamplingExtension(config Config) bool {
+	if config.Extensions == nil {
+		return false
+	}
+	for name := range config.Extensions.Object {
+		if name == "jaegerremotesampling" || strings.HasPrefix(name, "jaegerremotesampling/") {
+			return true
+		}
+	}
+	return false
+}
+
 func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
 	if autoscaler.Behavior != nil {
 		if autoscaler.Behavior.ScaleDown != nil && autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds != nil &&
@@ -454,6 +986,19 @@ func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
 	return nil
 }
 
+// validatePortGroups checks that the Services of the port groups aren't named like the other Services of the
+// collector, which are suffixed with the reserved group names.
+func validatePortGroups(portGroups []ServicePortGroup) error {
+	for _, group := range portGroups {
+		for _, reserved := range reservedPortGroupNames {
+			if group.Name == reserved {
+				return fmt.Errorf("the port group name %q is reserved for the %s Service of the collector", group.Name, reserved)
+			}
+		}
+	}
+	return nil
+}
+
 func SetupCollectorWebhook(mgr ctrl.Manager, cfg config.Config, reviewer *rbac.Reviewer, metrics *Metrics) error {
 	cvw := &CollectorWebhook{
 		reviewer: reviewer,
@@ -224,6 +224,15 @@ type OpenTelemetryCommonFields struct {
 	//
 	// +optional
 	AdditionalContainers []v1.Container `json:"additionalContainers,omitempty"`
+	// IPFamilies represents the IP families (IPv4 and/or IPv6) to set on the generated Services.
+	// When only IPv6 is requested, receivers listening on all IPv4 interfaces (0.0.0.0) are
+	// rendered to listen on all IPv6 interfaces ([::]) instead.
+	// +optional
+	// +listType=atomic
+	IPFamilies []v1.IPFamily `json:"ipFamilies,omitempty"`
+	// IPFamilyPolicy represents the dual-stack-ness requested or required by the generated Services.
+	// +optional
+	IPFamilyPolicy *v1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
 }
 
 type StatefulSetCommonFields struct {
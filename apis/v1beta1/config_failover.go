@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// failoverSignals are the signals of the pipelines which can export to the failover connector.
+var failoverSignals = map[string]bool{"traces": true, "metrics": true, "logs": true}
+
+// WithExporterFailover returns a copy of the config exporting the data of the pipelines of the failover to its
+// endpoints by order of priority. An exporter is added for each endpoint, and a failover connector for each signal
+// of the pipelines, which is added to their exporters, and exports to a pipeline per endpoint. The pipelines of the
+// failover which aren't in the config are ignored, as they can be in the configs of other signals.
+func (c *Config) WithExporterFailover(failover ExporterFailover) (Config, error) {
+	cfg := *c.DeepCopy()
+	pipelines, err := failoverPipelines(cfg, failover)
+	if err != nil || len(pipelines) == 0 {
+		return cfg, err
+	}
+	if cfg.Exporters.Object == nil {
+		cfg.Exporters.Object = map[string]interface{}{}
+	}
+	if cfg.Connectors == nil {
+		cfg.Connectors = &AnyConfig{}
+	}
+	if cfg.Connectors.Object == nil {
+		cfg.Connectors.Object = map[string]interface{}{}
+	}
+
+	exporters := make([]string, len(failover.Endpoints))
+	for i, endpoint := range failover.Endpoints {
+		exporterType := "otlp"
+		if endpoint.Protocol == FailoverProtocolHTTP {
+			exporterType = "otlphttp"
+		}
+		exporters[i] = fmt.Sprintf("%s/failover-%d", exporterType, i)
+		if _, ok := cfg.Exporters.Object[exporters[i]]; ok {
+			return cfg, fmt.Errorf("the config already has a %s exporter", exporters[i])
+		}
+		exporter := map[string]interface{}{
+			"endpoint":         endpoint.Endpoint,
+			"sending_queue":    map[string]interface{}{"enabled": false},
+			"retry_on_failure": map[string]interface{}{"enabled": false},
+		}
+		if failover.Exporter != nil {
+			exporter = mergeMaps(exporter, failover.Exporter.DeepCopy().Object)
+		}
+		cfg.Exporters.Object[exporters[i]] = exporter
+	}
+
+	connectors := map[string]bool{}
+	for _, name := range pipelines {
+		signal, _, _ := strings.Cut(name, "/")
+		connector := fmt.Sprintf("failover/%s", signal)
+		if !connectors[connector] {
+			if _, ok := cfg.Connectors.Object[connector]; ok {
+				return cfg, fmt.Errorf("the config already has a %s connector", connector)
+			}
+			connectors[connector] = true
+			priorityLevels := make([]interface{}, len(exporters))
+			for i, exporter := range exporters {
+				pipeline := fmt.Sprintf("%s/failover-%d", signal, i)
+				if _, exists := cfg.Service.Pipelines[pipeline]; exists {
+					return cfg, fmt.Errorf("the config already has a %s pipeline", pipeline)
+				}
+				cfg.Service.Pipelines[pipeline] = &Pipeline{Receivers: []string{connector}, Exporters: []string{exporter}}
+				priorityLevels[i] = []interface{}{pipeline}
+			}
+			cfg.Connectors.Object[connector] = failoverConnectorConfig(failover, priorityLevels)
+		}
+		cfg.Service.Pipelines[name].Exporters = append(cfg.Service.Pipelines[name].Exporters, connector)
+	}
+	return cfg, nil
+}
+
+// failoverPipelines returns the pipelines of the config exporting to the failover, sorted by name. The pipelines of
+// the other signals are skipped when the failover applies to all the pipelines.
+func failoverPipelines(cfg Config, failover ExporterFailover) ([]string, error) {
+	var pipelines []string
+	if len(failover.Pipelines) == 0 {
+		for name, pipeline := range cfg.Service.Pipelines {
+			if signal, _, _ := strings.Cut(name, "/"); pipeline != nil && failoverSignals[signal] {
+				pipelines = append(pipelines, name)
+			}
+		}
+		sort.Strings(pipelines)
+		return pipelines, nil
+	}
+	for _, name := range failover.Pipelines {
+		if cfg.Service.Pipelines[name] == nil {
+			continue
+		}
+		if signal, _, _ := strings.Cut(name, "/"); !failoverSignals[signal] {
+			return nil, fmt.Errorf("the %s pipeline can't export to the failover, only the traces, metrics and logs pipelines can", name)
+		}
+		if !slices.Contains(pipelines, name) {
+			pipelines = append(pipelines, name)
+		}
+	}
+	sort.Strings(pipelines)
+	return pipelines, nil
+}
+
+// failoverConnectorConfig returns the config of a failover connector exporting to the given priority levels.
+func failoverConnectorConfig(failover ExporterFailover, priorityLevels []interface{}) map[string]interface{} {
+	connector := map[string]interface{}{"priority_levels": priorityLevels}
+	if failover.RetryInterval != nil {
+		connector["retry_interval"] = failover.RetryInterval.Duration.String()
+	}
+	if failover.RetryGap != nil {
+		connector["retry_gap"] = failover.RetryGap.Duration.String()
+	}
+	if failover.MaxRetries != nil {
+		connector["max_retries"] = int(*failover.MaxRetries)
+	}
+	return connector
+}
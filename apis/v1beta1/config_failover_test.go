@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func failoverTestConfig() Config {
+	return Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+		Exporters: AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				"metrics": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+}
+
+func TestConfigWithExporterFailover(t *testing.T) {
+	cfg := failoverTestConfig()
+	maxRetries := int32(5)
+
+	actual, err := cfg.WithExporterFailover(ExporterFailover{
+		Endpoints: []FailoverEndpoint{
+			{Endpoint: "primary:4317", Protocol: FailoverProtocolGRPC},
+			{Endpoint: "https://secondary:4318", Protocol: FailoverProtocolHTTP},
+		},
+		Pipelines:     []string{"traces"},
+		Exporter:      &AnyConfig{Object: map[string]interface{}{"tls": map[string]interface{}{"insecure": true}}},
+		RetryInterval: &metav1.Duration{Duration: 5 * time.Minute},
+		MaxRetries:    &maxRetries,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"endpoint":         "primary:4317",
+		"sending_queue":    map[string]interface{}{"enabled": false},
+		"retry_on_failure": map[string]interface{}{"enabled": false},
+		"tls":              map[string]interface{}{"insecure": true},
+	}, actual.Exporters.Object["otlp/failover-0"])
+	assert.Equal(t, "https://secondary:4318", actual.Exporters.Object["otlphttp/failover-1"].(map[string]interface{})["endpoint"])
+	assert.Equal(t, map[string]interface{}{
+		"priority_levels": []interface{}{[]interface{}{"traces/failover-0"}, []interface{}{"traces/failover-1"}},
+		"retry_interval":  "5m0s",
+		"max_retries":     5,
+	}, actual.Connectors.Object["failover/traces"])
+	assert.Equal(t, []string{"debug", "failover/traces"}, actual.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, &Pipeline{Receivers: []string{"failover/traces"}, Exporters: []string{"otlp/failover-0"}}, actual.Service.Pipelines["traces/failover-0"])
+	assert.Equal(t, &Pipeline{Receivers: []string{"failover/traces"}, Exporters: []string{"otlphttp/failover-1"}}, actual.Service.Pipelines["traces/failover-1"])
+
+	// the metrics pipeline isn't part of the failover
+	assert.Equal(t, []string{"debug"}, actual.Service.Pipelines["metrics"].Exporters)
+	assert.NotContains(t, actual.Connectors.Object, "failover/metrics")
+
+	// the config is left untouched
+	assert.Nil(t, cfg.Connectors)
+	assert.Len(t, cfg.Exporters.Object, 1)
+	assert.Equal(t, []string{"debug"}, cfg.Service.Pipelines["traces"].Exporters)
+}
+
+func TestConfigWithExporterFailoverAllPipelines(t *testing.T) {
+	cfg := failoverTestConfig()
+
+	actual, err := cfg.WithExporterFailover(ExporterFailover{
+		Endpoints: []FailoverEndpoint{{Endpoint: "primary:4317"}, {Endpoint: "secondary:4317"}},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, actual.Exporters.Object, "otlp/failover-0")
+	assert.Contains(t, actual.Exporters.Object, "otlp/failover-1")
+	assert.Contains(t, actual.Connectors.Object, "failover/traces")
+	assert.Contains(t, actual.Connectors.Object, "failover/metrics")
+	assert.Equal(t, []string{"debug", "failover/traces"}, actual.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"debug", "failover/metrics"}, actual.Service.Pipelines["metrics"].Exporters)
+	assert.Len(t, actual.Service.Pipelines, 6)
+}
+
+func TestConfigWithExporterFailoverErrors(t *testing.T) {
+	endpoints := []FailoverEndpoint{{Endpoint: "primary:4317"}, {Endpoint: "secondary:4317"}}
+	for _, tt := range []struct {
+		desc        string
+		config      func(cfg *Config)
+		pipelines   []string
+		expectedErr string
+	}{
+		{
+			desc: "exporter collision",
+			config: func(cfg *Config) {
+				cfg.Exporters.Object["otlp/failover-0"] = map[string]interface{}{}
+			},
+			expectedErr: "the config already has a otlp/failover-0 exporter",
+		},
+		{
+			desc: "connector collision",
+			config: func(cfg *Config) {
+				cfg.Connectors = &AnyConfig{Object: map[string]interface{}{"failover/traces": map[string]interface{}{}}}
+			},
+			pipelines:   []string{"traces"},
+			expectedErr: "the config already has a failover/traces connector",
+		},
+		{
+			desc: "pipeline collision",
+			config: func(cfg *Config) {
+				cfg.Service.Pipelines["traces/failover-1"] = &Pipeline{}
+			},
+			pipelines:   []string{"traces"},
+			expectedErr: "the config already has a traces/failover-1 pipeline",
+		},
+		{
+			desc: "unsupported pipeline",
+			config: func(cfg *Config) {
+				cfg.Service.Pipelines["profiles"] = &Pipeline{}
+			},
+			pipelines:   []string{"profiles"},
+			expectedErr: "the profiles pipeline can't export to the failover",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := failoverTestConfig()
+			tt.config(&cfg)
+
+			_, err := cfg.WithExporterFailover(ExporterFailover{Endpoints: endpoints, Pipelines: tt.pipelines})
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
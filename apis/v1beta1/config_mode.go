@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+type (
+	// ConfigMode represents how the config is passed to the collector.
+	// +kubebuilder:validation:Enum=file;env;stdin
+	ConfigMode string
+)
+
+const (
+	// ConfigModeFile specifies that the config is mounted in the collector container, and its path passed with
+	// the --config argument.
+	ConfigModeFile ConfigMode = "file"
+
+	// ConfigModeEnv specifies that the config is passed in the OTEL_CONFIG environment variable, read with the
+	// --config=env:OTEL_CONFIG argument.
+	ConfigModeEnv ConfigMode = "env"
+
+	// ConfigModeStdin specifies that the config is fed to the standard input of the collector started with the
+	// command of the collector spec, by a /bin/sh of the image.
+	ConfigModeStdin ConfigMode = "stdin"
+)
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+)
+
+// WithOverlay returns a copy of the config with the overlay merged into it: the maps are merged recursively, and the
+// other values of the overlay replace the ones of the config.
+func (c *Config) WithOverlay(overlay AnyConfig) (Config, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return Config{}, err
+	}
+	base := map[string]interface{}{}
+	if err := json.Unmarshal(b, &base); err != nil {
+		return Config{}, err
+	}
+	b, err = json.Marshal(mergeMaps(base, overlay.Object))
+	if err != nil {
+		return Config{}, err
+	}
+	merged := Config{}
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return Config{}, err
+	}
+	return merged, nil
+}
+
+// mergeMaps returns the maps merged recursively, the values of src replacing the other values of dst.
+func mergeMaps(dst, src map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := merged[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			merged[k] = mergeMaps(dstMap, srcMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWithOverlay(t *testing.T) {
+	cfg := Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+		Exporters: AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+		Processors: &AnyConfig{Object: map[string]interface{}{
+			"probabilistic_sampler": map[string]interface{}{"sampling_percentage": float64(100), "hash_seed": float64(22)},
+		}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Processors: []string{"probabilistic_sampler"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+
+	merged, err := cfg.WithOverlay(AnyConfig{Object: map[string]interface{}{
+		"processors": map[string]interface{}{
+			"probabilistic_sampler": map[string]interface{}{"sampling_percentage": float64(10)},
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{"exporters": []interface{}{"otlp"}},
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"sampling_percentage": float64(10), "hash_seed": float64(22)},
+		merged.Processors.Object["probabilistic_sampler"])
+	assert.Equal(t, []string{"otlp"}, merged.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"otlp"}, merged.Service.Pipelines["traces"].Receivers)
+
+	// the config is left untouched
+	assert.Equal(t, float64(100), cfg.Processors.Object["probabilistic_sampler"].(map[string]interface{})["sampling_percentage"])
+	assert.Equal(t, []string{"debug"}, cfg.Service.Pipelines["traces"].Exporters)
+}
+
+func TestConfigWithInvalidOverlay(t *testing.T) {
+	cfg := Config{}
+
+	_, err := cfg.WithOverlay(AnyConfig{Object: map[string]interface{}{
+		"service": map[string]interface{}{"pipelines": "traces"},
+	}})
+	assert.Error(t, err)
+}
@@ -18,6 +18,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
 func init() {
@@ -52,6 +54,17 @@ type OpenTelemetryCollector struct {
 // Hub exists to allow for conversion.
 func (*OpenTelemetryCollector) Hub() {}
 
+// ResourceNameAnnotation pins the name the Deployments, Services and ConfigMaps generated for the collector are named
+// after, in place of <name>-collector. The webhook sets it to the name rendered by the collector name template of the
+// operator when the collector is created, so that changing the template doesn't rename the existing resources.
+const ResourceNameAnnotation = constants.AnnotationCollectorResourceName
+
+// ConfigSourceAnnotation holds the spec.config string of the v1alpha1 collectors written with YAML anchors, aliases or
+// merge keys, which are resolved when the string is converted to the structured spec.config of v1beta1. The string
+// is returned as is to the v1alpha1 clients, and rendered as is for the collector, as long as it decodes to the
+// config of the collector.
+const ConfigSourceAnnotation = "operator.opentelemetry.io/config-source"
+
 //+kubebuilder:object:root=true
 
 // OpenTelemetryCollectorList contains a list of OpenTelemetryCollector.
@@ -74,8 +87,48 @@ type OpenTelemetryCollectorStatus struct {
 	// Image indicates the container image to use for the OpenTelemetry Collector.
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// Conditions represent the latest available observations of the OpenTelemetryCollector.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlueGreen is the state of the blue/green rollout of the collector.
+	// +optional
+	BlueGreen *BlueGreenStatus `json:"blueGreen,omitempty"`
+
+	// ActiveConfigSchedule is the name of the config schedule merged into the config of the collector, if any.
+	// +optional
+	ActiveConfigSchedule string `json:"activeConfigSchedule,omitempty"`
+
+	// OrphanedObjects are the objects managed for the collector which are not generated anymore, e.g. the workload
+	// of the previous mode, as Kind/name. They are only reported with the DryRun orphaned objects policy, otherwise
+	// they are deleted.
+	// +optional
+	OrphanedObjects []string `json:"orphanedObjects,omitempty"`
+
+	// AuditLog summarizes the audit log of the mutations of the collector made by the operator, when the
+	// operator.collector.auditlog feature gate is enabled.
+	// +optional
+	AuditLog *AuditLogStatus `json:"auditLog,omitempty"`
 }
 
+const (
+	// ConditionTypeDegraded is set when the collector is not working as expected, e.g. when an automated upgrade
+	// failed and had to be rolled back.
+	ConditionTypeDegraded = "Degraded"
+	// ConditionTypeCrashLooping is set when collector pods are crash-looping, with the classified cause of the
+	// failure and the last logs of the collector.
+	ConditionTypeCrashLooping = "CrashLooping"
+	// ConditionTypeExportersReachable is set when the exporter check is enabled, with the exporter endpoints that
+	// could not be reached from the namespace of the collector.
+	ConditionTypeExportersReachable = "ExportersReachable"
+	// ConditionTypeServicePortsExposed is set when the Services of the collector are managed outside of the operator
+	// and selected by spec.skipServiceCreation.serviceSelector, with the ports of the collector they don't expose.
+	ConditionTypeServicePortsExposed = "ServicePortsExposed"
+)
+
 // OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
 type OpenTelemetryCollectorSpec struct {
 	// OpenTelemetryCommonFields are fields that are on all OpenTelemetry CRD workloads.
@@ -100,6 +153,18 @@ type OpenTelemetryCollectorSpec struct {
 	// +required
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Config Config `json:"config"`
+	// ConfigMode defines how the config is passed to the collector: mounted as a file (default), in an
+	// environment variable or on the standard input. The stdin mode requires the command to be set, and the image
+	// to provide /bin/sh to redirect the config to the standard input, which the distroless images don't. It is not
+	// supported by the sidecar mode.
+	// +optional
+	ConfigMode ConfigMode `json:"configMode,omitempty"`
+	// Command is the entrypoint of the collector container, replacing the entrypoint of the image. It is required
+	// by the stdin config mode, and allows running distributions that require a nonstandard invocation.
+	// The arguments generated by the operator and the args are appended to the command.
+	// +optional
+	// +listType=atomic
+	Command []string `json:"command,omitempty"`
 	// ConfigVersions defines the number versions to keep for the collector config. Each config version is stored in a separate ConfigMap.
 	// Defaults to 3. The minimum value is 1.
 	// +optional
@@ -111,6 +176,36 @@ type OpenTelemetryCollectorSpec struct {
 	// Valid modes are: deployment, daemonset and statefulset.
 	// +optional
 	Ingress Ingress `json:"ingress,omitempty"`
+	// Services is used to customize the Services generated for the OpenTelemetry Collector.
+	// +optional
+	Services Services `json:"services,omitempty"`
+	// SkipServiceCreation allows managing the Services exposing the collector's receiver ports outside of the operator.
+	// +optional
+	SkipServiceCreation SkipServiceCreation `json:"skipServiceCreation,omitempty"`
+	// ServiceMesh is the service mesh the collector pods are injected in. When set, the operator adds the pod
+	// annotations required to run the collector inside the mesh: the receiver and probe ports bypass the proxy,
+	// the collector waits for the proxy to be ready before starting, and protocol hints are added to the Services.
+	// This only works with the following OpenTelemetryCollector mode's: daemonset, statefulset, and deployment.
+	// +optional
+	ServiceMesh ServiceMesh `json:"serviceMesh,omitempty"`
+	// DNSPolicy sets the DNS policy of the collector pods. When not set, it defaults to 'ClusterFirst', or to
+	// 'ClusterFirstWithHostNet' when the pods run in the host network.
+	// DNS parameters given in DNSConfig will be merged with the policy selected with DNSPolicy.
+	// This only works with the following OpenTelemetryCollector mode's: daemonset, statefulset, and deployment.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNSConfig specifies the DNS parameters of the collector pods, e.g. additional name servers or search
+	// domains used by exporters to resolve split-horizon DNS names.
+	// This only works with the following OpenTelemetryCollector mode's: daemonset, statefulset, and deployment.
+	// +optional
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// HostAliases is a list of hosts and IPs that will be injected into the collector pods' hosts file,
+	// e.g. to pin the IPs of the backends the collector exports to.
+	// This only works with the following OpenTelemetryCollector mode's: daemonset, statefulset, and deployment.
+	// +optional
+	// +listType=atomic
+	HostAliases []v1.HostAlias `json:"hostAliases,omitempty"`
 	// Liveness config for the OpenTelemetry Collector except the probe handler which is auto generated from the health extension of the collector.
 	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline.
 	// +optional
@@ -141,6 +236,327 @@ type OpenTelemetryCollectorSpec struct {
 	// This is only applicable to Deployment mode.
 	// +optional
 	DeploymentUpdateStrategy appsv1.DeploymentStrategy `json:"deploymentUpdateStrategy,omitempty"`
+	// ZoneSpread guarantees a minimum number of collector replicas in each zone, so that the collector keeps
+	// ingesting data during zonal outages.
+	// This is only applicable to Deployment mode.
+	// +optional
+	ZoneSpread *ZoneSpread `json:"zoneSpread,omitempty"`
+	// ScaleDownDrain drains the collector replicas before removing them when the collector is scaled down, so
+	// that the data they hold, e.g. the traces buffered by the tail sampling processor, is not lost.
+	// The replicas to remove are taken out of the headless Service, used by the loadbalancing exporters to
+	// route the data to the collector, and are removed once the queues reported by their internal metrics are empty.
+	// This is only applicable to StatefulSet mode.
+	// +optional
+	ScaleDownDrain *ScaleDownDrain `json:"scaleDownDrain,omitempty"`
+	// PortNaming defines how the ports opened for the components of the collector config are named.
+	// +optional
+	PortNaming PortNaming `json:"portNaming,omitempty"`
+	// JaegerRemoteSampling defines the sampling strategies served by the jaegerremotesampling extensions of
+	// the collector config. The strategies file is mounted in the collector pods and set as the source of the
+	// extensions without one, which reload it when it changes.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	JaegerRemoteSampling *JaegerRemoteSampling `json:"jaegerRemoteSampling,omitempty"`
+	// ExporterCheck runs a Job connecting to the endpoints of the exporters of the config from the namespace of the
+	// collector when the config changes, and reports the unreachable endpoints in the ExportersReachable condition,
+	// e.g. to catch egress or NetworkPolicy problems. The check doesn't block the rollout of the config.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	ExporterCheck *ExporterCheck `json:"exporterCheck,omitempty"`
+	// ConfigProviders controls how the collector resolves the ${} references of its config.
+	// +optional
+	ConfigProviders *ConfigProviders `json:"configProviders,omitempty"`
+	// SkipPipelineDefaults opts the collector out of the pipeline defaults of the operator, enabled with its
+	// --enable-pipeline-defaults flag, which add a batch processor to the pipelines without one, and enable the
+	// sending queue and the retries of the OTLP exporters not configuring them. The defaults are added to the config
+	// rendered for the collector, the config of the spec is left untouched.
+	// +optional
+	SkipPipelineDefaults bool `json:"skipPipelineDefaults,omitempty"`
+	// BlueGreen rolls the changes of the collector out to a second Deployment, running in parallel to the current
+	// one, and switches the Services to it once its replicas stayed ready for the verification window. The previous
+	// Deployment keeps running, so that reverting the changes switches the Services back to it right away.
+	// This is only applicable to Deployment mode, without autoscaler and zones.
+	// +optional
+	BlueGreen *BlueGreen `json:"blueGreen,omitempty"`
+	// ContainerName overrides the name of the collector container, otc-container by default.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+	// SplitPipelinesIntoContainers runs the pipelines of each signal in a separate collector container of the pod,
+	// named after the collector container and the signal, e.g. otc-container-traces, so that the signals are
+	// isolated from each other. Each container runs a config only holding the pipelines of its signal and their
+	// components. The extensions run in the container of the first signal, the following containers only run the
+	// extensions their components reference, like authenticators. The telemetry metrics of the following containers
+	// are exposed on the ports following the metrics port that the endpoints of the components don't use.
+	// This is not applicable to Sidecar mode, and requires the file config mode, the pipelines of the signals to
+	// use distinct receivers and no connectors.
+	// +optional
+	SplitPipelinesIntoContainers *SplitPipelinesIntoContainers `json:"splitPipelinesIntoContainers,omitempty"`
+	// ConfigSchedules are config variants merged into the config during the windows of their cron schedules, e.g.
+	// to lower the sampling percentage at night. The first schedule whose window is open is active, and reported in
+	// the status. The config is used as is while no window is open.
+	// This is not applicable to Sidecar mode.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ConfigSchedules []ConfigSchedule `json:"configSchedules,omitempty"`
+	// ExporterFailover renders the OTLP exporters of a list of endpoints, and a failover connector exporting the data
+	// of the pipelines to the first endpoint which doesn't fail, so that a backend with a secondary endpoint doesn't
+	// require hand-built routing configs.
+	// +optional
+	ExporterFailover *ExporterFailover `json:"exporterFailover,omitempty"`
+	// OrphanedObjectsPolicy defines what happens to the workloads of the other modes, the ServiceAccounts and the
+	// target allocator objects managed for the collector which are not generated anymore, e.g. after a mode change or
+	// after disabling the target allocator. They are deleted by default, and only reported in the status with DryRun.
+	// The other objects which are not generated anymore are deleted whatever the policy.
+	// +optional
+	OrphanedObjectsPolicy OrphanedObjectsPolicy `json:"orphanedObjectsPolicy,omitempty"`
+}
+
+// OrphanedObjectsPolicy defines the handling of the objects which are not generated for the collector anymore.
+//
+// +kubebuilder:validation:Enum=Delete;DryRun
+type OrphanedObjectsPolicy string
+
+const (
+	// OrphanedObjectsPolicyDelete deletes the orphaned objects in the reconciliation orphaning them.
+	OrphanedObjectsPolicyDelete OrphanedObjectsPolicy = "Delete"
+	// OrphanedObjectsPolicyDryRun keeps the orphaned objects, and reports them in the status.
+	OrphanedObjectsPolicyDryRun OrphanedObjectsPolicy = "DryRun"
+)
+
+// ConfigSchedule defines a config variant applied on a cron schedule.
+type ConfigSchedule struct {
+	// Name of the config variant.
+	// +required
+	Name string `json:"name"`
+	// Schedule is the cron expression of the times the window of the variant opens, e.g. "0 20 * * *".
+	// +required
+	Schedule string `json:"schedule"`
+	// Duration is the time the window of the variant stays open, e.g. 12h.
+	// +required
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the name of the time zone of the schedule in the IANA database, e.g. "Europe/Paris".
+	// The schedule is in UTC by default.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Config is merged into the config of the collector while the window is open: the maps are merged
+	// recursively, and the other values replace the ones of the config.
+	// +required
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config AnyConfig `json:"config"`
+}
+
+// ExporterFailover defines OTLP endpoints used by order of priority.
+type ExporterFailover struct {
+	// Endpoints are the OTLP endpoints by order of priority: the first one is the primary endpoint, and the next ones
+	// are exported to while the ones before them fail.
+	// +required
+	// +kubebuilder:validation:MinItems=2
+	Endpoints []FailoverEndpoint `json:"endpoints"`
+	// Pipelines are the names of the pipelines exporting to the endpoints, e.g. traces. Defaults to all the
+	// pipelines.
+	// +optional
+	Pipelines []string `json:"pipelines,omitempty"`
+	// Exporter is merged into the config of the exporter of each endpoint, e.g. to set their tls settings or
+	// headers. The sending queue and the retries of the exporters are disabled by default, so that their failures
+	// reach the failover connector right away.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Exporter *AnyConfig `json:"exporter,omitempty"`
+	// RetryInterval is the time after which the failing endpoints of a higher priority are exported to again.
+	// Defaults to the one of the failover connector.
+	// +optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+	// RetryGap is the time between the retries of two endpoints of a higher priority.
+	// Defaults to the one of the failover connector.
+	// +optional
+	RetryGap *metav1.Duration `json:"retryGap,omitempty"`
+	// MaxRetries is the number of times the failing endpoints are retried before being given up.
+	// Defaults to the one of the failover connector.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// FailoverEndpoint defines an OTLP endpoint of the exporter failover.
+type FailoverEndpoint struct {
+	// Endpoint of the OTLP receiver, e.g. otlp-primary:4317 for grpc or http://otlp-primary:4318 for http.
+	// +required
+	Endpoint string `json:"endpoint"`
+	// Protocol is the OTLP protocol of the endpoint: grpc, exported to with the otlp exporter, or http, exported to
+	// with the otlphttp exporter.
+	// +optional
+	// +kubebuilder:default=grpc
+	Protocol FailoverProtocol `json:"protocol,omitempty"`
+}
+
+// FailoverProtocol is the OTLP protocol of an endpoint of the exporter failover.
+//
+// +kubebuilder:validation:Enum=grpc;http
+type FailoverProtocol string
+
+const (
+	// FailoverProtocolGRPC exports to the endpoint with the otlp exporter.
+	FailoverProtocolGRPC FailoverProtocol = "grpc"
+	// FailoverProtocolHTTP exports to the endpoint with the otlphttp exporter.
+	FailoverProtocolHTTP FailoverProtocol = "http"
+)
+
+// SplitPipelinesIntoContainers defines the collector containers running the pipelines of each signal.
+type SplitPipelinesIntoContainers struct {
+	// Enabled runs the pipelines of each signal in a separate container.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Resources are the compute resources of the container of each signal, keyed by signal, e.g. traces.
+	// The containers of the other signals use spec.resources.
+	// +optional
+	Resources map[string]v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// UnifyEnvVarExpansionFeatureGate is the feature gate of the collector disabling the expansion of the $VAR references.
+const UnifyEnvVarExpansionFeatureGate = "confmap.unifyEnvVarExpansion"
+
+// ConfigProviders defines the confmap providers and converters used to resolve the config of the collector.
+type ConfigProviders struct {
+	// Providers are the confmap providers the ${<scheme>:<value>} references of the config can use,
+	// e.g. env, file, http, https or yaml. Configs referencing other providers are rejected. All the providers of the
+	// image can be referenced when empty.
+	// +optional
+	// +listType=set
+	Providers []string `json:"providers,omitempty"`
+	// ExpandConverter expands the $VAR and ${VAR} references to environment variables. When disabled, the
+	// confmap.unifyEnvVarExpansion feature gate of the collector is enabled, so that only the ${VAR} and ${env:VAR}
+	// references are expanded. Defaults to true.
+	// +optional
+	ExpandConverter *bool `json:"expandConverter,omitempty"`
+}
+
+// ExporterCheck defines the reachability check of the exporter endpoints.
+type ExporterCheck struct {
+	// Enabled runs the check.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Timeout is the maximum time to connect to an endpoint. Defaults to 5s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// JaegerRemoteSampling defines where the sampling strategies served by the jaegerremotesampling extensions come from.
+// Exactly one of Strategies and ConfigMap has to be set.
+type JaegerRemoteSampling struct {
+	// Strategies are the per-service sampling strategies, in the format of the Jaeger sampling strategies file,
+	// e.g. default_strategy and service_strategies. The operator generates the strategies file from them.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Strategies *AnyConfig `json:"strategies,omitempty"`
+	// ConfigMap is the name of a ConfigMap in the namespace of the collector holding the strategies file in its
+	// strategies.json entry.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// ReloadInterval is how often the extensions check the strategies file for changes. Defaults to 30s.
+	// +optional
+	ReloadInterval *metav1.Duration `json:"reloadInterval,omitempty"`
+}
+
+// PortNaming defines how the ports of the collector are named.
+type PortNaming struct {
+	// Strategy is how the ports whose component name is too long, or not a valid port name, are named.
+	// Defaults to portNumber.
+	// +optional
+	Strategy PortNamingStrategy `json:"strategy,omitempty"`
+	// Overrides are the names of the ports, indexed by the name of their component, e.g. otlp/internal, when the
+	// component opens a single port, or by the name of their component and their port number, e.g. otlp/internal:4317.
+	// +optional
+	Overrides map[string]string `json:"overrides,omitempty"`
+}
+
+// ScaleDownDrain defines how the collector replicas are drained before being removed.
+type ScaleDownDrain struct {
+	// Timeout is the maximum time to wait for a replica to drain, after which it is removed anyway.
+	// Defaults to 5m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// QueueMetrics are the internal metrics of the collector whose sum has to be zero for a replica to be drained.
+	// Defaults to otelcol_exporter_queue_size.
+	// +optional
+	// +listType=atomic
+	QueueMetrics []string `json:"queueMetrics,omitempty"`
+}
+
+// BlueGreenColor is the color of one of the two Deployments of a collector rolled out blue/green.
+// +kubebuilder:validation:Enum=blue;green
+type BlueGreenColor string
+
+const (
+	// BlueGreenColorBlue is the color of the first Deployment of a collector rolled out blue/green.
+	BlueGreenColorBlue BlueGreenColor = "blue"
+	// BlueGreenColorGreen is the color of the second Deployment of a collector rolled out blue/green.
+	BlueGreenColorGreen BlueGreenColor = "green"
+)
+
+// BlueGreen defines the blue/green rollout of the collector.
+type BlueGreen struct {
+	// VerificationWindow is the duration the replicas of the new Deployment have to stay ready before the Services
+	// are switched to them. Defaults to 1m.
+	// +optional
+	VerificationWindow *metav1.Duration `json:"verificationWindow,omitempty"`
+}
+
+// BlueGreenStatus defines the state of the blue/green rollout of the collector.
+type BlueGreenStatus struct {
+	// Active is the color of the Deployment the Services send the data to.
+	// +optional
+	Active BlueGreenColor `json:"active,omitempty"`
+	// ActiveRevision is the revision of the pod template of the active Deployment.
+	// +optional
+	ActiveRevision string `json:"activeRevision,omitempty"`
+	// PreviousRevision is the revision of the pod template of the previous Deployment, which the Services are
+	// switched back to without verification window.
+	// +optional
+	PreviousRevision string `json:"previousRevision,omitempty"`
+	// PreviewReadySince is the time since when the replicas of the Deployment previewing the changes are ready.
+	// +optional
+	PreviewReadySince *metav1.Time `json:"previewReadySince,omitempty"`
+}
+
+// AuditLogStatus summarizes the audit log of the mutations of the collector made by the operator.
+type AuditLogStatus struct {
+	// ConfigMap is the name of the ConfigMap holding the entries of the audit log, with the time, the source and the
+	// JSON merge patch of each mutation.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// Mutations is the number of mutations kept in the audit log.
+	// +optional
+	Mutations int32 `json:"mutations,omitempty"`
+	// LastMutationTime is the time of the last mutation.
+	// +optional
+	LastMutationTime *metav1.Time `json:"lastMutationTime,omitempty"`
+	// LastMutationSource is what made the last mutation: upgrade, rollback, defaulting or opamp.
+	// +optional
+	LastMutationSource string `json:"lastMutationSource,omitempty"`
+	// LastMutationMessage describes the last mutation.
+	// +optional
+	LastMutationMessage string `json:"lastMutationMessage,omitempty"`
+}
+
+// ZoneSpread defines how the collector replicas are spread across zones.
+type ZoneSpread struct {
+	// MinPerZone is the minimum number of collector replicas running in each zone.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	MinPerZone int32 `json:"minPerZone"`
+	// Zones are the zones the collector runs in. When set, the collector is split into one Deployment per zone,
+	// running at least MinPerZone replicas each, and the replicas of the collector are divided among the zones.
+	// When not set, the replicas are spread evenly across the zones of the cluster with a topology spread
+	// constraint, and should be at least MinPerZone times the number of zones.
+	// +optional
+	// +listType=set
+	Zones []string `json:"zones,omitempty"`
+	// TopologyKey is the node label holding the zone of the nodes. Defaults to topology.kubernetes.io/zone.
+	// +optional
+	// +kubebuilder:default:=topology.kubernetes.io/zone
+	TopologyKey string `json:"topologyKey,omitempty"`
 }
 
 // TargetAllocatorEmbedded defines the configuration for the Prometheus target allocator, embedded in the
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"sort"
+	"strings"
+)
+
+// batchProcessor is the processor added to the pipelines without batching.
+const batchProcessor = "batch"
+
+// queuedExporterTypes are the types of the exporters supporting the sending_queue and retry_on_failure settings.
+var queuedExporterTypes = []string{"otlp", "otlphttp"}
+
+// WithPipelineDefaults returns a copy of the config with the pipeline defaults applied, the config is left untouched.
+func (c *Config) WithPipelineDefaults() Config {
+	cfg := *c.DeepCopy()
+	cfg.applyPipelineDefaults()
+	return cfg
+}
+
+// applyPipelineDefaults adds the batch processor to the pipelines without one, and enables the sending queue and the
+// retries of the exporters of the pipelines not configuring them.
+func (c *Config) applyPipelineDefaults() {
+	exporters := map[string]struct{}{}
+	for _, pipeline := range c.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		if !hasComponentOfType(pipeline.Processors, batchProcessor) {
+			pipeline.Processors = append(pipeline.Processors, batchProcessor)
+			if c.Processors == nil {
+				c.Processors = &AnyConfig{}
+			}
+			if c.Processors.Object == nil {
+				c.Processors.Object = map[string]interface{}{}
+			}
+			if _, ok := c.Processors.Object[batchProcessor].(map[string]interface{}); !ok {
+				c.Processors.Object[batchProcessor] = map[string]interface{}{}
+			}
+		}
+		for _, exporter := range pipeline.Exporters {
+			exporters[exporter] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !hasComponentOfType([]string{name}, queuedExporterTypes...) {
+			continue
+		}
+		value, defined := c.Exporters.Object[name]
+		if !defined {
+			continue
+		}
+		exporter, ok := value.(map[string]interface{})
+		if !ok {
+			exporter = map[string]interface{}{}
+			c.Exporters.Object[name] = exporter
+		}
+		for _, setting := range []string{"sending_queue", "retry_on_failure"} {
+			if _, ok := exporter[setting]; !ok {
+				exporter[setting] = map[string]interface{}{"enabled": true}
+			}
+		}
+	}
+}
+
+// hasComponentOfType returns true when one of the components is of one of the given types.
+func hasComponentOfType(components []string, types ...string) bool {
+	for _, component := range components {
+		componentType, _, _ := strings.Cut(component, "/")
+		for _, t := range types {
+			if componentType == t {
+				return true
+			}
+		}
+	}
+	return false
+}
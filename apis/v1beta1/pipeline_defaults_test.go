@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestApplyPipelineDefaults(t *testing.T) {
+	cfg := Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+		Exporters: AnyConfig{Object: map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"endpoint":      "tempo:4317",
+				"sending_queue": map[string]interface{}{"queue_size": 5000},
+			},
+			"otlphttp/backend": nil,
+			"debug":            map[string]interface{}{},
+		}},
+		Processors: &AnyConfig{Object: map[string]interface{}{
+			"batch/custom": map[string]interface{}{"timeout": "1s"},
+		}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch/custom"},
+					Exporters:  []string{"otlp"},
+				},
+				"logs": {
+					Receivers: []string{"otlp"},
+					Exporters: []string{"otlphttp/backend", "debug"},
+				},
+			},
+		},
+	}
+
+	cfg.applyPipelineDefaults()
+
+	assert.Equal(t, []string{"batch/custom"}, cfg.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, []string{"batch"}, cfg.Service.Pipelines["logs"].Processors)
+	assert.Equal(t, map[string]interface{}{}, cfg.Processors.Object["batch"])
+	assert.Equal(t, map[string]interface{}{
+		"endpoint":         "tempo:4317",
+		"sending_queue":    map[string]interface{}{"queue_size": 5000},
+		"retry_on_failure": map[string]interface{}{"enabled": true},
+	}, cfg.Exporters.Object["otlp"])
+	assert.Equal(t, map[string]interface{}{
+		"sending_queue":    map[string]interface{}{"enabled": true},
+		"retry_on_failure": map[string]interface{}{"enabled": true},
+	}, cfg.Exporters.Object["otlphttp/backend"])
+	assert.Equal(t, map[string]interface{}{}, cfg.Exporters.Object["debug"])
+}
+
+func TestApplyPipelineDefaultsWithoutProcessors(t *testing.T) {
+	cfg := Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"metrics": {Receivers: []string{"prometheus"}, Exporters: []string{"prometheusremotewrite"}},
+			},
+		},
+	}
+
+	cfg.applyPipelineDefaults()
+
+	require.NotNil(t, cfg.Processors)
+	assert.Equal(t, map[string]interface{}{"batch": map[string]interface{}{}}, cfg.Processors.Object)
+	assert.Equal(t, []string{"batch"}, cfg.Service.Pipelines["metrics"].Processors)
+}
+
+func TestConfigWithPipelineDefaults(t *testing.T) {
+	cfg := Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+
+	defaulted := cfg.WithPipelineDefaults()
+
+	assert.Equal(t, []string{"batch"}, defaulted.Service.Pipelines["traces"].Processors)
+	assert.Nil(t, cfg.Processors, "the config should be left untouched")
+	assert.Empty(t, cfg.Service.Pipelines["traces"].Processors, "the config should be left untouched")
+}
+
+func TestCollectorDefaultingWebhookKeepsPipelines(t *testing.T) {
+	cvw := &CollectorWebhook{
+		logger: logr.Discard(),
+		cfg:    config.New(config.WithEnablePipelineDefaults(true)),
+	}
+	otelcol := &OpenTelemetryCollector{
+		Spec: OpenTelemetryCollectorSpec{
+			Config: Config{
+				Service: Service{
+					Pipelines: map[string]*Pipeline{
+						"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, cvw.Default(context.Background(), otelcol))
+
+	// the pipeline defaults are added to the rendered config, the spec is left as written by the user
+	assert.Empty(t, otelcol.Spec.Config.Service.Pipelines["traces"].Processors)
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// signalsOrder is the order of the containers running the pipelines of the signals when they are split, the other
+// signals follow in alphabetical order.
+var signalsOrder = []string{"traces", "metrics", "logs"}
+
+// SignalConfig is the part of a config running the pipelines of a signal.
+// +kubebuilder:object:generate=false
+type SignalConfig struct {
+	// Signal of the pipelines, e.g. traces.
+	Signal string
+	// Config holds the pipelines of the signal and the components they use.
+	Config Config
+}
+
+// PipelineSignal returns the signal of a pipeline, e.g. traces for traces/backend.
+func PipelineSignal(pipeline string) string {
+	signal, _, _ := strings.Cut(pipeline, "/")
+	return signal
+}
+
+// SplitBySignal splits the config into a config per signal of its pipelines, holding these pipelines and the
+// receivers, processors and exporters they use. Only the config of the first signal keeps all the extensions, the
+// following ones only keep the extensions their components reference, like the authenticators of their exporters.
+// The telemetry metrics of the following ones are exposed on the ports following the metrics port that the endpoints
+// of the components don't use, so that the collectors can run in the same pod. The components are shared with the
+// config, which must not be modified.
+func (c *Config) SplitBySignal() ([]SignalConfig, error) {
+	metricsPort, err := c.Service.MetricsPort()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := map[string]map[string]*Pipeline{}
+	for name, pipeline := range c.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		signal := PipelineSignal(name)
+		if pipelines[signal] == nil {
+			pipelines[signal] = map[string]*Pipeline{}
+		}
+		pipelines[signal][name] = pipeline
+	}
+
+	var processors, extensions map[string]interface{}
+	if c.Processors != nil {
+		processors = c.Processors.Object
+	}
+	if c.Extensions != nil {
+		extensions = c.Extensions.Object
+	}
+	usedPorts := c.endpointPorts()
+	usedPorts[metricsPort] = struct{}{}
+	signalMetricsPort := metricsPort
+	configs := make([]SignalConfig, 0, len(pipelines))
+	for i, signal := range sortSignals(pipelines) {
+		cfg := Config{
+			Receivers: AnyConfig{Object: map[string]interface{}{}},
+			Exporters: AnyConfig{Object: map[string]interface{}{}},
+			Service:   Service{Pipelines: pipelines[signal]},
+		}
+		signalProcessors := map[string]interface{}{}
+		for _, pipeline := range pipelines[signal] {
+			copyComponents(cfg.Receivers.Object, c.Receivers.Object, pipeline.Receivers)
+			copyComponents(signalProcessors, processors, pipeline.Processors)
+			copyComponents(cfg.Exporters.Object, c.Exporters.Object, pipeline.Exporters)
+		}
+		if len(signalProcessors) > 0 {
+			cfg.Processors = &AnyConfig{Object: signalProcessors}
+		}
+		if i == 0 {
+			cfg.Extensions = c.Extensions
+			cfg.Service.Extensions = c.Service.Extensions
+			cfg.Service.Telemetry = c.Service.Telemetry
+		} else {
+			if referenced := c.referencedExtensions(cfg); len(referenced) > 0 {
+				cfg.Extensions = &AnyConfig{Object: map[string]interface{}{}}
+				copyComponents(cfg.Extensions.Object, extensions, referenced)
+				cfg.Service.Extensions = &referenced
+			}
+			for {
+				if _, used := usedPorts[signalMetricsPort]; !used {
+					break
+				}
+				signalMetricsPort++
+			}
+			usedPorts[signalMetricsPort] = struct{}{}
+			cfg.Service.Telemetry = telemetryWithMetricsPort(c.Service.Telemetry, signalMetricsPort)
+		}
+		configs = append(configs, SignalConfig{Signal: signal, Config: cfg})
+	}
+	return configs, nil
+}
+
+// splitPipelinesErrors returns the reasons why the pipelines of the config can't be split by signal: the
+// connectors, which link the pipelines of several signals, and the receivers used by the pipelines of several
+// signals, which can't listen twice on the same port of the pod.
+func (c *Config) splitPipelinesErrors() []string {
+	connectors := map[string]struct{}{}
+	if c.Connectors != nil {
+		for name := range c.Connectors.Object {
+			connectors[name] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(c.Service.Pipelines))
+	for name := range c.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	receiverSignals := map[string]string{}
+	for _, name := range names {
+		pipeline := c.Service.Pipelines[name]
+		if pipeline == nil {
+			continue
+		}
+		for _, component := range append(append([]string{}, pipeline.Receivers...), pipeline.Exporters...) {
+			if _, ok := connectors[component]; ok {
+				errs = append(errs, fmt.Sprintf("the pipeline %s uses the connector %s", name, component))
+			}
+		}
+		signal := PipelineSignal(name)
+		for _, receiver := range pipeline.Receivers {
+			if _, ok := connectors[receiver]; ok {
+				continue
+			}
+			if other, ok := receiverSignals[receiver]; ok && other != signal {
+				errs = append(errs, fmt.Sprintf("the receiver %s is used by the %s and %s pipelines", receiver, other, signal))
+				continue
+			}
+			receiverSignals[receiver] = signal
+		}
+	}
+	return errs
+}
+
+// sortSignals returns the signals in the order of their containers.
+func sortSignals(pipelines map[string]map[string]*Pipeline) []string {
+	var signals, others []string
+	for _, signal := range signalsOrder {
+		if _, ok := pipelines[signal]; ok {
+			signals = append(signals, signal)
+		}
+	}
+	for signal := range pipelines {
+		if !hasComponentOfType([]string{signal}, signalsOrder...) {
+			others = append(others, signal)
+		}
+	}
+	sort.Strings(others)
+	return append(signals, others...)
+}
+
+// copyComponents copies the config of the components from src to dst.
+func copyComponents(dst, src map[string]interface{}, components []string) {
+	for _, component := range components {
+		if value, ok := src[component]; ok {
+			dst[component] = value
+		}
+	}
+}
+
+// referencedExtensions returns the extensions of the service referenced by the components of the signal config, in
+// the order of the service.
+func (c *Config) referencedExtensions(signalConfig Config) []string {
+	if c.Service.Extensions == nil {
+		return nil
+	}
+	values := map[string]struct{}{}
+	components := []map[string]interface{}{signalConfig.Receivers.Object, signalConfig.Exporters.Object}
+	if signalConfig.Processors != nil {
+		components = append(components, signalConfig.Processors.Object)
+	}
+	for _, component := range components {
+		collectStrings(component, values)
+	}
+	var referenced []string
+	for _, extension := range *c.Service.Extensions {
+		if _, ok := values[extension]; ok {
+			referenced = append(referenced, extension)
+		}
+	}
+	return referenced
+}
+
+// endpointPorts returns the ports of the endpoints set in the config of the components, e.g. 8889 for the
+// 0.0.0.0:8889 endpoint of a prometheus exporter.
+func (c *Config) endpointPorts() map[int32]struct{} {
+	values := map[string]struct{}{}
+	collectStrings(c.Receivers.Object, values)
+	collectStrings(c.Exporters.Object, values)
+	for _, components := range []*AnyConfig{c.Processors, c.Extensions, c.Connectors} {
+		if components != nil {
+			collectStrings(components.Object, values)
+		}
+	}
+	ports := map[int32]struct{}{}
+	for value := range values {
+		address := value
+		if u, err := url.Parse(value); err == nil && u.Host != "" {
+			address = u.Host
+		}
+		_, port, err := net.SplitHostPort(address)
+		if err != nil {
+			continue
+		}
+		if p, err := strconv.ParseInt(port, 10, 32); err == nil {
+			ports[int32(p)] = struct{}{}
+		}
+	}
+	return ports
+}
+
+// collectStrings adds the string values found in the given value, at any depth, to the set.
+func collectStrings(value interface{}, set map[string]struct{}) {
+	switch v := value.(type) {
+	case string:
+		set[v] = struct{}{}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectStrings(item, set)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectStrings(item, set)
+		}
+	}
+}
+
+// telemetryWithMetricsPort returns a copy of the telemetry config exposing the metrics on the given port.
+func telemetryWithMetricsPort(telemetry *AnyConfig, port int32) *AnyConfig {
+	object := map[string]interface{}{}
+	if telemetry != nil {
+		for k, v := range telemetry.Object {
+			object[k] = v
+		}
+	}
+	metrics := map[string]interface{}{}
+	if m, ok := object["metrics"].(map[string]interface{}); ok {
+		for k, v := range m {
+			metrics[k] = v
+		}
+	}
+	host := "0.0.0.0"
+	if address, ok := metrics["address"].(string); ok {
+		if h, _, err := net.SplitHostPort(address); err == nil {
+			host = h
+		}
+	}
+	metrics["address"] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	object["metrics"] = metrics
+	return &AnyConfig{Object: object}
+}
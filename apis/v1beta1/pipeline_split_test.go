@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBySignal(t *testing.T) {
+	cfg := Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{
+			"otlp":       map[string]interface{}{},
+			"prometheus": map[string]interface{}{},
+		}},
+		Exporters: AnyConfig{Object: map[string]interface{}{
+			"otlp":                  map[string]interface{}{"endpoint": "tempo:4317"},
+			"prometheusremotewrite": map[string]interface{}{},
+		}},
+		Processors: &AnyConfig{Object: map[string]interface{}{
+			"batch": map[string]interface{}{},
+		}},
+		Extensions: &AnyConfig{Object: map[string]interface{}{
+			"health_check": map[string]interface{}{},
+		}},
+		Service: Service{
+			Extensions: &[]string{"health_check"},
+			Telemetry: &AnyConfig{Object: map[string]interface{}{
+				"metrics": map[string]interface{}{"level": "detailed", "address": "0.0.0.0:9090"},
+			}},
+			Pipelines: map[string]*Pipeline{
+				"metrics/scraped": {Receivers: []string{"prometheus"}, Exporters: []string{"prometheusremotewrite"}},
+				"traces":          {Receivers: []string{"otlp"}, Processors: []string{"batch"}, Exporters: []string{"otlp"}},
+			},
+		},
+	}
+
+	configs, err := cfg.SplitBySignal()
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+
+	traces := configs[0]
+	assert.Equal(t, "traces", traces.Signal)
+	assert.Equal(t, map[string]interface{}{"otlp": map[string]interface{}{}}, traces.Config.Receivers.Object)
+	assert.Equal(t, map[string]interface{}{"batch": map[string]interface{}{}}, traces.Config.Processors.Object)
+	assert.Equal(t, map[string]interface{}{"otlp": map[string]interface{}{"endpoint": "tempo:4317"}}, traces.Config.Exporters.Object)
+	assert.Equal(t, cfg.Extensions, traces.Config.Extensions)
+	assert.Equal(t, cfg.Service.Telemetry, traces.Config.Service.Telemetry)
+	assert.Equal(t, []string{"traces"}, pipelineNames(traces.Config.Service.Pipelines))
+
+	metrics := configs[1]
+	assert.Equal(t, "metrics", metrics.Signal)
+	assert.Equal(t, map[string]interface{}{"prometheus": map[string]interface{}{}}, metrics.Config.Receivers.Object)
+	assert.Nil(t, metrics.Config.Processors)
+	assert.Nil(t, metrics.Config.Extensions)
+	assert.Nil(t, metrics.Config.Service.Extensions)
+	assert.Equal(t, map[string]interface{}{
+		"metrics": map[string]interface{}{"level": "detailed", "address": "0.0.0.0:9091"},
+	}, metrics.Config.Service.Telemetry.Object)
+	assert.Equal(t, []string{"metrics/scraped"}, pipelineNames(metrics.Config.Service.Pipelines))
+
+	// the config is left untouched
+	assert.Equal(t, "0.0.0.0:9090", cfg.Service.Telemetry.Object["metrics"].(map[string]interface{})["address"])
+}
+
+func TestSplitBySignalDefaultMetricsPort(t *testing.T) {
+	cfg := Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"logs":    {Receivers: []string{"filelog"}, Exporters: []string{"debug"}},
+				"metrics": {Receivers: []string{"hostmetrics"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+
+	configs, err := cfg.SplitBySignal()
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+
+	assert.Equal(t, "metrics", configs[0].Signal)
+	assert.Nil(t, configs[0].Config.Service.Telemetry)
+	assert.Equal(t, "logs", configs[1].Signal)
+	port, err := configs[1].Config.Service.MetricsPort()
+	require.NoError(t, err)
+	assert.Equal(t, int32(8889), port)
+}
+
+func TestSplitBySignalReferencedExtensions(t *testing.T) {
+	cfg := Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{
+			"otlp":        map[string]interface{}{},
+			"filelog":     map[string]interface{}{},
+			"hostmetrics": map[string]interface{}{},
+		}},
+		Exporters: AnyConfig{Object: map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"endpoint": "tempo:4317",
+				"auth":     map[string]interface{}{"authenticator": "oauth2client"},
+			},
+			"otlphttp": map[string]interface{}{
+				"endpoint":      "https://loki:3100/otlp",
+				"auth":          map[string]interface{}{"authenticator": "oauth2client"},
+				"sending_queue": map[string]interface{}{"storage": "file_storage"},
+			},
+			"debug": map[string]interface{}{},
+		}},
+		Extensions: &AnyConfig{Object: map[string]interface{}{
+			"health_check": map[string]interface{}{},
+			"oauth2client": map[string]interface{}{"client_id": "collector"},
+			"file_storage": map[string]interface{}{"directory": "/var/lib/otelcol"},
+		}},
+		Service: Service{
+			Extensions: &[]string{"health_check", "file_storage", "oauth2client"},
+			Pipelines: map[string]*Pipeline{
+				"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"otlp"}},
+				"metrics": {Receivers: []string{"hostmetrics"}, Exporters: []string{"debug"}},
+				"logs":    {Receivers: []string{"filelog"}, Exporters: []string{"otlphttp"}},
+			},
+		},
+	}
+
+	configs, err := cfg.SplitBySignal()
+	require.NoError(t, err)
+	require.Len(t, configs, 3)
+
+	// the first signal keeps all the extensions
+	assert.Equal(t, cfg.Extensions, configs[0].Config.Extensions)
+	assert.Equal(t, cfg.Service.Extensions, configs[0].Config.Service.Extensions)
+
+	// the following ones only keep the extensions their components reference
+	assert.Equal(t, "metrics", configs[1].Signal)
+	assert.Nil(t, configs[1].Config.Extensions)
+	assert.Nil(t, configs[1].Config.Service.Extensions)
+	assert.Equal(t, "logs", configs[2].Signal)
+	assert.Equal(t, map[string]interface{}{
+		"oauth2client": map[string]interface{}{"client_id": "collector"},
+		"file_storage": map[string]interface{}{"directory": "/var/lib/otelcol"},
+	}, configs[2].Config.Extensions.Object)
+	assert.Equal(t, &[]string{"file_storage", "oauth2client"}, configs[2].Config.Service.Extensions)
+}
+
+func TestSplitBySignalMetricsPortsUsedByComponents(t *testing.T) {
+	cfg := Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{
+			"otlp":        map[string]interface{}{},
+			"filelog":     map[string]interface{}{},
+			"hostmetrics": map[string]interface{}{},
+		}},
+		Exporters: AnyConfig{Object: map[string]interface{}{
+			"prometheus": map[string]interface{}{"endpoint": "0.0.0.0:8889"},
+			"otlphttp":   map[string]interface{}{"endpoint": "http://loki:8890/otlp"},
+			"debug":      map[string]interface{}{},
+		}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				"metrics": {Receivers: []string{"hostmetrics"}, Exporters: []string{"prometheus"}},
+				"logs":    {Receivers: []string{"filelog"}, Exporters: []string{"otlphttp"}},
+			},
+		},
+	}
+
+	configs, err := cfg.SplitBySignal()
+	require.NoError(t, err)
+	require.Len(t, configs, 3)
+
+	var ports []int32
+	for _, signalConfig := range configs[1:] {
+		port, err := signalConfig.Config.Service.MetricsPort()
+		require.NoError(t, err)
+		ports = append(ports, port)
+	}
+	assert.Equal(t, []int32{8891, 8892}, ports)
+}
+
+func TestSplitPipelinesErrors(t *testing.T) {
+	cfg := Config{
+		Connectors: &AnyConfig{Object: map[string]interface{}{"spanmetrics": map[string]interface{}{}}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"logs":    {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				"metrics": {Receivers: []string{"spanmetrics"}, Exporters: []string{"debug"}},
+				"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"spanmetrics"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"the pipeline metrics uses the connector spanmetrics",
+		"the pipeline traces uses the connector spanmetrics",
+		"the receiver otlp is used by the logs and traces pipelines",
+	}, cfg.splitPipelinesErrors())
+}
+
+func pipelineNames(pipelines map[string]*Pipeline) []string {
+	var names []string
+	for name := range pipelines {
+		names = append(names, name)
+	}
+	return names
+}
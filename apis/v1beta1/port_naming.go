@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+type (
+	// PortNamingStrategy represents how the names of the ports too long to be named after their component are built.
+	// +kubebuilder:validation:Enum=portNumber;hashSuffix
+	PortNamingStrategy string
+)
+
+const (
+	// PortNamingStrategyPortNumber names the ports after their number, e.g. port-4317. Different components
+	// listening on the same port number get the same port name.
+	PortNamingStrategyPortNumber PortNamingStrategy = "portNumber"
+
+	// PortNamingStrategyHashSuffix names the ports after the beginning of their component name followed by a hash
+	// of the component name and port number, e.g. otlp-int-1a2b3c, which is unique and stable.
+	PortNamingStrategyHashSuffix PortNamingStrategy = "hashSuffix"
+)
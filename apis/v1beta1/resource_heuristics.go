@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// defaultGRPCMaxRecvMsgSizeMiB is the default max_recv_msg_size_mib of the gRPC servers of the collector.
+	defaultGRPCMaxRecvMsgSizeMiB = 4
+	mebibyte                     = 1024 * 1024
+)
+
+// ResourceHeuristic inspects a collector at admission and returns warnings about settings that are likely to be
+// inconsistent with the resources of the collector.
+// +kubebuilder:object:generate=false
+type ResourceHeuristic func(r *OpenTelemetryCollector) []string
+
+// resourceHeuristics are the heuristics evaluated by the collector webhook.
+var resourceHeuristics = []ResourceHeuristic{
+	grpcReceiverMemoryHeuristic,
+}
+
+// RegisterResourceHeuristic adds a heuristic evaluated by the collector webhook. It is not safe to call it once the
+// webhook is serving requests.
+func RegisterResourceHeuristic(heuristic ResourceHeuristic) {
+	resourceHeuristics = append(resourceHeuristics, heuristic)
+}
+
+// evaluateResourceHeuristics returns the warnings of all the registered heuristics.
+func evaluateResourceHeuristics(r *OpenTelemetryCollector) []string {
+	var warnings []string
+	for _, heuristic := range resourceHeuristics {
+		warnings = append(warnings, heuristic(r)...)
+	}
+	return warnings
+}
+
+// grpcReceiverMemoryHeuristic warns when the messages an OTLP receiver accepts over gRPC, alone or across its
+// concurrent streams, don't fit in the memory limit of the collector, which leads to OOM kill loops under load.
+func grpcReceiverMemoryHeuristic(r *OpenTelemetryCollector) []string {
+	memoryLimit := r.Spec.Resources.Limits.Memory()
+	if memoryLimit.IsZero() {
+		return nil
+	}
+	limitMiB := float64(memoryLimit.Value()) / mebibyte
+
+	var warnings []string
+	for _, name := range enabledReceivers(r.Spec.Config, "otlp") {
+		grpc, ok := nestedMap(r.Spec.Config.Receivers.Object, name, "protocols", "grpc")
+		if !ok {
+			continue
+		}
+		msgSizeMiB := float64(defaultGRPCMaxRecvMsgSizeMiB)
+		if v, ok := toFloat(grpc["max_recv_msg_size_mib"]); ok && v > 0 {
+			msgSizeMiB = v
+		}
+		streams, _ := toFloat(grpc["max_concurrent_streams"])
+
+		switch {
+		case msgSizeMiB >= limitMiB/2:
+			warnings = append(warnings, fmt.Sprintf("receiver %s accepts gRPC messages of up to %g MiB, which is more than half of the memory limit of %s, the collector might be OOM killed", name, msgSizeMiB, memoryLimit.String()))
+		case streams > 0 && msgSizeMiB*streams > limitMiB:
+			warnings = append(warnings, fmt.Sprintf("receiver %s can receive up to %g MiB over %g concurrent gRPC streams of %g MiB messages, which is more than the memory limit of %s, the collector might be OOM killed", name, msgSizeMiB*streams, streams, msgSizeMiB, memoryLimit.String()))
+		}
+	}
+	return warnings
+}
+
+// enabledReceivers returns the sorted names of the receivers of the given type used in the pipelines of the config.
+func enabledReceivers(config Config, receiverType string) []string {
+	var names []string
+	for name := range config.GetEnabledComponents()[ComponentTypeReceiver] {
+		if name == receiverType || strings.HasPrefix(name, receiverType+"/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func nestedMap(obj map[string]interface{}, keys ...string) (map[string]interface{}, bool) {
+	for _, key := range keys {
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		obj = next
+	}
+	return obj, true
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
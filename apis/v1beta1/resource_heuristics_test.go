@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGRPCReceiverMemoryHeuristic(t *testing.T) {
+	otelcol := func(memory string, grpc map[string]interface{}) *OpenTelemetryCollector {
+		r := &OpenTelemetryCollector{
+			Spec: OpenTelemetryCollectorSpec{
+				Config: Config{
+					Receivers: AnyConfig{
+						Object: map[string]interface{}{
+							"otlp/gateway": map[string]interface{}{
+								"protocols": map[string]interface{}{
+									"grpc": grpc,
+								},
+							},
+							"otlp/unused": map[string]interface{}{
+								"protocols": map[string]interface{}{
+									"grpc": map[string]interface{}{"max_recv_msg_size_mib": float64(1024)},
+								},
+							},
+						},
+					},
+					Service: Service{
+						Pipelines: map[string]*Pipeline{
+							"traces": {Receivers: []string{"otlp/gateway"}},
+						},
+					},
+				},
+			},
+		}
+		if memory != "" {
+			r.Spec.Resources.Limits = v1.ResourceList{v1.ResourceMemory: resource.MustParse(memory)}
+		}
+		return r
+	}
+
+	for _, tt := range []struct {
+		name     string
+		otelcol  *OpenTelemetryCollector
+		expected []string
+	}{
+		{
+			name:    "no memory limit",
+			otelcol: otelcol("", map[string]interface{}{"max_recv_msg_size_mib": float64(1024)}),
+		},
+		{
+			name:    "defaults",
+			otelcol: otelcol("512Mi", map[string]interface{}{}),
+		},
+		{
+			name:    "consistent settings",
+			otelcol: otelcol("1Gi", map[string]interface{}{"max_recv_msg_size_mib": float64(16), "max_concurrent_streams": float64(32)}),
+		},
+		{
+			name:    "message larger than half of the limit",
+			otelcol: otelcol("128Mi", map[string]interface{}{"max_recv_msg_size_mib": float64(64)}),
+			expected: []string{
+				"receiver otlp/gateway accepts gRPC messages of up to 64 MiB, which is more than half of the memory limit of 128Mi, the collector might be OOM killed",
+			},
+		},
+		{
+			name:    "streams larger than the limit",
+			otelcol: otelcol("1Gi", map[string]interface{}{"max_recv_msg_size_mib": float64(16), "max_concurrent_streams": float64(100)}),
+			expected: []string{
+				"receiver otlp/gateway can receive up to 1600 MiB over 100 concurrent gRPC streams of 16 MiB messages, which is more than the memory limit of 1Gi, the collector might be OOM killed",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, grpcReceiverMemoryHeuristic(tt.otelcol))
+		})
+	}
+}
+
+func TestRegisterResourceHeuristic(t *testing.T) {
+	registered := resourceHeuristics
+	defer func() { resourceHeuristics = registered }()
+
+	RegisterResourceHeuristic(func(r *OpenTelemetryCollector) []string {
+		return []string{"custom warning"}
+	})
+
+	assert.Equal(t, []string{"custom warning"}, evaluateResourceHeuristics(&OpenTelemetryCollector{}))
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Services is used to customize the Services generated for the OpenTelemetry Collector,
+// per type of Service.
+type Services struct {
+	// Base customizes the Service exposing the collector's receiver ports.
+	// +optional
+	Base ServiceSpec `json:"base,omitempty"`
+
+	// Headless customizes the headless Service exposing the collector's receiver ports.
+	// +optional
+	Headless ServiceSpec `json:"headless,omitempty"`
+
+	// Monitoring customizes the Service exposing the collector's own metrics.
+	// +optional
+	Monitoring ServiceSpec `json:"monitoring,omitempty"`
+
+	// PortGroups exposes subsets of the collector's ports through additional Services, each with its own type.
+	// This allows, for instance, exposing only the OTLP ingest ports through an internal load balancer while
+	// the base Service keeps exposing all ports inside the cluster.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	PortGroups []ServicePortGroup `json:"portGroups,omitempty"`
+}
+
+// ServicePortGroup defines an additional Service exposing a subset of the collector's ports.
+type ServicePortGroup struct {
+	// Name of the port group, used as suffix for the name of the generated Service. The names headless and
+	// monitoring are reserved for the other Services of the collector.
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+
+	// Ports is the list of names of the collector's ports to expose, as inferred from the configuration
+	// (e.g. otlp-grpc) or set in spec.ports.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Ports []string `json:"ports"`
+
+	// Type of the generated Service. Defaults to ClusterIP.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type v1.ServiceType `json:"type,omitempty"`
+
+	// ServiceSpec customizes the generated Service, e.g. with annotations requesting an internal load balancer.
+	ServiceSpec `json:",inline"`
+}
+
+// ServiceSpec defines the customizations of a Service generated by the operator.
+type ServiceSpec struct {
+	// Annotations to add to the Service, in addition to the annotations of the OpenTelemetryCollector.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// InternalTrafficPolicy describes how nodes distribute service traffic they receive on the ClusterIP.
+	// When not set, the base and headless Services use "Local" if the collector runs as a daemonset,
+	// so that applications send their telemetry to the agent on their own node, and "Cluster" otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+	InternalTrafficPolicy *v1.ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
+
+	// TopologyAwareRouting enables topology aware routing on the Service, so that traffic is preferably
+	// kept within the zone it originated from. This is typically used when agents send data to a local gateway.
+	// +optional
+	TopologyAwareRouting bool `json:"topologyAwareRouting,omitempty"`
+}
+
+// SkipServiceCreation defines whether the Services exposing the collector's receiver ports are managed
+// outside of the operator.
+type SkipServiceCreation struct {
+	// Enabled prevents the operator from creating the base, headless and port group Services of the collector,
+	// e.g. when they are managed by a service mesh. The monitoring Service is still created. It can't be used with
+	// an ingress or routes, which point to the base Service.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ServiceSelector selects the externally managed Services exposing the collector's receiver ports, in the
+	// namespace of the collector. The operator reports whether the selected Services expose the ports inferred
+	// from the collector's configuration in the ServicePortsExposed condition, and emits a warning event when
+	// ports start missing.
+	// +optional
+	ServiceSelector *metav1.LabelSelector `json:"serviceSelector,omitempty"`
+}
@@ -98,6 +98,9 @@ type Config struct {
 	Capabilities      map[Capability]bool `yaml:"capabilities"`
 	HeartbeatInterval time.Duration       `yaml:"heartbeatInterval,omitempty"`
 	Name              string              `yaml:"name,omitempty"`
+	// AuditLog records the configs applied to the collectors in their audit log ConfigMap, as the operator does for
+	// its own mutations of the collectors.
+	AuditLog bool `yaml:"auditLog,omitempty"`
 }
 
 func NewConfig(logger logr.Logger) *Config {
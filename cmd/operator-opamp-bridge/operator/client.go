@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
 )
 
 const (
@@ -62,6 +63,7 @@ type Client struct {
 	k8sClient         client.Client
 	close             chan bool
 	name              string
+	auditLog          bool
 }
 
 var _ ConfigApplier = &Client{}
@@ -76,6 +78,12 @@ func NewClient(name string, log logr.Logger, c client.Client, componentsAllowed
 	}
 }
 
+// WithAuditLog records the configs applied to the collectors in their audit log when enabled.
+func (c *Client) WithAuditLog(enabled bool) *Client {
+	c.auditLog = enabled
+	return c
+}
+
 func (c Client) labelSetContainsLabel(instance *v1alpha1.OpenTelemetryCollector, label, value string) bool {
 	if instance == nil || instance.GetLabels() == nil {
 		return false
@@ -98,14 +106,33 @@ func (c Client) create(ctx context.Context, name string, namespace string, colle
 	}
 	collector.ObjectMeta.Labels[ResourceIdentifierKey] = ResourceIdentifierValue
 	c.log.Info("Creating collector")
-	return c.k8sClient.Create(ctx, collector)
+	if err := c.k8sClient.Create(ctx, collector); err != nil {
+		return err
+	}
+	c.recordMutation(ctx, &v1alpha1.OpenTelemetryCollector{}, collector, "created the collector from the config received from the OpAMP server")
+	return nil
 }
 
 func (c Client) update(ctx context.Context, old *v1alpha1.OpenTelemetryCollector, new *v1alpha1.OpenTelemetryCollector) error {
 	new.ObjectMeta = old.ObjectMeta
 	new.TypeMeta = old.TypeMeta
 	c.log.Info("Updating collector")
-	return c.k8sClient.Update(ctx, new)
+	if err := c.k8sClient.Update(ctx, new); err != nil {
+		return err
+	}
+	c.recordMutation(ctx, old, new, "applied the config received from the OpAMP server")
+	return nil
+}
+
+// recordMutation records the mutation of the collector in its audit log, when enabled. The config is already applied,
+// failing to record it is only logged.
+func (c Client) recordMutation(ctx context.Context, before, after *v1alpha1.OpenTelemetryCollector, message string) {
+	if !c.auditLog {
+		return
+	}
+	if err := auditlog.RecordMutation(ctx, c.k8sClient, before, after, auditlog.SourceOpAMP, message); err != nil {
+		c.log.Error(err, "failed to record the mutation of the collector in its audit log", "name", after.Name, "namespace", after.Namespace)
+	}
 }
 
 func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentConfigFile) error {
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
 )
 
 var (
@@ -45,7 +46,7 @@ const (
 func getFakeClient(t *testing.T, lists ...client.ObjectList) client.WithWatch {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
-		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{})
+		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{}, &v1.ConfigMap{}, &v1.ConfigMapList{})
 		metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 		return nil
 	})
@@ -208,6 +209,34 @@ func Test_collectorUpdate(t *testing.T) {
 	assert.Contains(t, allInstances, *updatedInstance)
 }
 
+func Test_collectorAuditLog(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil).WithAuditLog(true)
+
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.Apply(name, namespace, &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should apply base config")
+
+	newColConfig, err := loadConfig("testdata/updated-collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.Apply(name, namespace, &protobufs.AgentConfigFile{Body: newColConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should be able to update collector")
+
+	instance, err := c.GetInstance(name, namespace)
+	require.NoError(t, err)
+	entries, err := auditlog.Get(context.Background(), fakeClient, instance)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, auditlog.SourceOpAMP, entries[0].Source)
+	assert.Equal(t, "created the collector from the config received from the OpAMP server", entries[0].Message)
+	assert.Equal(t, auditlog.SourceOpAMP, entries[1].Source)
+	assert.Equal(t, "applied the config received from the OpAMP server", entries[1].Message)
+	assert.Contains(t, entries[1].Patch, "memory_limiter")
+}
+
 func Test_collectorDelete(t *testing.T) {
 	name := "test"
 	namespace := "testing"
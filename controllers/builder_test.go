@@ -37,7 +37,6 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
-	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
@@ -88,8 +87,11 @@ service:
 	err := go_yaml.Unmarshal([]byte(goodConfigYaml), &goodConfig)
 	require.NoError(t, err)
 
-	goodConfigHash, _ := manifestutils.GetConfigMapSHA(goodConfig)
-	goodConfigHash = goodConfigHash[:8]
+	goodConfigSHA, err := collector.GetCollectorConfigSHA(config.New(), v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{Config: goodConfig},
+	})
+	require.NoError(t, err)
+	goodConfigHash := goodConfigSHA[:8]
 
 	one := int32(1)
 	type args struct {
@@ -134,7 +136,7 @@ service:
 							"app.kubernetes.io/version":    "latest",
 						},
 						Annotations: map[string]string{
-							"opentelemetry-operator-config/sha256": "8f1dbf79c3a8d89f9799abcb169581e2de70318e00e10cac1cd71fe234185401",
+							"opentelemetry-operator-config/sha256": goodConfigSHA,
 							"prometheus.io/path":                   "/metrics",
 							"prometheus.io/port":                   "8888",
 							"prometheus.io/scrape":                 "true",
@@ -156,7 +158,7 @@ service:
 									"app.kubernetes.io/version":    "latest",
 								},
 								Annotations: map[string]string{
-									"opentelemetry-operator-config/sha256": "8f1dbf79c3a8d89f9799abcb169581e2de70318e00e10cac1cd71fe234185401",
+									"opentelemetry-operator-config/sha256": goodConfigSHA,
 									"prometheus.io/path":                   "/metrics",
 									"prometheus.io/port":                   "8888",
 									"prometheus.io/scrape":                 "true",
@@ -217,6 +219,7 @@ service:
 												MountPath: "/conf",
 											},
 										},
+										TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 									},
 								},
 								ShareProcessNamespace: ptr.To(false),
@@ -384,7 +387,7 @@ service:
 							"app.kubernetes.io/version":    "latest",
 						},
 						Annotations: map[string]string{
-							"opentelemetry-operator-config/sha256": "8f1dbf79c3a8d89f9799abcb169581e2de70318e00e10cac1cd71fe234185401",
+							"opentelemetry-operator-config/sha256": goodConfigSHA,
 							"prometheus.io/path":                   "/metrics",
 							"prometheus.io/port":                   "8888",
 							"prometheus.io/scrape":                 "true",
@@ -406,7 +409,7 @@ service:
 									"app.kubernetes.io/version":    "latest",
 								},
 								Annotations: map[string]string{
-									"opentelemetry-operator-config/sha256": "8f1dbf79c3a8d89f9799abcb169581e2de70318e00e10cac1cd71fe234185401",
+									"opentelemetry-operator-config/sha256": goodConfigSHA,
 									"prometheus.io/path":                   "/metrics",
 									"prometheus.io/port":                   "8888",
 									"prometheus.io/scrape":                 "true",
@@ -467,6 +470,7 @@ service:
 												MountPath: "/conf",
 											},
 										},
+										TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 									},
 								},
 								ShareProcessNamespace: ptr.To(false),
@@ -670,7 +674,7 @@ service:
 							"app.kubernetes.io/version":    "latest",
 						},
 						Annotations: map[string]string{
-							"opentelemetry-operator-config/sha256": "8f1dbf79c3a8d89f9799abcb169581e2de70318e00e10cac1cd71fe234185401",
+							"opentelemetry-operator-config/sha256": goodConfigSHA,
 							"prometheus.io/path":                   "/metrics",
 							"prometheus.io/port":                   "8888",
 							"prometheus.io/scrape":                 "true",
@@ -692,7 +696,7 @@ service:
 									"app.kubernetes.io/version":    "latest",
 								},
 								Annotations: map[string]string{
-									"opentelemetry-operator-config/sha256": "8f1dbf79c3a8d89f9799abcb169581e2de70318e00e10cac1cd71fe234185401",
+									"opentelemetry-operator-config/sha256": goodConfigSHA,
 									"prometheus.io/path":                   "/metrics",
 									"prometheus.io/port":                   "8888",
 									"prometheus.io/scrape":                 "true",
@@ -753,6 +757,7 @@ service:
 												MountPath: "/conf",
 											},
 										},
+										TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 									},
 								},
 								ShareProcessNamespace: ptr.To(false),
@@ -1144,8 +1149,15 @@ service:
 	err := go_yaml.Unmarshal([]byte(goodConfigYaml), &goodConfig)
 	require.NoError(t, err)
 
-	goodConfigHash, _ := manifestutils.GetConfigMapSHA(goodConfig)
-	goodConfigHash = goodConfigHash[:8]
+	// the scrape configs are served by the target allocator, they aren't part of the collector config hash
+	goodConfigSHA, err := collector.GetCollectorConfigSHA(config.New(), v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config:          goodConfig,
+			TargetAllocator: v1beta1.TargetAllocatorEmbedded{Enabled: true},
+		},
+	})
+	require.NoError(t, err)
+	goodConfigHash := goodConfigSHA[:8]
 
 	one := int32(1)
 	type args struct {
@@ -1198,7 +1210,7 @@ service:
 							"app.kubernetes.io/version":    "latest",
 						},
 						Annotations: map[string]string{
-							"opentelemetry-operator-config/sha256": "57434ab43283b8d3cd0b8584aad7f05226ec24225f28d93b3969d3d2ccf7d009",
+							"opentelemetry-operator-config/sha256": goodConfigSHA,
 							"prometheus.io/path":                   "/metrics",
 							"prometheus.io/port":                   "8888",
 							"prometheus.io/scrape":                 "true",
@@ -1221,7 +1233,7 @@ service:
 									"app.kubernetes.io/version":    "latest",
 								},
 								Annotations: map[string]string{
-									"opentelemetry-operator-config/sha256": "57434ab43283b8d3cd0b8584aad7f05226ec24225f28d93b3969d3d2ccf7d009",
+									"opentelemetry-operator-config/sha256": goodConfigSHA,
 									"prometheus.io/path":                   "/metrics",
 									"prometheus.io/port":                   "8888",
 									"prometheus.io/scrape":                 "true",
@@ -1281,6 +1293,7 @@ service:
 												MountPath: "/conf",
 											},
 										},
+										TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 									},
 								},
 								ShareProcessNamespace: ptr.To(false),
@@ -1303,7 +1316,9 @@ service:
 							"app.kubernetes.io/part-of":    "opentelemetry",
 							"app.kubernetes.io/version":    "latest",
 						},
-						Annotations: nil,
+						Annotations: map[string]string{
+							collector.ScrapeConfigsHashAnnotation: "163f4139fc5cc9a24b94b0cf29cf772a2f8ccdba3151b0f206a22aa4cbbe42e5",
+						},
 					},
 					Data: map[string]string{
 						"collector.yaml": "exporters:\n    logging: null\nreceivers:\n    prometheus:\n        config: {}\n        target_allocator:\n            collector_id: ${POD_NAME}\n            endpoint: http://test-targetallocator:80\n            interval: 30s\nservice:\n    pipelines:\n        metrics:\n            exporters:\n                - logging\n            processors: []\n            receivers:\n                - prometheus\n",
@@ -1592,7 +1607,7 @@ prometheus_cr:
 							"app.kubernetes.io/version":    "latest",
 						},
 						Annotations: map[string]string{
-							"opentelemetry-operator-config/sha256": "57434ab43283b8d3cd0b8584aad7f05226ec24225f28d93b3969d3d2ccf7d009",
+							"opentelemetry-operator-config/sha256": goodConfigSHA,
 							"prometheus.io/path":                   "/metrics",
 							"prometheus.io/port":                   "8888",
 							"prometheus.io/scrape":                 "true",
@@ -1615,7 +1630,7 @@ prometheus_cr:
 									"app.kubernetes.io/version":    "latest",
 								},
 								Annotations: map[string]string{
-									"opentelemetry-operator-config/sha256": "57434ab43283b8d3cd0b8584aad7f05226ec24225f28d93b3969d3d2ccf7d009",
+									"opentelemetry-operator-config/sha256": goodConfigSHA,
 									"prometheus.io/path":                   "/metrics",
 									"prometheus.io/port":                   "8888",
 									"prometheus.io/scrape":                 "true",
@@ -1675,6 +1690,7 @@ prometheus_cr:
 												MountPath: "/conf",
 											},
 										},
+										TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 									},
 								},
 								ShareProcessNamespace: ptr.To(false),
@@ -1697,7 +1713,9 @@ prometheus_cr:
 							"app.kubernetes.io/part-of":    "opentelemetry",
 							"app.kubernetes.io/version":    "latest",
 						},
-						Annotations: nil,
+						Annotations: map[string]string{
+							collector.ScrapeConfigsHashAnnotation: "163f4139fc5cc9a24b94b0cf29cf772a2f8ccdba3151b0f206a22aa4cbbe42e5",
+						},
 					},
 					Data: map[string]string{
 						"collector.yaml": "exporters:\n    logging: null\nreceivers:\n    prometheus:\n        config: {}\n        target_allocator:\n            collector_id: ${POD_NAME}\n            endpoint: http://test-targetallocator:80\n            interval: 30s\nservice:\n    pipelines:\n        metrics:\n            exporters:\n                - logging\n            processors: []\n            receivers:\n                - prometheus\n",
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// authorizeSubresource authenticates the bearer token of the request with a TokenReview, and checks with a
+// SubjectAccessReview that its user can get the given subresource of the collector. It returns the HTTP status to
+// reply with when the request isn't allowed.
+func (r *OpenTelemetryCollectorReconciler) authorizeSubresource(req *http.Request, key client.ObjectKey, subresource string) (int, error) {
+	token, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return http.StatusUnauthorized, fmt.Errorf("a bearer token is required")
+	}
+	tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := r.Create(req.Context(), tokenReview); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review the token: %w", err)
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, fmt.Errorf("the token isn't valid: %s", tokenReview.Status.Error)
+	}
+
+	user := tokenReview.Status.User
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace:   key.Namespace,
+			Verb:        "get",
+			Group:       v1beta1.GroupVersion.Group,
+			Resource:    "opentelemetrycollectors",
+			Subresource: subresource,
+			Name:        key.Name,
+		},
+		User:   user.Username,
+		Groups: user.Groups,
+		UID:    user.UID,
+		Extra:  extra,
+	}}
+	if err := r.Create(req.Context(), accessReview); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review the access of %s: %w", user.Username, err)
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("%s can't get the %s of the opentelemetrycollector %s", user.Username, subresource, key)
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// subresourceReaders are the users allowed to get the subresources of the collectors.
+var subresourceReaders = map[string]string{
+	EffectiveConfigSubresource: "reader",
+	SupportBundleSubresource:   "support",
+}
+
+// reviewFuncs authenticates the tokens named after their users, and allows the subresourceReaders to get the
+// subresources of the collectors.
+var reviewFuncs = interceptor.Funcs{
+	Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		switch review := obj.(type) {
+		case *authenticationv1.TokenReview:
+			if review.Spec.Token != "invalid" {
+				review.Status.Authenticated = true
+				review.Status.User = authenticationv1.UserInfo{Username: review.Spec.Token}
+			}
+			return nil
+		case *authorizationv1.SubjectAccessReview:
+			attributes := review.Spec.ResourceAttributes
+			review.Status.Allowed = review.Spec.User == subresourceReaders[attributes.Subresource] &&
+				attributes.Verb == "get" && attributes.Group == "opentelemetry.io" &&
+				attributes.Resource == "opentelemetrycollectors"
+			return nil
+		}
+		return cl.Create(ctx, obj, opts...)
+	},
+}
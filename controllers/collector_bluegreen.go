@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+// retainedDeployments returns the deployments kept while the Services still select their pods: the deployment of the
+// color not built for the current spec during blue/green rollouts, the deployment of the previous rollouts until the
+// deployment of the first color is ready when blue/green rollouts are enabled, and the deployments of the colors
+// until the deployment replacing them is ready when blue/green rollouts are disabled.
+func retainedDeployments(otelcol v1beta1.OpenTelemetryCollector, ownedObjects map[types.UID]client.Object) map[types.UID]client.Object {
+	retained := map[types.UID]client.Object{}
+	if otelcol.Spec.Mode != v1beta1.ModeDeployment {
+		return retained
+	}
+	blueGreen, started := collector.BlueGreenEnabled(otelcol), collector.BlueGreenStarted(otelcol)
+	replacementReady := false
+	for _, object := range ownedObjects {
+		if d, ok := object.(*appsv1.Deployment); ok && d.Name == collector.WorkloadName(otelcol) {
+			replacementReady = collector.DeploymentReady(*d)
+		}
+	}
+	for uid, object := range ownedObjects {
+		if _, ok := object.(*appsv1.Deployment); !ok {
+			continue
+		}
+		colored := isBlueGreenDeployment(otelcol, object.GetName())
+		switch {
+		case blueGreen && colored,
+			blueGreen && !started && object.GetName() == collector.WorkloadName(otelcol),
+			!blueGreen && colored && !replacementReady:
+			retained[uid] = object
+		}
+	}
+	return retained
+}
+
+func isBlueGreenDeployment(otelcol v1beta1.OpenTelemetryCollector, name string) bool {
+	return name == collector.ColorWorkloadName(otelcol, string(v1beta1.BlueGreenColorBlue)) ||
+		name == collector.ColorWorkloadName(otelcol, string(v1beta1.BlueGreenColorGreen))
+}
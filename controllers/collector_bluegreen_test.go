@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func colorDeployment(name string, ready bool) *appsv1.Deployment {
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)}}
+	if ready {
+		d.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+	}
+	return d
+}
+
+func TestRetainedDeployments(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		blueGreen *v1beta1.BlueGreen
+		status    *v1beta1.BlueGreenStatus
+		owned     []client.Object
+		expected  []string
+	}{
+		{
+			name:      "enabling before the first color is ready",
+			blueGreen: &v1beta1.BlueGreen{},
+			owned:     []client.Object{colorDeployment("test-collector", true), colorDeployment("test-collector-blue", false)},
+			expected:  []string{"test-collector", "test-collector-blue"},
+		},
+		{
+			name:      "enabled",
+			blueGreen: &v1beta1.BlueGreen{},
+			status:    &v1beta1.BlueGreenStatus{Active: v1beta1.BlueGreenColorBlue, ActiveRevision: "revision"},
+			owned:     []client.Object{colorDeployment("test-collector", true), colorDeployment("test-collector-blue", true), colorDeployment("test-collector-green", true)},
+			expected:  []string{"test-collector-blue", "test-collector-green"},
+		},
+		{
+			name:     "disabling before the deployment is ready",
+			owned:    []client.Object{colorDeployment("test-collector", false), colorDeployment("test-collector-blue", true)},
+			expected: []string{"test-collector-blue"},
+		},
+		{
+			name:  "disabled",
+			owned: []client.Object{colorDeployment("test-collector", true), colorDeployment("test-collector-blue", true)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec:       v1beta1.OpenTelemetryCollectorSpec{Mode: v1beta1.ModeDeployment, BlueGreen: tt.blueGreen},
+				Status:     v1beta1.OpenTelemetryCollectorStatus{BlueGreen: tt.status},
+			}
+			owned := map[types.UID]client.Object{}
+			for _, obj := range tt.owned {
+				owned[obj.GetUID()] = obj
+			}
+
+			var retained []string
+			for _, obj := range retainedDeployments(otelcol, owned) {
+				retained = append(retained, obj.GetName())
+			}
+			assert.ElementsMatch(t, tt.expected, retained)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// drainStartedAnnotation records on a collector pod when it was taken out of the ring to be drained.
+	drainStartedAnnotation = "operator.opentelemetry.io/drain-started"
+
+	defaultDrainTimeout = 5 * time.Minute
+	// drainCheckInterval is the interval between two checks of the queues of the pods being drained. The first check
+	// happens one interval after the pods were taken out of the ring, to let the loadbalancing exporters notice it.
+	drainCheckInterval = 10 * time.Second
+)
+
+var defaultDrainQueueMetrics = []string{"otelcol_exporter_queue_size"}
+
+// queueScraper returns the sum of the given internal metrics of a collector pod.
+type queueScraper func(ctx context.Context, pod corev1.Pod, port int32, metrics []string) (float64, error)
+
+// scrapeQueues reads the queue metrics from the internal metrics endpoint of the pod.
+func scrapeQueues(ctx context.Context, pod corev1.Pod, port int32, metrics []string) (float64, error) {
+	url := fmt.Sprintf("http://%s:%d/metrics", pod.Status.PodIP, port)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scrape the metrics of %s: %w", pod.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to scrape the metrics of %s: unexpected status %s", pod.Name, resp.Status)
+	}
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the metrics of %s: %w", pod.Name, err)
+	}
+	var sum float64
+	for _, name := range metrics {
+		for _, metric := range families[name].GetMetric() {
+			sum += metric.GetGauge().GetValue()
+		}
+	}
+	return sum, nil
+}
+
+// drainScaleDown holds the replicas of a statefulset collector being scaled down until the pods to remove are
+// drained: the pods are taken out of the loadbalancing ring, and removed once their queues are empty or the drain
+// timeout elapsed. It returns the time after which the drain has to be checked again, or zero when no drain is in
+// progress. The desired objects aren't to be applied when it fails, as the replicas being drained aren't held then.
+func (r *OpenTelemetryCollectorReconciler) drainScaleDown(ctx context.Context, params manifests.Params, desiredObjects []client.Object) (time.Duration, error) {
+	drain := params.OtelCol.Spec.ScaleDownDrain
+	if drain == nil {
+		return 0, nil
+	}
+	var desired *appsv1.StatefulSet
+	for _, obj := range desiredObjects {
+		if ss, ok := obj.(*appsv1.StatefulSet); ok && ss.Name == collector.WorkloadName(params.OtelCol) {
+			desired = ss
+		}
+	}
+	if desired == nil {
+		return 0, nil
+	}
+	existing := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	desiredReplicas, currentReplicas := replicasOrDefault(desired.Spec.Replicas), replicasOrDefault(existing.Spec.Replicas)
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(params.OtelCol.Namespace),
+		client.MatchingLabels(manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, collector.ComponentOpenTelemetryCollector))); err != nil {
+		return 0, fmt.Errorf("failed to list the collector pods: %w", err)
+	}
+
+	timeout := defaultDrainTimeout
+	if drain.Timeout != nil {
+		timeout = drain.Timeout.Duration
+	}
+	queueMetrics := drain.QueueMetrics
+	if len(queueMetrics) == 0 {
+		queueMetrics = defaultDrainQueueMetrics
+	}
+	metricsPort, err := params.OtelCol.Spec.Config.Service.MetricsPort()
+	if err != nil {
+		return 0, err
+	}
+
+	var requeueAfter time.Duration
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		ordinal, ok := podOrdinal(existing.Name, pod.Name)
+		if !ok {
+			continue
+		}
+		if ordinal < desiredReplicas || currentReplicas <= desiredReplicas {
+			// the pod is kept, e.g. when the scale down was reverted before its end
+			if err := r.setRingMember(ctx, pod, true); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err := r.setRingMember(ctx, pod, false); err != nil {
+			return 0, err
+		}
+		started, err := time.Parse(time.RFC3339, pod.Annotations[drainStartedAnnotation])
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s annotation on pod %s: %w", drainStartedAnnotation, pod.Name, err)
+		}
+		elapsed := time.Since(started)
+		if elapsed >= timeout {
+			r.recorder.Event(&params.OtelCol, corev1.EventTypeWarning, "DrainTimeout",
+				fmt.Sprintf("the pod %s was not drained within %s, removing it", pod.Name, timeout))
+			continue
+		}
+		if elapsed < drainCheckInterval {
+			requeueAfter = drainCheckInterval - elapsed
+			continue
+		}
+		queued, err := r.scrapeQueues(ctx, *pod, metricsPort, queueMetrics)
+		if err != nil {
+			params.Log.Error(err, "failed to check whether the pod is drained", "pod", pod.Name)
+		}
+		if err != nil || queued > 0 {
+			requeueAfter = drainCheckInterval
+		}
+	}
+
+	if currentReplicas > desiredReplicas && requeueAfter > 0 {
+		// keep the replicas until the pods are drained
+		desired.Spec.Replicas = &currentReplicas
+		return requeueAfter, nil
+	}
+	return 0, nil
+}
+
+// setRingMember adds the pod to, or takes it out of, the loadbalancing ring of the collector.
+func (r *OpenTelemetryCollectorReconciler) setRingMember(ctx context.Context, pod *corev1.Pod, member bool) error {
+	value := strconv.FormatBool(member)
+	_, draining := pod.Annotations[drainStartedAnnotation]
+	if pod.Labels[collector.RingMemberLabel] == value && draining != member {
+		return nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[collector.RingMemberLabel] = value
+	if member {
+		delete(pod.Annotations, drainStartedAnnotation)
+	} else {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[drainStartedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
+	if err := r.Patch(ctx, pod, patch); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to update the ring membership of pod %s: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// podOrdinal returns the ordinal of a pod of the given statefulset.
+func podOrdinal(statefulSet, pod string) (int32, bool) {
+	suffix, ok := strings.CutPrefix(pod, statefulSet+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func drainTestObjects(replicas int32) (v1beta1.OpenTelemetryCollector, []client.Object) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tail",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:           v1beta1.ModeStatefulSet,
+			ScaleDownDrain: &v1beta1.ScaleDownDrain{},
+		},
+	}
+	objects := []client.Object{
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "tail-collector", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		},
+	}
+	for _, name := range []string{"tail-collector-0", "tail-collector-1", "tail-collector-2"} {
+		labels := manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+		labels[collector.RingMemberLabel] = "true"
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		})
+	}
+	return otelcol, objects
+}
+
+func desiredStatefulSet(replicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "tail-collector", Namespace: "default"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+	}
+}
+
+// startDrain moves the start of the drain of the pods back in time.
+func startDrain(t *testing.T, cl client.Client, ago time.Duration) {
+	for _, name := range []string{"tail-collector-1", "tail-collector-2"} {
+		pod := &corev1.Pod{}
+		require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, pod))
+		pod.Annotations[drainStartedAnnotation] = time.Now().Add(-ago).UTC().Format(time.RFC3339)
+		require.NoError(t, cl.Update(context.Background(), pod))
+	}
+}
+
+func TestDrainScaleDown(t *testing.T) {
+	otelcol, objects := drainTestObjects(3)
+	cl := fake.NewClientBuilder().WithObjects(objects...).Build()
+	queued := map[string]float64{}
+	r := &OpenTelemetryCollectorReconciler{
+		Client:   cl,
+		recorder: record.NewFakeRecorder(10),
+		scrapeQueues: func(_ context.Context, pod corev1.Pod, port int32, metrics []string) (float64, error) {
+			assert.Equal(t, int32(8888), port)
+			assert.Equal(t, []string{"otelcol_exporter_queue_size"}, metrics)
+			return queued[pod.Name], nil
+		},
+	}
+	params := manifests.Params{OtelCol: otelcol, Log: logr.Discard()}
+
+	// the pods to remove are taken out of the ring, and the replicas are kept
+	desired := desiredStatefulSet(1)
+	requeueAfter, err := r.drainScaleDown(context.Background(), params, []client.Object{desired})
+	require.NoError(t, err)
+	assert.Greater(t, requeueAfter, time.Duration(0))
+	assert.Equal(t, int32(3), *desired.Spec.Replicas)
+	for name, member := range map[string]string{"tail-collector-0": "true", "tail-collector-1": "false", "tail-collector-2": "false"} {
+		pod := &corev1.Pod{}
+		require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, pod))
+		assert.Equal(t, member, pod.Labels[collector.RingMemberLabel], name)
+	}
+
+	// a pod still has queued data
+	startDrain(t, cl, time.Minute)
+	queued["tail-collector-2"] = 5
+	desired = desiredStatefulSet(1)
+	requeueAfter, err = r.drainScaleDown(context.Background(), params, []client.Object{desired})
+	require.NoError(t, err)
+	assert.Equal(t, drainCheckInterval, requeueAfter)
+	assert.Equal(t, int32(3), *desired.Spec.Replicas)
+
+	// the pods are drained
+	queued["tail-collector-2"] = 0
+	desired = desiredStatefulSet(1)
+	requeueAfter, err = r.drainScaleDown(context.Background(), params, []client.Object{desired})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), requeueAfter)
+	assert.Equal(t, int32(1), *desired.Spec.Replicas)
+}
+
+func TestDrainScaleDownTimeout(t *testing.T) {
+	otelcol, objects := drainTestObjects(3)
+	otelcol.Spec.ScaleDownDrain.Timeout = &metav1.Duration{Duration: 2 * time.Minute}
+	cl := fake.NewClientBuilder().WithObjects(objects...).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &OpenTelemetryCollectorReconciler{
+		Client:   cl,
+		recorder: recorder,
+		scrapeQueues: func(context.Context, corev1.Pod, int32, []string) (float64, error) {
+			return 10, nil
+		},
+	}
+	params := manifests.Params{OtelCol: otelcol, Log: logr.Discard()}
+
+	_, err := r.drainScaleDown(context.Background(), params, []client.Object{desiredStatefulSet(1)})
+	require.NoError(t, err)
+	startDrain(t, cl, 3*time.Minute)
+
+	desired := desiredStatefulSet(1)
+	requeueAfter, err := r.drainScaleDown(context.Background(), params, []client.Object{desired})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), requeueAfter)
+	assert.Equal(t, int32(1), *desired.Spec.Replicas)
+	assert.Len(t, recorder.Events, 2)
+}
+
+func TestDrainScaleDownReverted(t *testing.T) {
+	otelcol, objects := drainTestObjects(3)
+	cl := fake.NewClientBuilder().WithObjects(objects...).Build()
+	r := &OpenTelemetryCollectorReconciler{
+		Client:   cl,
+		recorder: record.NewFakeRecorder(10),
+	}
+	params := manifests.Params{OtelCol: otelcol, Log: logr.Discard()}
+
+	_, err := r.drainScaleDown(context.Background(), params, []client.Object{desiredStatefulSet(2)})
+	require.NoError(t, err)
+
+	// the scale down is reverted before the pod is drained
+	desired := desiredStatefulSet(3)
+	requeueAfter, err := r.drainScaleDown(context.Background(), params, []client.Object{desired})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), requeueAfter)
+	assert.Equal(t, int32(3), *desired.Spec.Replicas)
+	pod := &corev1.Pod{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "tail-collector-2"}, pod))
+	assert.Equal(t, "true", pod.Labels[collector.RingMemberLabel])
+	assert.NotContains(t, pod.Annotations, drainStartedAnnotation)
+}
+
+func TestPodOrdinal(t *testing.T) {
+	ordinal, ok := podOrdinal("tail-collector", "tail-collector-12")
+	assert.True(t, ok)
+	assert.Equal(t, int32(12), ordinal)
+
+	_, ok = podOrdinal("tail-collector", "tail-collector-canary-0")
+	assert.False(t, ok)
+}
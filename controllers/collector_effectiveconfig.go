@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const (
+	// EffectiveConfigSubresource is the subresource of the collectors that the users need to be allowed to get, to
+	// read their effective config.
+	EffectiveConfigSubresource = "effectiveconfig"
+)
+
+// EffectiveConfigHandler serves the effective configs of the collectors, to be registered with the namespace and
+// name path values, e.g. at /effective-config/{namespace}/{name}. The effective config is the config rendered for
+// the collector pods, with the active schedule, the config variables and the changes made by the operator, like
+// the target allocator settings. The requests are authenticated with the bearer token of the user, who needs to be
+// allowed to get the effectiveconfig subresource of the collector.
+func (r *OpenTelemetryCollectorReconciler) EffectiveConfigHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		key := client.ObjectKey{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+		if status, err := r.authorizeSubresource(req, key, EffectiveConfigSubresource); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		config, err := r.effectiveConfig(req.Context(), key)
+		if apierrors.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		if _, err := w.Write([]byte(config)); err != nil {
+			r.log.Error(err, "failed to write the effective config", "opentelemetrycollector", key)
+		}
+	})
+}
+
+// effectiveConfig renders the config of the collector like in the reconciliation, and returns the configs of its
+// ConfigMap. When the pipelines are split into containers, the configs of the signals follow the config of the
+// collector as separate YAML documents, each one preceded by the ConfigMap entry it comes from.
+func (r *OpenTelemetryCollectorReconciler) effectiveConfig(ctx context.Context, key client.ObjectKey) (string, error) {
+	var instance v1beta1.OpenTelemetryCollector
+	if err := r.Get(ctx, key, &instance); err != nil {
+		return "", err
+	}
+	rendered, err := r.renderConfig(ctx, instance, time.Now())
+	if err != nil {
+		return "", err
+	}
+	params, err := r.getParams(rendered)
+	if err != nil {
+		return "", err
+	}
+	configMap, err := collector.ConfigMap(params)
+	if err != nil {
+		return "", err
+	}
+	entry := params.Config.CollectorConfigMapEntry()
+	if len(configMap.Data) == 1 {
+		return configMap.Data[entry], nil
+	}
+	entries := make([]string, 0, len(configMap.Data))
+	for e := range configMap.Data {
+		if e != entry {
+			entries = append(entries, e)
+		}
+	}
+	sort.Strings(entries)
+	documents := make([]string, 0, len(configMap.Data))
+	for _, e := range append([]string{entry}, entries...) {
+		documents = append(documents, fmt.Sprintf("# Source: %s\n%s", e, configMap.Data[e]))
+	}
+	return strings.Join(documents, "---\n"), nil
+}
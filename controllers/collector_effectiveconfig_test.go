@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "effective", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"},
+				}}}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				}},
+			},
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(unitTestScheme(t)).WithObjects(otelcol).WithInterceptorFuncs(reviewFuncs).Build()
+	r := NewReconciler(Params{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   cl.Scheme(),
+		Config:   config.New(),
+		Recorder: record.NewFakeRecorder(10),
+	})
+	mux := http.NewServeMux()
+	mux.Handle("GET /effective-config/{namespace}/{name}", r.EffectiveConfigHandler())
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	for _, tt := range []struct {
+		desc           string
+		token          string
+		path           string
+		expectedStatus int
+	}{
+		{
+			desc:           "no token",
+			path:           "/effective-config/default/effective",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "invalid token",
+			token:          "invalid",
+			path:           "/effective-config/default/effective",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "user not allowed",
+			token:          "someone",
+			path:           "/effective-config/default/effective",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "collector not found",
+			token:          "reader",
+			path:           "/effective-config/default/missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			desc:           "effective config",
+			token:          "reader",
+			path:           "/effective-config/default/effective",
+			expectedStatus: http.StatusOK,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "application/yaml", resp.Header.Get("Content-Type"))
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			// the config is rendered with the changes made by the operator, like the IPv6 wildcard endpoints
+			assert.Contains(t, string(body), "otlp")
+			assert.Contains(t, string(body), "[::]:4317")
+			assert.NotContains(t, string(body), "0.0.0.0")
+		})
+	}
+}
+
+func TestEffectiveConfigSplitPipelines(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "split", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}}}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+					"metrics": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				}},
+			},
+			SplitPipelinesIntoContainers: &v1beta1.SplitPipelinesIntoContainers{Enabled: true},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(unitTestScheme(t)).WithObjects(otelcol).Build()
+	r := NewReconciler(Params{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   cl.Scheme(),
+		Config:   config.New(config.WithCollectorConfigMapEntry("otel.yaml")),
+		Recorder: record.NewFakeRecorder(10),
+	})
+
+	effective, err := r.effectiveConfig(context.Background(), client.ObjectKeyFromObject(otelcol))
+	require.NoError(t, err)
+
+	// the config of the collector comes first, followed by the configs of the signals sorted by entry
+	documents := strings.Split(effective, "---\n")
+	require.Len(t, documents, 3)
+	assert.True(t, strings.HasPrefix(documents[0], "# Source: otel.yaml\n"))
+	assert.True(t, strings.HasPrefix(documents[1], "# Source: otel-metrics.yaml\n"))
+	assert.True(t, strings.HasPrefix(documents[2], "# Source: otel-traces.yaml\n"))
+	assert.Contains(t, documents[1], "metrics:")
+	assert.Contains(t, documents[2], "traces:")
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// findNameCollisions returns the desired objects whose names are taken by objects controlled by another owner, like
+// another collector whose resources are named the same by the collector name template, or by objects nothing
+// controls, like the ones created by hand. Reconciling them would take the objects over from their owner or their
+// author.
+func (r *OpenTelemetryCollectorReconciler) findNameCollisions(ctx context.Context, otelcol v1beta1.OpenTelemetryCollector, desiredObjects []client.Object) ([]string, error) {
+	var collisions []string
+	for _, desired := range desiredObjects {
+		if !isNamespaceScoped(desired) {
+			continue
+		}
+		existing := desired.DeepCopyObject().(client.Object)
+		if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %w", r.objectReference(desired), err)
+		}
+		owner := metav1.GetControllerOf(existing)
+		if owner == nil {
+			collisions = append(collisions, fmt.Sprintf("%s (not controlled by any owner)", r.objectReference(desired)))
+		} else if owner.UID != otelcol.UID {
+			collisions = append(collisions, fmt.Sprintf("%s (controlled by %s/%s)", r.objectReference(desired), owner.Kind, owner.Name))
+		}
+	}
+	sort.Strings(collisions)
+	return collisions, nil
+}
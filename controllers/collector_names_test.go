@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func controlledBy(name string, uid types.UID) []metav1.OwnerReference {
+	return []metav1.OwnerReference{{
+		APIVersion: "opentelemetry.io/v1beta1",
+		Kind:       "OpenTelemetryCollector",
+		Name:       name,
+		UID:        uid,
+		Controller: ptr.To(true),
+	}}
+}
+
+func TestFindNameCollisions(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gateway",
+			Namespace:   "default",
+			UID:         types.UID("gateway-uid"),
+			Annotations: map[string]string{v1beta1.ResourceNameAnnotation: "otel-gateway"},
+		},
+	}
+	existing := []client.Object{
+		// owned by the collector
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "otel-gateway", Namespace: "default", OwnerReferences: controlledBy("gateway", "gateway-uid"),
+		}},
+		// owned by another collector named the same
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name: "otel-gateway", Namespace: "default", OwnerReferences: controlledBy("other", "other-uid"),
+		}},
+		// not controlled by anything
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "otel-gateway-0123abcd", Namespace: "default",
+		}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(unitTestScheme(t)).WithObjects(existing...).Build()
+	r := &OpenTelemetryCollectorReconciler{Client: cl, config: config.New()}
+
+	desired := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "otel-gateway", Namespace: "default"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "otel-gateway", Namespace: "default"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "otel-gateway-headless", Namespace: "default"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "otel-gateway-0123abcd", Namespace: "default"}},
+	}
+	collisions, err := r.findNameCollisions(context.Background(), otelcol, desired)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"ConfigMap/otel-gateway-0123abcd (not controlled by any owner)",
+		"Service/otel-gateway (controlled by OpenTelemetryCollector/other)",
+	}, collisions)
+}
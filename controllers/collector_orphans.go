@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// findTargetAllocatorObjects returns the target allocator objects generated for the collector, which are orphaned
+// once the target allocator is disabled.
+func (r *OpenTelemetryCollectorReconciler) findTargetAllocatorObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	ownedObjectTypes := []client.Object{
+		&appsv1.Deployment{},
+		&policyV1.PodDisruptionBudget{},
+		&corev1.Service{},
+		&corev1.ServiceAccount{},
+		&corev1.ConfigMap{},
+	}
+	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() && r.config.PrometheusCRAvailability() == prometheus.Available {
+		ownedObjectTypes = append(ownedObjectTypes, &monitoringv1.ServiceMonitor{})
+	}
+	listOps := &client.ListOptions{
+		Namespace:     params.OtelCol.Namespace,
+		LabelSelector: labels.SelectorFromSet(manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, targetallocator.ComponentOpenTelemetryTargetAllocator)),
+	}
+	for _, objectType := range ownedObjectTypes {
+		objs, err := getList(ctx, r, objectType, listOps)
+		if err != nil {
+			return nil, err
+		}
+		for uid, object := range objs {
+			// the objects of a TargetAllocator CR with the name of the collector share the labels
+			if metav1.IsControlledBy(object, &params.OtelCol) {
+				ownedObjects[uid] = object
+			}
+		}
+	}
+	return ownedObjects, nil
+}
+
+// pruneOrphanedObjects returns the owned objects to delete after the reconciliation of the desired objects. With the
+// DryRun orphaned objects policy, the workloads of the other modes, the ServiceAccounts and the target allocator
+// objects aren't deleted, and the orphaned ones are reported instead. The other owned objects, e.g. the previous
+// versions of the config, are deleted whatever the policy.
+func (r *OpenTelemetryCollectorReconciler) pruneOrphanedObjects(otelcol v1beta1.OpenTelemetryCollector, desiredObjects []client.Object, ownedObjects map[types.UID]client.Object) (map[types.UID]client.Object, []string) {
+	if otelcol.Spec.OrphanedObjectsPolicy != v1beta1.OrphanedObjectsPolicyDryRun {
+		return ownedObjects, nil
+	}
+	desired := map[string]bool{}
+	for _, object := range desiredObjects {
+		desired[r.objectReference(object)] = true
+	}
+	toDelete := map[types.UID]client.Object{}
+	var orphaned []string
+	for uid, object := range ownedObjects {
+		if !orphanedObjectsPolicyApplies(object) {
+			toDelete[uid] = object
+			continue
+		}
+		if reference := r.objectReference(object); !desired[reference] {
+			orphaned = append(orphaned, reference)
+		}
+	}
+	sort.Strings(orphaned)
+	return toDelete, orphaned
+}
+
+// orphanedObjectsPolicyApplies returns true for the owned objects whose deletion depends on the orphaned objects
+// policy.
+func orphanedObjectsPolicyApplies(object client.Object) bool {
+	switch object.(type) {
+	case *appsv1.DaemonSet, *appsv1.StatefulSet, *corev1.ServiceAccount:
+		return true
+	}
+	return object.GetLabels()["app.kubernetes.io/component"] == targetallocator.ComponentOpenTelemetryTargetAllocator
+}
+
+// objectReference returns the Kind/name reference of the object reported in the status.
+func (r *OpenTelemetryCollectorReconciler) objectReference(object client.Object) string {
+	kind := object.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(object, r.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	return fmt.Sprintf("%s/%s", kind, object.GetName())
+}
+
+// reportOrphanedObjects records the orphaned objects in the status of the collector.
+func (r *OpenTelemetryCollectorReconciler) reportOrphanedObjects(ctx context.Context, log logr.Logger, otelcol v1beta1.OpenTelemetryCollector, orphaned []string) error {
+	if slices.Equal(otelcol.Status.OrphanedObjects, orphaned) {
+		return nil
+	}
+	if len(orphaned) > 0 {
+		log.Info("keeping orphaned objects with the DryRun orphaned objects policy", "objects", orphaned)
+	}
+	changed := otelcol.DeepCopy()
+	changed.Status.OrphanedObjects = orphaned
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&otelcol)); err != nil {
+		return fmt.Errorf("failed to report the orphaned objects: %w", err)
+	}
+	return nil
+}
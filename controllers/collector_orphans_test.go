@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+)
+
+// unitTestScheme returns the scheme of the tests of the reconcilers' internals, which can't use the one of the envtest
+// suite of the controllers_test package.
+func unitTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func orphanTestObjects() (v1beta1.OpenTelemetryCollector, []client.Object) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "orphans",
+			Namespace: "default",
+			UID:       types.UID("collector-uid"),
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+		},
+	}
+	owner := []metav1.OwnerReference{{
+		APIVersion: "opentelemetry.io/v1beta1",
+		Kind:       "OpenTelemetryCollector",
+		Name:       otelcol.Name,
+		UID:        otelcol.UID,
+		Controller: ptr.To(true),
+	}}
+	collectorLabels := manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	targetAllocatorLabels := manifestutils.SelectorLabels(otelcol.ObjectMeta, targetallocator.ComponentOpenTelemetryTargetAllocator)
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "orphans-collector", Namespace: "default", UID: "deployment", Labels: collectorLabels, OwnerReferences: owner,
+		}},
+		// the autoscaler removed from the collector
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{
+			Name: "orphans-collector", Namespace: "default", UID: "hpa", Labels: collectorLabels, OwnerReferences: owner,
+		}},
+		// the workload of the daemonset mode the collector was switched from
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "orphans-collector", Namespace: "default", UID: "daemonset", Labels: collectorLabels, OwnerReferences: owner,
+		}},
+		// the objects of the target allocator disabled on the collector
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "orphans-targetallocator", Namespace: "default", UID: "ta-deployment", Labels: targetAllocatorLabels, OwnerReferences: owner,
+		}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "orphans-targetallocator", Namespace: "default", UID: "ta-configmap", Labels: targetAllocatorLabels, OwnerReferences: owner,
+		}},
+		// the target allocator of a TargetAllocator CR named after the collector
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name: "orphans-targetallocator", Namespace: "default", UID: "ta-service", Labels: targetAllocatorLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "opentelemetry.io/v1alpha1",
+				Kind:       "TargetAllocator",
+				Name:       otelcol.Name,
+				UID:        types.UID("targetallocator-uid"),
+				Controller: ptr.To(true),
+			}},
+		}},
+	}
+	return otelcol, objects
+}
+
+func TestFindOtelOwnedObjectsOrphans(t *testing.T) {
+	otelcol, objects := orphanTestObjects()
+	cl := fake.NewClientBuilder().WithScheme(unitTestScheme(t)).WithObjects(objects...).Build()
+	r := &OpenTelemetryCollectorReconciler{Client: cl, config: config.New()}
+	params := manifests.Params{OtelCol: otelcol, Config: r.config, Log: logr.Discard()}
+
+	owned, err := r.findOtelOwnedObjects(context.Background(), params)
+	require.NoError(t, err)
+	var uids []types.UID
+	for uid := range owned {
+		uids = append(uids, uid)
+	}
+	assert.ElementsMatch(t, []types.UID{"deployment", "hpa", "daemonset", "ta-deployment", "ta-configmap"}, uids)
+}
+
+func TestPruneOrphanedObjects(t *testing.T) {
+	otelcol, objects := orphanTestObjects()
+	cl := fake.NewClientBuilder().WithScheme(unitTestScheme(t)).WithObjects(objects...).Build()
+	r := &OpenTelemetryCollectorReconciler{Client: cl, config: config.New()}
+	params := manifests.Params{OtelCol: otelcol, Config: r.config, Log: logr.Discard()}
+	owned, err := r.findOtelOwnedObjects(context.Background(), params)
+	require.NoError(t, err)
+	desired := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "orphans-collector", Namespace: "default"}},
+	}
+
+	// the orphaned objects are deleted by default
+	toDelete, orphaned := r.pruneOrphanedObjects(otelcol, desired, owned)
+	assert.Equal(t, owned, toDelete)
+	assert.Empty(t, orphaned)
+
+	// the orphaned objects are only reported with DryRun, the objects removed before the policy existed are deleted
+	otelcol.Spec.OrphanedObjectsPolicy = v1beta1.OrphanedObjectsPolicyDryRun
+	toDelete, orphaned = r.pruneOrphanedObjects(otelcol, desired, owned)
+	var uids []types.UID
+	for uid := range toDelete {
+		uids = append(uids, uid)
+	}
+	assert.ElementsMatch(t, []types.UID{"deployment", "hpa"}, uids)
+	assert.Equal(t, []string{
+		"ConfigMap/orphans-targetallocator",
+		"DaemonSet/orphans-collector",
+		"Deployment/orphans-targetallocator",
+	}, orphaned)
+}
+
+func TestReportOrphanedObjects(t *testing.T) {
+	otelcol, _ := orphanTestObjects()
+	cl := fake.NewClientBuilder().WithScheme(unitTestScheme(t)).WithObjects(&otelcol).WithStatusSubresource(&otelcol).Build()
+	r := &OpenTelemetryCollectorReconciler{Client: cl}
+
+	require.NoError(t, r.reportOrphanedObjects(context.Background(), logr.Discard(), otelcol, []string{"DaemonSet/orphans-collector"}))
+	reported := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(&otelcol), reported))
+	assert.Equal(t, []string{"DaemonSet/orphans-collector"}, reported.Status.OrphanedObjects)
+
+	// the report is cleared once the objects are gone
+	require.NoError(t, r.reportOrphanedObjects(context.Background(), logr.Discard(), *reported, nil))
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(&otelcol), reported))
+	assert.Empty(t, reported.Status.OrphanedObjects)
+}
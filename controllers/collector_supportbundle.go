@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/supportbundle"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+)
+
+// SupportBundleSubresource is the subresource of the collectors that the users need to be allowed to get, to download
+// their support bundles.
+const SupportBundleSubresource = "supportbundle"
+
+// SupportBundleHandler serves the support bundles of the collectors, to be registered with the namespace and name
+// path values, e.g. at /support-bundle/{namespace}/{name}. The events are read with the given reader, as they aren't
+// cached. The requests are authenticated with the bearer token of the user, who needs to be allowed to get the
+// supportbundle subresource of the collector.
+func (r *OpenTelemetryCollectorReconciler) SupportBundleHandler(apiReader client.Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		key := client.ObjectKey{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+		if status, err := r.authorizeSubresource(req, key, SupportBundleSubresource); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		bundle, err := r.supportBundle(req.Context(), apiReader, key)
+		if apierrors.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		dir := fmt.Sprintf("%s-%s", key.Namespace, key.Name)
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dir+".tar.gz"))
+		if err := bundle.Write(w, dir, time.Now()); err != nil {
+			r.log.Error(err, "failed to write the support bundle", "opentelemetrycollector", key)
+		}
+	})
+}
+
+// supportBundle gathers the CR of the collector, its rendered config, the manifests generated for it, the status of
+// its pods and the recent events of the collector and of its objects.
+func (r *OpenTelemetryCollectorReconciler) supportBundle(ctx context.Context, apiReader client.Reader, key client.ObjectKey) (*supportbundle.Bundle, error) {
+	var instance v1beta1.OpenTelemetryCollector
+	if err := r.Get(ctx, key, &instance); err != nil {
+		return nil, err
+	}
+	bundle := &supportbundle.Bundle{}
+	bundle.AddYAML("version.yaml", version.Get())
+
+	cr := instance.DeepCopy()
+	cr.ManagedFields = nil
+	cr.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("OpenTelemetryCollector"))
+	bundle.AddYAML("opentelemetrycollector.yaml", cr)
+
+	// the config is rendered like in the reconciliation, with the active schedule and the config variables
+	rendered, err := r.renderConfig(ctx, instance, time.Now())
+	if err != nil {
+		bundle.AddError("config.yaml", err)
+	} else if config, yamlErr := rendered.Spec.Config.Yaml(); yamlErr != nil {
+		bundle.AddError("config.yaml", yamlErr)
+	} else {
+		bundle.Add("config.yaml", []byte(config))
+	}
+
+	uids := map[types.UID]bool{instance.UID: true}
+	params, err := r.getParams(rendered)
+	if err != nil {
+		bundle.AddError("manifests", err)
+	} else {
+		r.addManifests(bundle, params)
+		owned, ownedErr := r.findOtelOwnedObjects(ctx, params)
+		if ownedErr != nil {
+			bundle.AddError("events.yaml", ownedErr)
+		}
+		for uid := range owned {
+			uids[uid] = true
+		}
+	}
+
+	pods := &corev1.PodList{}
+	err = r.List(ctx, pods, client.InNamespace(instance.Namespace),
+		client.MatchingLabels(manifestutils.SelectorLabels(instance.ObjectMeta, collector.ComponentOpenTelemetryCollector)))
+	if err != nil {
+		bundle.AddError("pods.yaml", err)
+	} else {
+		statuses := map[string]corev1.PodStatus{}
+		for _, pod := range pods.Items {
+			statuses[pod.Name] = pod.Status
+			uids[pod.UID] = true
+		}
+		bundle.AddYAML("pods.yaml", statuses)
+	}
+
+	events := &corev1.EventList{}
+	if err = apiReader.List(ctx, events, client.InNamespace(instance.Namespace)); err != nil {
+		bundle.AddError("events.yaml", err)
+	} else {
+		bundle.AddYAML("events.yaml", relatedEvents(events.Items, uids))
+	}
+	return bundle, nil
+}
+
+// addManifests adds the manifests generated for the collector to the bundle.
+func (r *OpenTelemetryCollectorReconciler) addManifests(bundle *supportbundle.Bundle, params manifests.Params) {
+	objects, err := BuildCollector(params)
+	if err != nil {
+		bundle.AddError("manifests", err)
+		return
+	}
+	for _, object := range objects {
+		gvk, gvkErr := apiutil.GVKForObject(object, r.scheme)
+		if gvkErr != nil {
+			bundle.AddError(object.GetName(), gvkErr)
+			continue
+		}
+		object.GetObjectKind().SetGroupVersionKind(gvk)
+		bundle.AddYAML(fmt.Sprintf("manifests/%s-%s.yaml", strings.ToLower(gvk.Kind), object.GetName()), object)
+	}
+}
+
+// relatedEvents returns the events of the objects with the given UIDs, oldest first.
+func relatedEvents(events []corev1.Event, uids map[types.UID]bool) []corev1.Event {
+	var related []corev1.Event
+	for _, event := range events {
+		if uids[event.InvolvedObject.UID] {
+			event.ManagedFields = nil
+			related = append(related, event)
+		}
+	}
+	sort.SliceStable(related, func(i, j int) bool {
+		return eventTime(related[i]).Before(eventTime(related[j]))
+	})
+	return related
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	return event.EventTime.Time
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func supportBundleServer(t *testing.T, objects ...client.Object) *httptest.Server {
+	cl := fake.NewClientBuilder().WithScheme(unitTestScheme(t)).WithObjects(objects...).WithInterceptorFuncs(reviewFuncs).Build()
+	r := NewReconciler(Params{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   cl.Scheme(),
+		Config:   config.New(),
+		Recorder: record.NewFakeRecorder(10),
+	})
+	mux := http.NewServeMux()
+	mux.Handle("GET /support-bundle/{namespace}/{name}", r.SupportBundleHandler(cl))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// getSupportBundle requests the support bundle at the given path, with the bearer token when set.
+func getSupportBundle(t *testing.T, server *httptest.Server, path, token string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func readSupportBundle(t *testing.T, r io.Reader) map[string]string {
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	archive := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(archive)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
+
+func TestSupportBundle(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", Namespace: "default", UID: "collector-uid"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}}}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				}},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bundle-collector-abc", Namespace: "default", UID: "pod-uid",
+			Labels: manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	event := func(name string, uid types.UID, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{UID: uid},
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+	now := time.Now()
+	server := supportBundleServer(t, otelcol, pod,
+		event("pod-event", "pod-uid", now),
+		event("collector-event", "collector-uid", now.Add(-time.Minute)),
+		event("other-event", "other-uid", now),
+	)
+
+	resp := getSupportBundle(t, server, "/support-bundle/default/bundle", "support")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/gzip", resp.Header.Get("Content-Type"))
+	files := readSupportBundle(t, resp.Body)
+
+	for _, name := range []string{"version.yaml", "opentelemetrycollector.yaml", "config.yaml", "manifests/deployment-bundle-collector.yaml", "pods.yaml", "events.yaml"} {
+		assert.Contains(t, files, "default-bundle/"+name)
+	}
+	assert.NotContains(t, files, "default-bundle/errors.txt")
+	assert.Contains(t, files["default-bundle/opentelemetrycollector.yaml"], "kind: OpenTelemetryCollector")
+	assert.Contains(t, files["default-bundle/config.yaml"], "otlp")
+	assert.Contains(t, files["default-bundle/pods.yaml"], "bundle-collector-abc")
+	// the events of the collector and of its pods, oldest first
+	events := files["default-bundle/events.yaml"]
+	assert.Contains(t, events, "collector-event")
+	assert.NotContains(t, events, "other-event")
+	assert.Less(t, strings.Index(events, "collector-event"), strings.Index(events, "pod-event"))
+}
+
+func TestSupportBundleNotFound(t *testing.T) {
+	server := supportBundleServer(t)
+
+	resp := getSupportBundle(t, server, "/support-bundle/default/missing", "support")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSupportBundleUnauthorized(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", Namespace: "default"},
+	}
+	server := supportBundleServer(t, otelcol)
+
+	for _, tt := range []struct {
+		desc           string
+		token          string
+		expectedStatus int
+	}{
+		{
+			desc:           "no token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "invalid token",
+			token:          "invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			desc:           "user not allowed",
+			token:          "someone",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "user allowed to get the effective config only",
+			token:          "reader",
+			expectedStatus: http.StatusForbidden,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			resp := getSupportBundle(t, server, "/support-bundle/default/bundle", tt.token)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
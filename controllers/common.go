@@ -33,6 +33,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collectortest"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/fleet"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
 )
@@ -95,6 +97,38 @@ func BuildOpAMPBridge(params manifests.Params) ([]client.Object, error) {
 	return resources, nil
 }
 
+// BuildCollectorFleet returns the generation and collected errors of all manifests for a given fleet.
+func BuildCollectorFleet(params fleet.Params) ([]client.Object, error) {
+	builders := []manifests.Builder[fleet.Params]{
+		fleet.Build,
+	}
+	var resources []client.Object
+	for _, builder := range builders {
+		objs, err := builder(params)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, objs...)
+	}
+	return resources, nil
+}
+
+// BuildCollectorTest returns the generation and collected errors of all manifests for a given collector test.
+func BuildCollectorTest(params collectortest.Params) ([]client.Object, error) {
+	builders := []manifests.Builder[collectortest.Params]{
+		collectortest.Build,
+	}
+	var resources []client.Object
+	for _, builder := range builders {
+		objs, err := builder(params)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, objs...)
+	}
+	return resources, nil
+}
+
 // BuildTargetAllocator returns the generation and collected errors of all manifests for a given instance.
 func BuildTargetAllocator(params targetallocator.Params) ([]client.Object, error) {
 	builders := []manifests.Builder[targetallocator.Params]{
@@ -161,6 +195,7 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 			if delErr != nil {
 				return delErr
 			}
+			delete(ownedObjects, existing.GetUID())
 			continue
 		} else if crudErr != nil {
 			l.Error(crudErr, "failed to configure desired")
@@ -193,7 +228,8 @@ func deleteObjects(ctx context.Context, kubeClient client.Client, logger logr.Lo
 		)
 
 		l.Info("pruning unmanaged resource")
-		err := kubeClient.Delete(ctx, obj)
+		// the pods of the jobs are not deleted with the jobs in the default orphan propagation
+		err := kubeClient.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		if err != nil {
 			l.Error(err, "failed to delete resource")
 			pruneErrs = append(pruneErrs, err)
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// InstrumentationReconciler reconciles the status of an Instrumentation object.
+type InstrumentationReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+
+	// injectedPods reads the injected pods from the cache, as the client doesn't cache the pods
+	injectedPods client.Reader
+}
+
+// InstrumentationReconcilerParams is the set of options to build a new InstrumentationReconciler.
+type InstrumentationReconcilerParams struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+func NewInstrumentationReconciler(params InstrumentationReconcilerParams) *InstrumentationReconciler {
+	return &InstrumentationReconciler{
+		Client: params.Client,
+		scheme: params.Scheme,
+		log:    params.Log,
+	}
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Reconcile reports in the status of the Instrumentation the collector its exporter endpoint was defaulted to by the
+// webhook, and the workloads currently injected with the Instrumentation.
+func (r *InstrumentationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+	var instance v1alpha1.Instrumentation
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Instrumentation")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	changed := instance.DeepCopy()
+	changed.Status.DefaultExporterEndpoint = nil
+	if collector, ok := instance.Annotations[constants.AnnotationDefaultExporterEndpointCollector]; ok {
+		changed.Status.DefaultExporterEndpoint = &v1alpha1.DefaultExporterEndpoint{
+			Collector: collector,
+			Endpoint:  instance.Spec.Exporter.Endpoint,
+		}
+	}
+
+	pods, err := instrumentation.InjectedPods(ctx, r.injectedPods, req.NamespacedName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list the injected pods: %w", err)
+	}
+	injection, err := instrumentation.InjectionReport(ctx, r.Client, req.NamespacedName, pods)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to report the injected workloads: %w", err)
+	}
+	changed.Status.Injection = injection
+
+	if reflect.DeepEqual(changed.Status, instance.Status) {
+		return ctrl.Result{}, nil
+	}
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the Instrumentation CR: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *InstrumentationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, instrumentation.InjectedPodIndex, instrumentation.InjectedPodIndexValues)
+	if err != nil {
+		return err
+	}
+	r.injectedPods = mgr.GetCache()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Instrumentation{}).
+		Watches(&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.instrumentationsOfPod),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				pod, ok := obj.(*corev1.Pod)
+				return ok && instrumentation.HasInjectedAgent(*pod)
+			}))).
+		Complete(r)
+}
+
+// instrumentationsOfPod returns the Instrumentations injected in the pod, whose report has to be updated.
+func (r *InstrumentationReconciler) instrumentationsOfPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	agents, err := instrumentation.InjectedAgents(ctx, r.Client, *pod)
+	if err != nil {
+		r.log.Error(err, "failed to get the Instrumentations injected in the pod", "pod", client.ObjectKeyFromObject(pod))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, agent := range agents {
+		requests = append(requests, reconcile.Request{NamespacedName: agent.Instrumentation})
+	}
+	return requests
+}
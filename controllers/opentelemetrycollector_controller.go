@@ -19,12 +19,15 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
@@ -36,13 +39,18 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/fairqueue"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
@@ -64,6 +72,8 @@ type OpenTelemetryCollectorReconciler struct {
 	scheme   *runtime.Scheme
 	log      logr.Logger
 	config   config.Config
+
+	scrapeQueues queueScraper
 }
 
 // Params is the set of options to build a new OpenTelemetryCollectorReconciler.
@@ -78,9 +88,15 @@ type Params struct {
 func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
 	ownedObjects := map[types.UID]client.Object{}
 	ownedObjectTypes := []client.Object{
+		&appsv1.Deployment{},
+		&appsv1.DaemonSet{},
+		&appsv1.StatefulSet{},
 		&autoscalingv2.HorizontalPodAutoscaler{},
 		&networkingv1.Ingress{},
 		&policyV1.PodDisruptionBudget{},
+		&corev1.Service{},
+		&corev1.ServiceAccount{},
+		&batchv1.Job{},
 	}
 	listOps := &client.ListOptions{
 		Namespace:     params.OtelCol.Namespace,
@@ -104,6 +120,16 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 			ownedObjects[uid] = object
 		}
 	}
+	for uid := range retainedDeployments(params.OtelCol, ownedObjects) {
+		delete(ownedObjects, uid)
+	}
+	targetAllocatorObjects, err := r.findTargetAllocatorObjects(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	for uid, object := range targetAllocatorObjects {
+		ownedObjects[uid] = object
+	}
 	if params.Config.CreateRBACPermissions() == rbac.Available {
 		objs, err := r.findClusterRoleObjects(ctx, params)
 		if err != nil {
@@ -115,11 +141,21 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 	}
 
 	configMapList := &corev1.ConfigMapList{}
-	err := r.List(ctx, configMapList, listOps)
+	err = r.List(ctx, configMapList, listOps)
 	if err != nil {
 		return nil, fmt.Errorf("error listing ConfigMaps: %w", err)
 	}
-	ownedConfigMaps := r.getConfigMapsToRemove(params.OtelCol.Spec.ConfigVersions, configMapList)
+	// the sampling strategies ConfigMap isn't a version of the collector config
+	samplingConfigMap := collector.JaegerRemoteSamplingConfigMapName(params.OtelCol)
+	configVersions := &corev1.ConfigMapList{}
+	for i := range configMapList.Items {
+		if configMapList.Items[i].Name == samplingConfigMap {
+			ownedObjects[configMapList.Items[i].GetUID()] = &configMapList.Items[i]
+			continue
+		}
+		configVersions.Items = append(configVersions.Items, configMapList.Items[i])
+	}
+	ownedConfigMaps := r.getConfigMapsToRemove(params.OtelCol.Spec.ConfigVersions, configVersions)
 	for i := range ownedConfigMaps {
 		ownedObjects[ownedConfigMaps[i].GetUID()] = &ownedConfigMaps[i]
 	}
@@ -195,13 +231,18 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 		scheme:   p.Scheme,
 		config:   p.Config,
 		recorder: p.Recorder,
+
+		scrapeQueues: scrapeQueues,
 	}
 	return r
 }
 
 // +kubebuilder:rbac:groups="",resources=pods;configmaps;services;serviceaccounts;persistentvolumeclaims;persistentvolumes,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;list;patch
 // +kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
@@ -212,6 +253,7 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetryconfigvars,verbs=get;list;watch
 
 // Reconcile the current state of an OpenTelemetry collector resource with the desired state.
 func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -273,18 +315,62 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		}
 	}
 
+	// the config of the active schedule and the config variables are only applied to the managed objects, the CR is
+	// left as is
+	now := time.Now()
+	if len(instance.Spec.ConfigSchedules) > 0 || featuregate.EnableConfigVars.IsEnabled() {
+		rendered, renderErr := r.renderConfig(ctx, instance, now)
+		if renderErr != nil {
+			return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, now, renderErr)
+		}
+		if params, err = r.getParams(rendered); err != nil {
+			log.Error(err, "Failed to create manifest.Params")
+			return ctrl.Result{}, err
+		}
+	}
+
 	desiredObjects, buildErr := BuildCollector(params)
 	if buildErr != nil {
 		return ctrl.Result{}, buildErr
 	}
 
+	collisions, err := r.findNameCollisions(ctx, instance, desiredObjects)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(collisions) > 0 {
+		err = fmt.Errorf("the names of %s are taken, set another name with the %s annotation", strings.Join(collisions, ", "), v1beta1.ResourceNameAnnotation)
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, now, err)
+	}
+
+	drainRequeueAfter, err := r.drainScaleDown(ctx, params, desiredObjects)
+	if err != nil {
+		// the statefulset isn't scaled down until its pods are drained
+		err = fmt.Errorf("failed to drain the collector pods being scaled down: %w", err)
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, now, err)
+	}
+
 	ownedObjects, err := r.findOtelOwnedObjects(ctx, params)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	ownedObjects, orphanedObjects := r.pruneOrphanedObjects(instance, desiredObjects, ownedObjects)
 	err = reconcileDesiredObjects(ctx, r.Client, log, &instance, params.Scheme, desiredObjects, ownedObjects)
-	return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, err)
+	if err == nil {
+		err = r.reportOrphanedObjects(ctx, log, instance, orphanedObjects)
+	}
+	result, err := collectorStatus.HandleReconcileStatus(ctx, log, params, instance, now, err)
+	if drainRequeueAfter > 0 && (result.RequeueAfter == 0 || drainRequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = drainRequeueAfter
+	}
+	if next := collector.NextConfigScheduleChange(instance, now); !next.IsZero() {
+		// the config is switched when a window of the schedules opens or closes
+		if scheduleRequeueAfter := next.Sub(now); result.RequeueAfter == 0 || scheduleRequeueAfter < result.RequeueAfter {
+			result.RequeueAfter = scheduleRequeueAfter
+		}
+	}
+	return result, err
 }
 
 // SetupWithManager tells the manager what our controller is interested in.
@@ -301,7 +387,8 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&networkingv1.Ingress{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Owns(&policyV1.PodDisruptionBudget{})
+		Owns(&policyV1.PodDisruptionBudget{}).
+		Owns(&batchv1.Job{})
 
 	if r.config.CreateRBACPermissions() == rbac.Available {
 		builder.Owns(&rbacv1.ClusterRoleBinding{})
@@ -315,10 +402,54 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 	if r.config.OpenShiftRoutesAvailability() == openshift.RoutesAvailable {
 		builder.Owns(&routev1.Route{})
 	}
+	if featuregate.EnableConfigVars.IsEnabled() {
+		builder.Watches(&v1alpha1.OpenTelemetryConfigVars{}, handler.EnqueueRequestsFromMapFunc(r.collectorsReferencingConfigVars))
+	}
+	if fairness := r.config.ReconcileFairness(); fairness.IsRateLimited() || len(fairness.PriorityNamespaces) > 0 {
+		builder.WithOptions(controller.Options{NewQueue: fairqueue.New(fairness)})
+	}
 
 	return builder.Complete(r)
 }
 
+// renderConfig merges the config of the active schedule into the config of the collector, and resolves the config
+// variables it references.
+func (r *OpenTelemetryCollectorReconciler) renderConfig(ctx context.Context, instance v1beta1.OpenTelemetryCollector, now time.Time) (v1beta1.OpenTelemetryCollector, error) {
+	rendered, err := collector.WithConfigSchedule(instance, now)
+	if err != nil {
+		return instance, err
+	}
+	if !featuregate.EnableConfigVars.IsEnabled() || !collector.ReferencesConfigVars(rendered) {
+		return rendered, nil
+	}
+	catalogs := &v1alpha1.OpenTelemetryConfigVarsList{}
+	if err := r.List(ctx, catalogs); err != nil {
+		return instance, fmt.Errorf("failed to list the OpenTelemetryConfigVars: %w", err)
+	}
+	vars, err := collector.ConfigVars(catalogs.Items)
+	if err != nil {
+		return instance, err
+	}
+	return collector.WithConfigVars(rendered, vars)
+}
+
+// collectorsReferencingConfigVars returns the collectors whose config references config variables, which have to be
+// rendered again when the OpenTelemetryConfigVars change.
+func (r *OpenTelemetryCollectorReconciler) collectorsReferencingConfigVars(ctx context.Context, _ client.Object) []reconcile.Request {
+	collectors := &v1beta1.OpenTelemetryCollectorList{}
+	if err := r.List(ctx, collectors); err != nil {
+		r.log.Error(err, "failed to list the collectors referencing config variables")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, otelcol := range collectors.Items {
+		if collector.ReferencesConfigVars(otelcol) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&otelcol)})
+		}
+	}
+	return requests
+}
+
 const collectorFinalizer = "opentelemetrycollector.opentelemetry.io/finalizer"
 
 func (r *OpenTelemetryCollectorReconciler) finalizeCollector(ctx context.Context, params manifests.Params) error {
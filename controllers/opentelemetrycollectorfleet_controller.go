@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/fleet"
+	fleetStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/fleet"
+)
+
+// OpenTelemetryCollectorFleetReconciler reconciles a OpenTelemetryCollectorFleet object.
+type OpenTelemetryCollectorFleetReconciler struct {
+	client.Client
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	recorder record.EventRecorder
+	config   config.Config
+}
+
+// OpenTelemetryCollectorFleetReconcilerParams is the set of options to build a new OpenTelemetryCollectorFleetReconciler.
+type OpenTelemetryCollectorFleetReconcilerParams struct {
+	client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Config   config.Config
+}
+
+func (r *OpenTelemetryCollectorFleetReconciler) getParams(instance v1alpha1.OpenTelemetryCollectorFleet) fleet.Params {
+	return fleet.Params{
+		Config:   r.config,
+		Client:   r.Client,
+		Fleet:    instance,
+		Log:      r.log,
+		Scheme:   r.scheme,
+		Recorder: r.recorder,
+	}
+}
+
+func NewOpenTelemetryCollectorFleetReconciler(params OpenTelemetryCollectorFleetReconcilerParams) *OpenTelemetryCollectorFleetReconciler {
+	reconciler := &OpenTelemetryCollectorFleetReconciler{
+		Client:   params.Client,
+		scheme:   params.Scheme,
+		log:      params.Log,
+		recorder: params.Recorder,
+		config:   params.Config,
+	}
+	return reconciler
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectorfleets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectorfleets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesetbindings,verbs=get;list;watch
+
+// Reconcile renders the collector of the fleet for the selected managed clusters, and aggregates its rollout status.
+func (r *OpenTelemetryCollectorFleetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollectorfleet", req.Name)
+	var instance v1alpha1.OpenTelemetryCollectorFleet
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpenTelemetryCollectorFleet")
+		}
+		// we'll ignore not-found errors, since they can't be fixed by an immediate
+		// requeue (we'll need to wait for a new notification), and we can get them
+		// on deleted requests.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// We have a deletion, short circuit and let the deletion happen
+	if deletionTimestamp := instance.GetDeletionTimestamp(); deletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	params := r.getParams(instance)
+	clusters, err := r.selectClusters(ctx, instance)
+	if err != nil {
+		return fleetStatus.HandleReconcileStatus(ctx, log, params, err)
+	}
+	params.Clusters = clusters
+
+	desiredObjects, buildErr := BuildCollectorFleet(params)
+	if buildErr != nil {
+		return fleetStatus.HandleReconcileStatus(ctx, log, params, buildErr)
+	}
+	ownedObjects, err := r.findFleetOwnedObjects(ctx, instance)
+	if err != nil {
+		return fleetStatus.HandleReconcileStatus(ctx, log, params, err)
+	}
+	err = reconcileDesiredObjects(ctx, r.Client, log, &params.Fleet, params.Scheme, desiredObjects, ownedObjects)
+	return fleetStatus.HandleReconcileStatus(ctx, log, params, err)
+}
+
+// selectClusters returns the managed clusters selected by the fleet.
+func (r *OpenTelemetryCollectorFleetReconciler) selectClusters(ctx context.Context, instance v1alpha1.OpenTelemetryCollectorFleet) ([]unstructured.Unstructured, error) {
+	selector := labels.Everything()
+	if instance.Spec.ClusterSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(instance.Spec.ClusterSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clusterSelector: %w", err)
+		}
+	}
+	clusters := &unstructured.UnstructuredList{}
+	clusters.SetGroupVersionKind(fleet.ClusterKind(instance))
+	if err := r.List(ctx, clusters, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list the managed clusters: %w", err)
+	}
+	return clusters.Items, nil
+}
+
+// findFleetOwnedObjects returns the objects generated for the fleet, for all the providers, so that the objects of
+// a previous provider are pruned as well.
+func (r *OpenTelemetryCollectorFleetReconciler) findFleetOwnedObjects(ctx context.Context, instance v1alpha1.OpenTelemetryCollectorFleet) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	listOpts := []client.ListOption{
+		client.MatchingLabels(map[string]string{
+			fleet.FleetLabel: instance.Name,
+		}),
+	}
+	kinds := []schema.GroupVersionKind{
+		corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		fleet.ManifestWorkGVK,
+		fleet.ClusterResourceSetGVK,
+	}
+	for _, kind := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(kind)
+		if err := r.List(ctx, list, listOpts...); err != nil {
+			// the APIs of the providers which aren't installed in the hub cluster can't hold any object
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error listing %s: %w", kind.Kind, err)
+		}
+		for i := range list.Items {
+			if metav1.IsControlledBy(&list.Items[i], &instance) {
+				ownedObjects[list.Items[i].GetUID()] = &list.Items[i]
+			}
+		}
+	}
+	return ownedObjects, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenTelemetryCollectorFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.OpenTelemetryCollectorFleet{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
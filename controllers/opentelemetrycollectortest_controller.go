@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collectortest"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	collectortestStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collectortest"
+)
+
+// OpenTelemetryCollectorTestReconciler reconciles a OpenTelemetryCollectorTest object.
+type OpenTelemetryCollectorTestReconciler struct {
+	client.Client
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	recorder record.EventRecorder
+	config   config.Config
+	scrape   collectortestStatus.Scraper
+}
+
+// OpenTelemetryCollectorTestReconcilerParams is the set of options to build a new OpenTelemetryCollectorTestReconciler.
+type OpenTelemetryCollectorTestReconcilerParams struct {
+	client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Config   config.Config
+	// Scraper reads the data received by the sink collectors, defaults to scraping their internal metrics.
+	Scraper collectortestStatus.Scraper
+}
+
+func (r *OpenTelemetryCollectorTestReconciler) getParams(instance v1alpha1.OpenTelemetryCollectorTest) collectortest.Params {
+	return collectortest.Params{
+		Config:   r.config,
+		Client:   r.Client,
+		Test:     instance,
+		Log:      r.log,
+		Scheme:   r.scheme,
+		Recorder: r.recorder,
+	}
+}
+
+func NewOpenTelemetryCollectorTestReconciler(params OpenTelemetryCollectorTestReconcilerParams) *OpenTelemetryCollectorTestReconciler {
+	scrape := params.Scraper
+	if scrape == nil {
+		scrape = collectortestStatus.ScrapeSink
+	}
+	reconciler := &OpenTelemetryCollectorTestReconciler{
+		Client:   params.Client,
+		scheme:   params.Scheme,
+		log:      params.Log,
+		recorder: params.Recorder,
+		config:   params.Config,
+		scrape:   scrape,
+	}
+	return reconciler
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectortests,verbs=get;list;watch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectortests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile runs the collectors of the test, sends the synthetic data once they are ready, and verifies the data
+// received by the sink.
+func (r *OpenTelemetryCollectorTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("opentelemetrycollectortest", req.NamespacedName)
+	var instance v1alpha1.OpenTelemetryCollectorTest
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch OpenTelemetryCollectorTest")
+		}
+		// we'll ignore not-found errors, since they can't be fixed by an immediate
+		// requeue (we'll need to wait for a new notification), and we can get them
+		// on deleted requests.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// We have a deletion, short circuit and let the deletion happen
+	if deletionTimestamp := instance.GetDeletionTimestamp(); deletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	params := r.getParams(instance)
+	ownedObjects, err := r.findTestOwnedObjects(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if collectortestStatus.IsCompleted(instance) {
+		if instance.Spec.KeepResources {
+			return ctrl.Result{}, nil
+		}
+		// the test ran, clean up its collectors and job
+		return ctrl.Result{}, r.cleanup(ctx, log, ownedObjects)
+	}
+
+	ready, err := r.collectorsReady(ctx, instance)
+	if err != nil {
+		return collectortestStatus.HandleReconcileStatus(ctx, log, params, r.scrape, err)
+	}
+	for _, obj := range ownedObjects {
+		// once the data is being sent, the job is kept even if a collector restarts
+		if _, ok := obj.(*batchv1.Job); ok {
+			ready = true
+		}
+	}
+	params.CollectorsReady = ready
+
+	desiredObjects, buildErr := BuildCollectorTest(params)
+	if buildErr != nil {
+		return collectortestStatus.HandleReconcileStatus(ctx, log, params, r.scrape, buildErr)
+	}
+	err = reconcileDesiredObjects(ctx, r.Client, log, &params.Test, params.Scheme, desiredObjects, ownedObjects)
+	return collectortestStatus.HandleReconcileStatus(ctx, log, params, r.scrape, err)
+}
+
+// collectorsReady returns whether the candidate and the sink collectors of the test have a ready pod.
+func (r *OpenTelemetryCollectorTestReconciler) collectorsReady(ctx context.Context, instance v1alpha1.OpenTelemetryCollectorTest) (bool, error) {
+	for _, name := range []string{naming.CollectorTestCandidate(instance.Name), naming.CollectorTestSink(instance.Name)} {
+		deployment := &appsv1.Deployment{}
+		key := client.ObjectKey{Namespace: instance.Namespace, Name: naming.Collector(name)}
+		if err := r.Get(ctx, key, deployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get the deployment of the collector %s: %w", name, err)
+		}
+		if deployment.Status.ReadyReplicas == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// cleanup deletes the collectors and the job of a completed test. The pods of the job are deleted in the background,
+// they would be orphaned otherwise.
+func (r *OpenTelemetryCollectorTestReconciler) cleanup(ctx context.Context, log logr.Logger, ownedObjects map[types.UID]client.Object) error {
+	var errs []error
+	for _, obj := range ownedObjects {
+		log.V(2).Info("deleting test resource", "object_name", obj.GetName())
+		if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to clean up the resources of the test: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// findTestOwnedObjects returns the collectors and the job of the test.
+func (r *OpenTelemetryCollectorTestReconciler) findTestOwnedObjects(ctx context.Context, instance v1alpha1.OpenTelemetryCollectorTest) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			collectortest.TestLabel: instance.Name,
+		}),
+	}
+	collectors := &v1beta1.OpenTelemetryCollectorList{}
+	if err := r.List(ctx, collectors, listOpts...); err != nil {
+		return nil, fmt.Errorf("error listing OpenTelemetryCollectors: %w", err)
+	}
+	for i := range collectors.Items {
+		if metav1.IsControlledBy(&collectors.Items[i], &instance) {
+			ownedObjects[collectors.Items[i].GetUID()] = &collectors.Items[i]
+		}
+	}
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, listOpts...); err != nil {
+		return nil, fmt.Errorf("error listing Jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		if metav1.IsControlledBy(&jobs.Items[i], &instance) {
+			ownedObjects[jobs.Items[i].GetUID()] = &jobs.Items[i]
+		}
+	}
+	return ownedObjects, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenTelemetryCollectorTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.OpenTelemetryCollectorTest{}).
+		Owns(&v1beta1.OpenTelemetryCollector{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
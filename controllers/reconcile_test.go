@@ -430,7 +430,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					result: controllerruntime.Result{},
 					checks: []check[v1alpha1.OpenTelemetryCollector]{
 						func(t *testing.T, params v1alpha1.OpenTelemetryCollector) {
-							configHash, _ := getConfigMapSHAFromString(params.Spec.Config)
+							configHash, _ := getConfigMapSHAFromString(params.Spec.Config, params.Spec.TargetAllocator.Enabled)
 							configHash = configHash[:8]
 							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.ConfigMap(params.Name, configHash), params.Namespace))
 							assert.NoError(t, err)
@@ -454,7 +454,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					result: controllerruntime.Result{},
 					checks: []check[v1alpha1.OpenTelemetryCollector]{
 						func(t *testing.T, params v1alpha1.OpenTelemetryCollector) {
-							configHash, _ := getConfigMapSHAFromString(params.Spec.Config)
+							configHash, _ := getConfigMapSHAFromString(params.Spec.Config, params.Spec.TargetAllocator.Enabled)
 							configHash = configHash[:8]
 							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.ConfigMap(params.Name, configHash), params.Namespace))
 							assert.NoError(t, err)
@@ -496,7 +496,7 @@ func TestOpenTelemetryCollectorReconciler_Reconcile(t *testing.T) {
 					result: controllerruntime.Result{},
 					checks: []check[v1alpha1.OpenTelemetryCollector]{
 						func(t *testing.T, params v1alpha1.OpenTelemetryCollector) {
-							configHash, _ := getConfigMapSHAFromString(params.Spec.Config)
+							configHash, _ := getConfigMapSHAFromString(params.Spec.Config, params.Spec.TargetAllocator.Enabled)
 							configHash = configHash[:8]
 							exists, err := populateObjectIfExists(t, &v1.ConfigMap{}, namespacedObjectName(naming.ConfigMap(params.Name, configHash), params.Namespace))
 							assert.NoError(t, err)
@@ -60,8 +60,8 @@ import (
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/testdata"
-	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 	// +kubebuilder:scaffold:imports
 )
@@ -483,11 +483,16 @@ func populateObjectIfExists(t testing.TB, object client.Object, namespacedName t
 	return true, nil
 }
 
-func getConfigMapSHAFromString(configStr string) (string, error) {
+func getConfigMapSHAFromString(configStr string, targetAllocatorEnabled bool) (string, error) {
 	var config v1beta1.Config
 	err := yaml.Unmarshal([]byte(configStr), &config)
 	if err != nil {
 		return "", err
 	}
-	return manifestutils.GetConfigMapSHA(config)
+	return collector.GetCollectorConfigSHA(config.New(), v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config:          config,
+			TargetAllocator: v1beta1.TargetAllocatorEmbedded{Enabled: targetAllocatorEnabled},
+		},
+	})
 }
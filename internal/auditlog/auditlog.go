@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditlog records the mutations of the collectors made by the operator, such as the upgrades, the rollbacks,
+// the defaulting and the configs applied from an OpAMP server, in an audit log ConfigMap next to each collector.
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+const (
+	// SourceUpgrade is the source of the mutations made by the automatic upgrades of the collectors.
+	SourceUpgrade = "upgrade"
+	// SourceRollback is the source of the mutations made by the rollbacks of the failed upgrades.
+	SourceRollback = "rollback"
+	// SourceDefaulting is the source of the mutations made by the defaulting admission webhook.
+	SourceDefaulting = "defaulting"
+	// SourceOpAMP is the source of the mutations applied by the OpAMP bridge from an OpAMP server.
+	SourceOpAMP = "opamp"
+
+	// ComponentAuditLog is the component label of the audit log ConfigMaps. It differs from the one of the collector
+	// ConfigMaps, which keeps the audit logs out of the previous config versions pruned by the reconciliation.
+	ComponentAuditLog = "opentelemetry-audit-log"
+	// DataKey is the key of the audit log ConfigMaps holding the entries, in YAML.
+	DataKey = "mutations.yaml"
+	// DefaultingAnnotation holds the mutations made by the defaulting admission webhook, as a JSON list of entries,
+	// until they're recorded in the audit log by the reconciliation: admission webhooks can't write to other objects.
+	DefaultingAnnotation = "operator.opentelemetry.io/pending-defaulting"
+
+	// maxEntries is the number of entries kept in an audit log, the oldest entries are dropped first.
+	maxEntries = 100
+	// maxPatchSize is the size of the largest patch kept in an entry, which keeps the audit logs under the size limit
+	// of the ConfigMaps.
+	maxPatchSize = 8 * 1024
+)
+
+// Entry is a mutation of a collector recorded in its audit log.
+type Entry struct {
+	// Time is when the mutation was made.
+	Time metav1.Time `json:"time"`
+	// Source is what made the mutation, one of the Source constants.
+	Source string `json:"source"`
+	// Message describes the mutation.
+	Message string `json:"message,omitempty"`
+	// Patch is the JSON merge patch from the collector before the mutation to the collector after it.
+	Patch string `json:"patch,omitempty"`
+}
+
+// Diff returns the JSON merge patch from before to after, or an empty string when they don't differ. Both are
+// marshaled to JSON, a snapshot already marshaled can be passed as a json.RawMessage. The status, the metadata
+// maintained by the API server and the DefaultingAnnotation aren't part of the patch.
+func Diff(before, after any) (string, error) {
+	original, err := stripped(before)
+	if err != nil {
+		return "", err
+	}
+	modified, err := stripped(after)
+	if err != nil {
+		return "", err
+	}
+	patch, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute the patch: %w", err)
+	}
+	if string(patch) == "{}" {
+		return "", nil
+	}
+	return string(patch), nil
+}
+
+// stripped marshals the object to JSON, without the fields left out of the patches.
+func stripped(obj any) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the object: %w", err)
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the object: %w", err)
+	}
+	delete(object, "status")
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"resourceVersion", "managedFields", "generation", "uid", "creationTimestamp"} {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, DefaultingAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	return json.Marshal(object)
+}
+
+// AddDefaulting appends the entry recording the given defaulting patch to the DefaultingAnnotation of the collector,
+// after the defaultings not recorded yet in the audit log.
+func AddDefaulting(otelcol client.Object, patch string) error {
+	entries, err := PendingDefaultings(otelcol)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, omitLargePatch(Entry{
+		Time:    metav1.NewTime(metav1.Now().Rfc3339Copy().Time),
+		Source:  SourceDefaulting,
+		Message: "defaulted by the admission webhook",
+		Patch:   patch,
+	}))
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the defaulting entries: %w", err)
+	}
+	annotations := otelcol.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DefaultingAnnotation] = string(data)
+	otelcol.SetAnnotations(annotations)
+	return nil
+}
+
+// PendingDefaultings returns the defaulting entries held by the DefaultingAnnotation of the collector, oldest first.
+func PendingDefaultings(otelcol client.Object) ([]Entry, error) {
+	value, ok := otelcol.GetAnnotations()[DefaultingAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var entries []Entry
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s annotation: %w", DefaultingAnnotation, err)
+	}
+	return entries, nil
+}
+
+// omitLargePatch returns the entry without its patch when it's larger than maxPatchSize.
+func omitLargePatch(entry Entry) Entry {
+	if len(entry.Patch) > maxPatchSize {
+		entry.Message = strings.TrimSpace(fmt.Sprintf("%s (patch of %d bytes omitted)", entry.Message, len(entry.Patch)))
+		entry.Patch = ""
+	}
+	return entry
+}
+
+// Record appends the entry to the audit log of the collector, creating the audit log ConfigMap when it doesn't exist
+// yet. The entries already in the audit log aren't recorded twice.
+func Record(ctx context.Context, c client.Client, otelcol client.Object, entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = metav1.Now()
+	}
+	entry = omitLargePatch(entry)
+	// the entries are stored with a precision of a second
+	entry.Time = metav1.NewTime(entry.Time.Rfc3339Copy().Time)
+
+	key := client.ObjectKey{Namespace: otelcol.GetNamespace(), Name: ConfigMapName(otelcol)}
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, key, cm)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		create := apierrors.IsNotFound(err)
+		if create {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels:    labels(otelcol, key.Name),
+				},
+			}
+			if err := controllerutil.SetOwnerReference(otelcol, cm, c.Scheme()); err != nil {
+				return fmt.Errorf("failed to set the owner of the audit log: %w", err)
+			}
+		}
+
+		entries, err := Entries(cm)
+		if err != nil {
+			return err
+		}
+		for _, existing := range entries {
+			if existing.Time.Equal(&entry.Time) && existing.Source == entry.Source && existing.Patch == entry.Patch {
+				return nil
+			}
+		}
+		entries = append(entries, entry)
+		if len(entries) > maxEntries {
+			entries = entries[len(entries)-maxEntries:]
+		}
+		data, err := yaml.Marshal(entries)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the audit log: %w", err)
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[DataKey] = string(data)
+
+		if create {
+			return c.Create(ctx, cm)
+		}
+		return c.Update(ctx, cm)
+	})
+}
+
+// RecordMutation records the mutation of the collector from before to after in its audit log, unless they don't
+// differ.
+func RecordMutation(ctx context.Context, c client.Client, before, after client.Object, source, message string) error {
+	patch, err := Diff(before, after)
+	if err != nil || patch == "" {
+		return err
+	}
+	return Record(ctx, c, after, Entry{Source: source, Message: message, Patch: patch})
+}
+
+// Get returns the entries of the audit log of the collector, oldest first, and nil when it has no audit log.
+func Get(ctx context.Context, c client.Reader, otelcol client.Object) ([]Entry, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: otelcol.GetNamespace(), Name: ConfigMapName(otelcol)}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return Entries(cm)
+}
+
+// Entries returns the entries of the audit log ConfigMap, oldest first.
+func Entries(cm *corev1.ConfigMap) ([]Entry, error) {
+	var entries []Entry
+	if err := yaml.Unmarshal([]byte(cm.Data[DataKey]), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse the audit log %s: %w", cm.Name, err)
+	}
+	return entries, nil
+}
+
+// ConfigMapName returns the name of the audit log ConfigMap of the collector, derived like the names of its other
+// resources from the name pinned by its resource name annotation when it is set.
+func ConfigMapName(otelcol client.Object) string {
+	if pinned := otelcol.GetAnnotations()[constants.AnnotationCollectorResourceName]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-audit", 63, pinned))
+	}
+	return naming.AuditLogConfigMap(otelcol.GetName())
+}
+
+// labels returns the labels of the audit log ConfigMap of the collector.
+func labels(otelcol client.Object, name string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, otelcol.GetNamespace(), otelcol.GetName()),
+		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/component":  ComponentAuditLog,
+		"app.kubernetes.io/name":       name,
+	}
+}
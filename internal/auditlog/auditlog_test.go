@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
+)
+
+func collector() *v1beta1.OpenTelemetryCollector {
+	return &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			UID:       "uid",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Image: "collector:0.1.0",
+			},
+		},
+	}
+}
+
+func newClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestDiff(t *testing.T) {
+	before := collector()
+	before.ResourceVersion = "1"
+	before.Status.Version = "0.1.0"
+
+	after := before.DeepCopy()
+	after.ResourceVersion = "2"
+	after.Status.Version = "0.2.0"
+	after.Spec.Image = "collector:0.2.0"
+	after.Annotations = map[string]string{auditlog.DefaultingAnnotation: "{}"}
+
+	patch, err := auditlog.Diff(before, after)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"image":"collector:0.2.0"}}`, patch)
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	before := collector()
+	after := before.DeepCopy()
+	after.ResourceVersion = "2"
+	after.Status.Version = "0.2.0"
+
+	patch, err := auditlog.Diff(before, after)
+	require.NoError(t, err)
+	assert.Empty(t, patch)
+}
+
+func TestDiffSnapshot(t *testing.T) {
+	otelcol := collector()
+	snapshot, err := json.Marshal(otelcol)
+	require.NoError(t, err)
+	otelcol.Spec.Mode = v1beta1.ModeDaemonSet
+
+	patch, err := auditlog.Diff(json.RawMessage(snapshot), otelcol)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"spec":{"mode":"daemonset"}}`, patch)
+}
+
+func TestRecord(t *testing.T) {
+	otelcol := collector()
+	cli := newClient(t, otelcol)
+	ctx := context.Background()
+
+	first := auditlog.Entry{
+		Time:    metav1.NewTime(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)),
+		Source:  auditlog.SourceUpgrade,
+		Message: "upgraded from 0.1.0 to 0.2.0",
+		Patch:   `{"spec":{"image":"collector:0.2.0"}}`,
+	}
+	second := auditlog.Entry{
+		Source: auditlog.SourceRollback,
+		Patch:  `{"spec":{"image":"collector:0.1.0"}}`,
+	}
+	require.NoError(t, auditlog.Record(ctx, cli, otelcol, first))
+	require.NoError(t, auditlog.Record(ctx, cli, otelcol, second))
+	// an entry already in the audit log isn't recorded twice
+	require.NoError(t, auditlog.Record(ctx, cli, otelcol, first))
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-collector-audit"}, cm))
+	assert.Equal(t, auditlog.ComponentAuditLog, cm.Labels["app.kubernetes.io/component"])
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "test", cm.OwnerReferences[0].Name)
+	assert.Nil(t, cm.OwnerReferences[0].Controller)
+
+	entries, err := auditlog.Get(ctx, cli, otelcol)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, first.Time.Equal(&entries[0].Time))
+	assert.Equal(t, first.Source, entries[0].Source)
+	assert.Equal(t, first.Message, entries[0].Message)
+	assert.Equal(t, first.Patch, entries[0].Patch)
+	assert.Equal(t, auditlog.SourceRollback, entries[1].Source)
+	assert.False(t, entries[1].Time.IsZero())
+}
+
+func TestRecordKeepsTheLatestEntries(t *testing.T) {
+	otelcol := collector()
+	cli := newClient(t, otelcol)
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 105; i++ {
+		require.NoError(t, auditlog.Record(ctx, cli, otelcol, auditlog.Entry{
+			Time:    metav1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			Source:  auditlog.SourceOpAMP,
+			Message: fmt.Sprintf("config %d", i),
+		}))
+	}
+
+	entries, err := auditlog.Get(ctx, cli, otelcol)
+	require.NoError(t, err)
+	require.Len(t, entries, 100)
+	assert.Equal(t, "config 5", entries[0].Message)
+	assert.Equal(t, "config 104", entries[99].Message)
+}
+
+func TestRecordOmitsLargePatches(t *testing.T) {
+	otelcol := collector()
+	cli := newClient(t, otelcol)
+	ctx := context.Background()
+
+	patch := fmt.Sprintf(`{"spec":{"image":"%s"}}`, strings.Repeat("a", 10000))
+	require.NoError(t, auditlog.Record(ctx, cli, otelcol, auditlog.Entry{
+		Source:  auditlog.SourceOpAMP,
+		Message: "applied the config",
+		Patch:   patch,
+	}))
+
+	entries, err := auditlog.Get(ctx, cli, otelcol)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Empty(t, entries[0].Patch)
+	assert.Equal(t, fmt.Sprintf("applied the config (patch of %d bytes omitted)", len(patch)), entries[0].Message)
+}
+
+func TestGetWithoutAuditLog(t *testing.T) {
+	otelcol := collector()
+	entries, err := auditlog.Get(context.Background(), newClient(t, otelcol), otelcol)
+	require.NoError(t, err)
+	assert.Nil(t, entries)
+}
+
+func TestConfigMapName(t *testing.T) {
+	otelcol := collector()
+	assert.Equal(t, "test-collector-audit", auditlog.ConfigMapName(otelcol))
+
+	// the audit log is named after the resource name pinned for the collector
+	otelcol.Annotations = map[string]string{v1beta1.ResourceNameAnnotation: "gateway"}
+	assert.Equal(t, "gateway-audit", auditlog.ConfigMapName(otelcol))
+
+	cli := newClient(t, otelcol)
+	require.NoError(t, auditlog.Record(context.Background(), cli, otelcol, auditlog.Entry{Source: auditlog.SourceUpgrade}))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "gateway-audit"}, cm))
+}
+
+func TestPendingDefaultings(t *testing.T) {
+	otelcol := collector()
+	entries, err := auditlog.PendingDefaultings(otelcol)
+	require.NoError(t, err)
+	assert.Nil(t, entries)
+
+	// the defaultings accumulate until they're recorded
+	require.NoError(t, auditlog.AddDefaulting(otelcol, `{"spec":{"replicas":1}}`))
+	require.NoError(t, auditlog.AddDefaulting(otelcol, `{"spec":{"mode":"deployment"}}`))
+
+	entries, err = auditlog.PendingDefaultings(otelcol)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, auditlog.SourceDefaulting, entries[0].Source)
+	assert.Equal(t, `{"spec":{"replicas":1}}`, entries[0].Patch)
+	assert.False(t, entries[0].Time.IsZero())
+	assert.Equal(t, `{"spec":{"mode":"deployment"}}`, entries[1].Patch)
+
+	otelcol.Annotations[auditlog.DefaultingAnnotation] = "not json"
+	_, err = auditlog.PendingDefaultings(otelcol)
+	assert.Error(t, err)
+	assert.Error(t, auditlog.AddDefaulting(otelcol, `{"spec":{"replicas":1}}`))
+}
+
+func TestRecordMutation(t *testing.T) {
+	before := collector()
+	cli := newClient(t, before)
+	ctx := context.Background()
+
+	// nothing is recorded when the collector doesn't change
+	require.NoError(t, auditlog.RecordMutation(ctx, cli, before, before.DeepCopy(), auditlog.SourceUpgrade, "unchanged"))
+	entries, err := auditlog.Get(ctx, cli, before)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	after := before.DeepCopy()
+	after.Spec.Image = "collector:0.2.0"
+	require.NoError(t, auditlog.RecordMutation(ctx, cli, before, after, auditlog.SourceUpgrade, "upgraded"))
+	entries, err = auditlog.Get(ctx, cli, before)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "upgraded", entries[0].Message)
+	assert.JSONEq(t, `{"spec":{"image":"collector:0.2.0"}}`, entries[0].Patch)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// CollectorNameTemplate renders the name the resources generated for a new collector are named after, in place of
+// <name>-collector, for organizations with naming conventions. The zero value is a template that is not set.
+type CollectorNameTemplate struct {
+	template *template.Template
+}
+
+// CollectorNameData is the data the collector name template is executed with.
+type CollectorNameData struct {
+	// Name is the name of the collector.
+	Name string
+	// Namespace is the namespace of the collector.
+	Namespace string
+	// Mode is the deployment mode of the collector.
+	Mode string
+}
+
+// NewCollectorNameTemplate parses the Go template of the names of the resources generated for the collectors. An
+// empty text returns a template that is not set.
+func NewCollectorNameTemplate(text string) (CollectorNameTemplate, error) {
+	if text == "" {
+		return CollectorNameTemplate{}, nil
+	}
+	tmpl, err := template.New("collector-name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return CollectorNameTemplate{}, fmt.Errorf("invalid collector name template %q: %w", text, err)
+	}
+	t := CollectorNameTemplate{template: tmpl}
+	// render a sample, to fail on the references to unknown fields and on the templates which don't render DNS labels,
+	// like the ones with dots, when starting rather than when creating collectors. The names rendered for the
+	// collectors are still validated, as they can be too long.
+	if _, err := t.Render(CollectorNameData{Name: "collector", Namespace: "default", Mode: "deployment"}); err != nil {
+		return CollectorNameTemplate{}, fmt.Errorf("invalid collector name template %q: %w", text, err)
+	}
+	return t, nil
+}
+
+// IsSet returns whether the template is set, as opposed to the resources being named after <name>-collector.
+func (t CollectorNameTemplate) IsSet() bool {
+	return t.template != nil
+}
+
+// Render returns the name rendered for the given collector, which has to be a valid DNS label.
+func (t CollectorNameTemplate) Render(data CollectorNameData) (string, error) {
+	if t.template == nil {
+		return "", fmt.Errorf("the collector name template is not set")
+	}
+	var out bytes.Buffer
+	if err := t.template.Execute(&out, data); err != nil {
+		return "", err
+	}
+	name := strings.TrimSpace(out.String())
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return "", fmt.Errorf("the name %q rendered for %s/%s is not a valid DNS label: %s", name, data.Namespace, data.Name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestCollectorNameTemplateNotSet(t *testing.T) {
+	tmpl, err := config.NewCollectorNameTemplate("")
+	require.NoError(t, err)
+
+	assert.False(t, tmpl.IsSet())
+	_, err = tmpl.Render(config.CollectorNameData{Name: "simplest"})
+	assert.Error(t, err)
+}
+
+func TestCollectorNameTemplateRender(t *testing.T) {
+	tmpl, err := config.NewCollectorNameTemplate(`otel-{{ .Namespace }}-{{ .Name }}{{ if eq .Mode "daemonset" }}-agent{{ end }}`)
+	require.NoError(t, err)
+	require.True(t, tmpl.IsSet())
+
+	for _, tt := range []struct {
+		data     config.CollectorNameData
+		expected string
+	}{
+		{data: config.CollectorNameData{Name: "simplest", Namespace: "observability", Mode: "deployment"}, expected: "otel-observability-simplest"},
+		{data: config.CollectorNameData{Name: "simplest", Namespace: "observability", Mode: "daemonset"}, expected: "otel-observability-simplest-agent"},
+	} {
+		t.Run(tt.expected, func(t *testing.T) {
+			name, err := tmpl.Render(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, name)
+		})
+	}
+}
+
+func TestCollectorNameTemplateInvalidName(t *testing.T) {
+	// the template renders valid names for most collectors, but not for the ones with long names
+	tmpl, err := config.NewCollectorNameTemplate(`otel-{{ .Namespace }}-{{ .Name }}`)
+	require.NoError(t, err)
+
+	_, err = tmpl.Render(config.CollectorNameData{Name: strings.Repeat("a", 60), Namespace: "observability"})
+	assert.ErrorContains(t, err, "is not a valid DNS label")
+}
+
+func TestCollectorNameTemplateInvalid(t *testing.T) {
+	for _, text := range []string{
+		"{{ .Name ",
+		"{{ .Cluster }}-{{ .Name }}",
+		"Collector_{{ .Name }}",
+		"{{ .Name }}.{{ .Namespace }}",
+	} {
+		t.Run(text, func(t *testing.T) {
+			_, err := config.NewCollectorNameTemplate(text)
+			assert.ErrorContains(t, err, "invalid collector name template")
+		})
+	}
+}
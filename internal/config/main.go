@@ -22,6 +22,7 @@ import (
 	"github.com/go-logr/logr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
@@ -42,11 +43,15 @@ type Config struct {
 	logger                              logr.Logger
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
+	telemetrygenImage                   string
+	exporterCheckImage                  string
 	autoInstrumentationPythonImage      string
 	collectorImage                      string
+	collectorConfigProviders            []string
 	collectorConfigMapEntry             string
 	createRBACPermissions               autoRBAC.Availability
 	enableMultiInstrumentation          bool
+	enablePipelineDefaults              bool
 	enableApacheHttpdInstrumentation    bool
 	enableDotNetInstrumentation         bool
 	enableGoInstrumentation             bool
@@ -67,6 +72,10 @@ type Config struct {
 	prometheusCRAvailability    prometheus.Availability
 	labelsFilter                []string
 	annotationsFilter           []string
+	upgradeWindow               UpgradeWindow
+	upgradeRollbackTimeout      time.Duration
+	reconcileFairness           ReconcileFairness
+	collectorNameTemplate       CollectorNameTemplate
 }
 
 // New constructs a new configuration based on the given options.
@@ -82,7 +91,7 @@ func New(opts ...Option) Config {
 		logger:                            logf.Log.WithName("config"),
 		version:                           version.Get(),
 		enableJavaInstrumentation:         true,
-		annotationsFilter:                 []string{"kubectl.kubernetes.io/last-applied-configuration"},
+		annotationsFilter:                 defaultAnnotationsFilter(),
 	}
 
 	for _, opt := range opts {
@@ -92,8 +101,10 @@ func New(opts ...Option) Config {
 	return Config{
 		autoDetect:                          o.autoDetect,
 		collectorImage:                      o.collectorImage,
+		collectorConfigProviders:            o.collectorConfigProviders,
 		collectorConfigMapEntry:             o.collectorConfigMapEntry,
 		enableMultiInstrumentation:          o.enableMultiInstrumentation,
+		enablePipelineDefaults:              o.enablePipelineDefaults,
 		enableApacheHttpdInstrumentation:    o.enableApacheHttpdInstrumentation,
 		enableDotNetInstrumentation:         o.enableDotNetInstrumentation,
 		enableGoInstrumentation:             o.enableGoInstrumentation,
@@ -103,6 +114,8 @@ func New(opts ...Option) Config {
 		enableJavaInstrumentation:           o.enableJavaInstrumentation,
 		targetAllocatorImage:                o.targetAllocatorImage,
 		operatorOpAMPBridgeImage:            o.operatorOpAMPBridgeImage,
+		telemetrygenImage:                   o.telemetrygenImage,
+		exporterCheckImage:                  o.exporterCheckImage,
 		targetAllocatorConfigMapEntry:       o.targetAllocatorConfigMapEntry,
 		operatorOpAMPBridgeConfigMapEntry:   o.operatorOpAMPBridgeConfigMapEntry,
 		logger:                              o.logger,
@@ -118,6 +131,24 @@ func New(opts ...Option) Config {
 		labelsFilter:                        o.labelsFilter,
 		annotationsFilter:                   o.annotationsFilter,
 		createRBACPermissions:               o.createRBACPermissions,
+		upgradeWindow:                       o.upgradeWindow,
+		upgradeRollbackTimeout:              o.upgradeRollbackTimeout,
+		reconcileFairness:                   o.reconcileFairness,
+		collectorNameTemplate:               o.collectorNameTemplate,
+	}
+}
+
+// defaultAnnotationsFilter returns the annotations never propagated from the instances to the objects they own. Next
+// to the last applied configuration, the rollback state recorded on the instances during the upgrades changes twice
+// per upgrade, which would roll the pods. The defaultings pending in the audit log change with the defaults of the
+// operator, and the config source of the v1alpha1 collectors is already rendered in their config.
+func defaultAnnotationsFilter() []string {
+	return []string{
+		"kubectl.kubernetes.io/last-applied-configuration",
+		"operator.opentelemetry.io/upgrade-started",
+		"operator.opentelemetry.io/upgrade-rollback-version",
+		auditlog.DefaultingAnnotation,
+		"operator.opentelemetry.io/config-source",
 	}
 }
 
@@ -154,11 +185,22 @@ func (c *Config) CollectorImage() string {
 	return c.collectorImage
 }
 
+// CollectorConfigProviders represents the confmap providers available in the default OpenTelemetry Collector image.
+func (c *Config) CollectorConfigProviders() []string {
+	return c.collectorConfigProviders
+}
+
 // EnableMultiInstrumentation is true when the operator supports multi instrumentation.
 func (c *Config) EnableMultiInstrumentation() bool {
 	return c.enableMultiInstrumentation
 }
 
+// EnablePipelineDefaults is true when the operator adds the batch processor and the exporter queue and retry settings
+// to the collector pipelines lacking them.
+func (c *Config) EnablePipelineDefaults() bool {
+	return c.enablePipelineDefaults
+}
+
 // EnableApacheHttpdAutoInstrumentation is true when the operator supports ApacheHttpd auto instrumentation.
 func (c *Config) EnableApacheHttpdAutoInstrumentation() bool {
 	return c.enableApacheHttpdInstrumentation
@@ -214,6 +256,16 @@ func (c *Config) OperatorOpAMPBridgeImage() string {
 	return c.operatorOpAMPBridgeImage
 }
 
+// TelemetrygenImage represents the image sending synthetic data in the collector tests.
+func (c *Config) TelemetrygenImage() string {
+	return c.telemetrygenImage
+}
+
+// ExporterCheckImage represents the image checking the reachability of the exporter endpoints of the collectors.
+func (c *Config) ExporterCheckImage() string {
+	return c.exporterCheckImage
+}
+
 // TargetAllocatorConfigMapEntry represents the configuration file name for the TargetAllocator. Immutable.
 func (c *Config) TargetAllocatorConfigMapEntry() string {
 	return c.targetAllocatorConfigMapEntry
@@ -278,3 +330,24 @@ func (c *Config) LabelsFilter() []string {
 func (c *Config) AnnotationsFilter() []string {
 	return c.annotationsFilter
 }
+
+// UpgradeWindow represents the maintenance window during which the managed instances are upgraded.
+func (c *Config) UpgradeWindow() UpgradeWindow {
+	return c.upgradeWindow
+}
+
+// UpgradeRollbackTimeout is the time the managed instances have to become ready after an upgrade before it is
+// rolled back. A zero timeout disables the rollbacks.
+func (c *Config) UpgradeRollbackTimeout() time.Duration {
+	return c.upgradeRollbackTimeout
+}
+
+// ReconcileFairness represents the per-namespace rate limits and the priority namespaces of the reconciliations.
+func (c *Config) ReconcileFairness() ReconcileFairness {
+	return c.reconcileFairness
+}
+
+// CollectorNameTemplate represents the template of the names of the resources generated for the new collectors.
+func (c *Config) CollectorNameTemplate() CollectorNameTemplate {
+	return c.collectorNameTemplate
+}
@@ -15,6 +15,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/go-logr/logr"
 	"go.uber.org/zap/zapcore"
 
@@ -40,9 +42,11 @@ type options struct {
 	autoInstrumentationApacheHttpdImage string
 	autoInstrumentationNginxImage       string
 	collectorImage                      string
+	collectorConfigProviders            []string
 	collectorConfigMapEntry             string
 	createRBACPermissions               autoRBAC.Availability
 	enableMultiInstrumentation          bool
+	enablePipelineDefaults              bool
 	enableApacheHttpdInstrumentation    bool
 	enableDotNetInstrumentation         bool
 	enableGoInstrumentation             bool
@@ -54,10 +58,16 @@ type options struct {
 	operatorOpAMPBridgeConfigMapEntry   string
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
+	telemetrygenImage                   string
+	exporterCheckImage                  string
 	openshiftRoutesAvailability         openshift.RoutesAvailability
 	prometheusCRAvailability            prometheus.Availability
 	labelsFilter                        []string
 	annotationsFilter                   []string
+	upgradeWindow                       UpgradeWindow
+	upgradeRollbackTimeout              time.Duration
+	reconcileFairness                   ReconcileFairness
+	collectorNameTemplate               CollectorNameTemplate
 }
 
 func WithAutoDetect(a autodetect.AutoDetect) Option {
@@ -75,11 +85,26 @@ func WithOperatorOpAMPBridgeImage(s string) Option {
 		o.operatorOpAMPBridgeImage = s
 	}
 }
+func WithTelemetrygenImage(s string) Option {
+	return func(o *options) {
+		o.telemetrygenImage = s
+	}
+}
+func WithExporterCheckImage(s string) Option {
+	return func(o *options) {
+		o.exporterCheckImage = s
+	}
+}
 func WithCollectorImage(s string) Option {
 	return func(o *options) {
 		o.collectorImage = s
 	}
 }
+func WithCollectorConfigProviders(providers []string) Option {
+	return func(o *options) {
+		o.collectorConfigProviders = providers
+	}
+}
 func WithCollectorConfigMapEntry(s string) Option {
 	return func(o *options) {
 		o.collectorConfigMapEntry = s
@@ -90,6 +115,11 @@ func WithEnableMultiInstrumentation(s bool) Option {
 		o.enableMultiInstrumentation = s
 	}
 }
+func WithEnablePipelineDefaults(s bool) Option {
+	return func(o *options) {
+		o.enablePipelineDefaults = s
+	}
+}
 func WithEnableApacheHttpdInstrumentation(s bool) Option {
 	return func(o *options) {
 		o.enableApacheHttpdInstrumentation = s
@@ -214,13 +244,47 @@ func WithLabelFilters(labelFilters []string) Option {
 
 // WithAnnotationFilters is additive if called multiple times. It works off of a few default filters
 // to prevent unnecessary rollouts. The defaults include the following:
-// * kubectl.kubernetes.io/last-applied-configuration.
+// * kubectl.kubernetes.io/last-applied-configuration
+// * the rollback state recorded by the upgrades: operator.opentelemetry.io/upgrade-started and
+// operator.opentelemetry.io/upgrade-rollback-version
+// * the defaultings pending in the audit log: operator.opentelemetry.io/pending-defaulting
+// * the config source of the v1alpha1 collectors: operator.opentelemetry.io/config-source.
 func WithAnnotationFilters(annotationFilters []string) Option {
 	return func(o *options) {
 		o.annotationsFilter = append(o.annotationsFilter, annotationFilters...)
 	}
 }
 
+// WithUpgradeWindow restricts the upgrades of the managed instances to the given maintenance window.
+func WithUpgradeWindow(w UpgradeWindow) Option {
+	return func(o *options) {
+		o.upgradeWindow = w
+	}
+}
+
+// WithUpgradeRollbackTimeout rolls back the upgrades of the managed instances which are not ready after the
+// given timeout.
+func WithUpgradeRollbackTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.upgradeRollbackTimeout = d
+	}
+}
+
+// WithReconcileFairness rate limits the reconciliations per namespace, and reconciles the priority namespaces first.
+func WithReconcileFairness(f ReconcileFairness) Option {
+	return func(o *options) {
+		o.reconcileFairness = f
+	}
+}
+
+// WithCollectorNameTemplate names the resources generated for the new collectors after the name rendered by the
+// template.
+func WithCollectorNameTemplate(t CollectorNameTemplate) Option {
+	return func(o *options) {
+		o.collectorNameTemplate = t
+	}
+}
+
 func WithEncodeLevelFormat(s string) zapcore.LevelEncoder {
 	if s == "lowercase" {
 		return zapcore.LowercaseLevelEncoder
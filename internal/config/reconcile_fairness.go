@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strings"
+)
+
+// ReconcileFairness limits the rate at which the changes of the instances of a namespace are reconciled, so that the
+// instances of a namespace changed repeatedly don't starve the reconciliations of the other namespaces, and
+// reconciles the instances of the priority namespaces first. The zero value doesn't limit the reconciliations.
+type ReconcileFairness struct {
+	// NamespaceQPS is the rate of the reconciliations triggered by changes allowed per namespace, unlimited when
+	// zero.
+	NamespaceQPS float64
+	// NamespaceBurst is the number of reconciliations allowed per namespace above the rate.
+	NamespaceBurst int
+	// PriorityNamespaces are the namespaces whose instances are reconciled first, and which aren't rate limited.
+	// A name ending with * matches the namespaces starting with the rest of the name.
+	PriorityNamespaces []string
+}
+
+// IsRateLimited returns whether the reconciliations of the namespaces are rate limited.
+func (f ReconcileFairness) IsRateLimited() bool {
+	return f.NamespaceQPS > 0
+}
+
+// IsPriorityNamespace returns whether the instances of the namespace are reconciled first.
+func (f ReconcileFairness) IsPriorityNamespace(namespace string) bool {
+	for _, name := range f.PriorityNamespaces {
+		if prefix, found := strings.CutSuffix(name, "*"); found && strings.HasPrefix(namespace, prefix) {
+			return true
+		}
+		if name == namespace {
+			return true
+		}
+	}
+	return false
+}
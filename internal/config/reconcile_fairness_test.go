@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestReconcileFairnessIsPriorityNamespace(t *testing.T) {
+	f := config.ReconcileFairness{PriorityNamespaces: []string{"kube-system", "openshift-*"}}
+	for namespace, expected := range map[string]bool{
+		"kube-system":         true,
+		"openshift-logging":   true,
+		"openshift":           false,
+		"kube-system-tenant":  false,
+		"tenant-kube-system":  false,
+		"tenant-openshift-ns": false,
+	} {
+		assert.Equal(t, expected, f.IsPriorityNamespace(namespace), namespace)
+	}
+	assert.False(t, config.ReconcileFairness{}.IsPriorityNamespace("kube-system"))
+}
+
+func TestReconcileFairnessIsRateLimited(t *testing.T) {
+	assert.False(t, config.ReconcileFairness{}.IsRateLimited())
+	assert.True(t, config.ReconcileFairness{NamespaceQPS: 0.5}.IsRateLimited())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+)
+
+// UpgradeWindow is the maintenance window during which the operator applies version bumps and config
+// migrations to the managed instances. The zero value is a window that is always open.
+type UpgradeWindow struct {
+	schedule *cronexpr.Expression
+	duration time.Duration
+}
+
+// NewUpgradeWindow returns a window opening on every occurrence of the cron schedule and closing after the given
+// duration. An empty schedule returns a window that is always open.
+func NewUpgradeWindow(schedule string, duration time.Duration) (UpgradeWindow, error) {
+	if schedule == "" {
+		return UpgradeWindow{}, nil
+	}
+	expr, err := cronexpr.Parse(schedule)
+	if err != nil {
+		return UpgradeWindow{}, fmt.Errorf("invalid upgrade window schedule %q: %w", schedule, err)
+	}
+	if duration <= 0 {
+		return UpgradeWindow{}, fmt.Errorf("invalid upgrade window duration %s: must be positive", duration)
+	}
+	return UpgradeWindow{schedule: expr, duration: duration}, nil
+}
+
+// IsScheduled returns whether the window has a schedule, as opposed to being always open.
+func (w UpgradeWindow) IsScheduled() bool {
+	return w.schedule != nil
+}
+
+// IsOpen returns whether upgrades can be applied at the given time.
+func (w UpgradeWindow) IsOpen(t time.Time) bool {
+	if w.schedule == nil {
+		return true
+	}
+	// the window is open if it started within the last duration
+	start := w.schedule.Next(t.Add(-w.duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// NextOpening returns the next time the window opens after the given time, or the zero time if the window is
+// always open or never opens again.
+func (w UpgradeWindow) NextOpening(t time.Time) time.Time {
+	if w.schedule == nil {
+		return time.Time{}
+	}
+	return w.schedule.Next(t)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestUpgradeWindowAlwaysOpen(t *testing.T) {
+	w, err := config.NewUpgradeWindow("", 0)
+	require.NoError(t, err)
+
+	assert.False(t, w.IsScheduled())
+	assert.True(t, w.IsOpen(time.Now()))
+	assert.True(t, w.NextOpening(time.Now()).IsZero())
+	assert.True(t, config.UpgradeWindow{}.IsOpen(time.Now()))
+}
+
+func TestUpgradeWindowIsOpen(t *testing.T) {
+	// every day between 02:00 and 04:00
+	w, err := config.NewUpgradeWindow("0 2 * * *", 2*time.Hour)
+	require.NoError(t, err)
+	require.True(t, w.IsScheduled())
+
+	for _, tt := range []struct {
+		at   time.Time
+		open bool
+	}{
+		{at: time.Date(2024, 6, 10, 1, 59, 59, 0, time.UTC), open: false},
+		{at: time.Date(2024, 6, 10, 2, 0, 0, 0, time.UTC), open: true},
+		{at: time.Date(2024, 6, 10, 3, 30, 0, 0, time.UTC), open: true},
+		{at: time.Date(2024, 6, 10, 4, 0, 1, 0, time.UTC), open: false},
+		{at: time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC), open: false},
+	} {
+		t.Run(tt.at.String(), func(t *testing.T) {
+			assert.Equal(t, tt.open, w.IsOpen(tt.at))
+		})
+	}
+}
+
+func TestUpgradeWindowNextOpening(t *testing.T) {
+	w, err := config.NewUpgradeWindow("0 2 * * *", time.Hour)
+	require.NoError(t, err)
+
+	next := w.NextOpening(time.Date(2024, 6, 10, 2, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 6, 11, 2, 0, 0, 0, time.UTC), next)
+}
+
+func TestUpgradeWindowInvalid(t *testing.T) {
+	_, err := config.NewUpgradeWindow("not a cron", time.Hour)
+	assert.ErrorContains(t, err, "invalid upgrade window schedule")
+
+	_, err = config.NewUpgradeWindow("0 2 * * *", 0)
+	assert.ErrorContains(t, err, "invalid upgrade window duration")
+}
+
+func TestNewConfigWithUpgradeWindow(t *testing.T) {
+	w, err := config.NewUpgradeWindow("0 2 * * *", time.Hour)
+	require.NoError(t, err)
+
+	cfg := config.New(config.WithUpgradeWindow(w))
+	assert.Equal(t, w, cfg.UpgradeWindow())
+}
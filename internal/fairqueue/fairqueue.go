@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fairqueue contains the work queue of the controllers rate limiting the reconciliations per namespace, and
+// reconciling the instances of the priority namespaces first.
+package fairqueue
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// New returns the constructor of the work queue of a controller, to be set as its NewQueue option.
+func New(fairness config.ReconcileFairness) func(controllerName string, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+	return func(controllerName string, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+		queue := newPriorityQueue(func(item interface{}) bool {
+			namespace, ok := itemNamespace(item)
+			return ok && fairness.IsPriorityNamespace(namespace)
+		})
+		delaying := workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+			Name:  controllerName,
+			Queue: queue,
+		})
+		return &fairQueue{
+			RateLimitingInterface: workqueue.NewRateLimitingQueueWithConfig(rateLimiter, workqueue.RateLimitingQueueConfig{
+				DelayingQueue: delaying,
+			}),
+			fairness: fairness,
+			now:      time.Now,
+			limiters: map[string]*rate.Limiter{},
+			waiting:  map[interface{}]time.Time{},
+		}
+	}
+}
+
+// fairQueue delays the items added by the watches of the controller once their namespace exceeded its rate.
+// The items added back by the controller after a reconciliation are rate limited by the rate limiter of the
+// controller only.
+type fairQueue struct {
+	workqueue.RateLimitingInterface
+
+	fairness config.ReconcileFairness
+	now      func() time.Time
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	// waiting holds the items delayed until their namespace is within its rate, by the time they are added at
+	waiting map[interface{}]time.Time
+}
+
+// Add adds the item to the queue, after the delay its namespace is rate limited for.
+func (q *fairQueue) Add(item interface{}) {
+	delay := q.delay(item)
+	if delay > 0 {
+		q.RateLimitingInterface.AddAfter(item, delay)
+		return
+	}
+	q.RateLimitingInterface.Add(item)
+}
+
+// delay reserves a reconciliation of the item in the rate of its namespace, and returns the time until it's due.
+func (q *fairQueue) delay(item interface{}) time.Duration {
+	namespace, ok := itemNamespace(item)
+	if !ok || !q.fairness.IsRateLimited() || q.fairness.IsPriorityNamespace(namespace) {
+		return 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.now()
+	if due, found := q.waiting[item]; found && due.After(now) {
+		// the item already waits for a reconciliation, which will see the latest state of the instance
+		return due.Sub(now)
+	}
+	for waitingItem, due := range q.waiting {
+		if !due.After(now) {
+			delete(q.waiting, waitingItem)
+		}
+	}
+	limiter, found := q.limiters[namespace]
+	if !found {
+		limiter = rate.NewLimiter(rate.Limit(q.fairness.NamespaceQPS), max(1, q.fairness.NamespaceBurst))
+		q.limiters[namespace] = limiter
+	}
+	delay := limiter.ReserveN(now, 1).DelayFrom(now)
+	if delay > 0 {
+		q.waiting[item] = now.Add(delay)
+	}
+	return delay
+}
+
+// itemNamespace returns the namespace of the instance to reconcile.
+func itemNamespace(item interface{}) (string, bool) {
+	req, ok := item.(reconcile.Request)
+	if !ok {
+		return "", false
+	}
+	return req.Namespace, true
+}
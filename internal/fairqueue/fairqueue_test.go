@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fairqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func request(namespace, name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+}
+
+func TestPriorityNamespacesFirst(t *testing.T) {
+	newQueue := New(config.ReconcileFairness{PriorityNamespaces: []string{"kube-system"}})
+	q := newQueue("test", workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	q.Add(request("tenant", "a"))
+	q.Add(request("tenant", "b"))
+	q.Add(request("kube-system", "critical"))
+	q.Add(request("tenant", "a"))
+	require.Equal(t, 3, q.Len())
+
+	for _, expected := range []reconcile.Request{request("kube-system", "critical"), request("tenant", "a"), request("tenant", "b")} {
+		item, shutdown := q.Get()
+		require.False(t, shutdown)
+		assert.Equal(t, expected, item)
+		q.Done(item)
+	}
+}
+
+func TestItemAddedWhileProcessed(t *testing.T) {
+	q := newPriorityQueue(func(interface{}) bool { return false })
+
+	q.Add("a")
+	item, _ := q.Get()
+	q.Add("a")
+	// the item is only handed out again once it's done
+	assert.Equal(t, 0, q.Len())
+	q.Done(item)
+	assert.Equal(t, 1, q.Len())
+
+	q.ShutDown()
+	item, shutdown := q.Get()
+	assert.False(t, shutdown)
+	assert.Equal(t, "a", item)
+	_, shutdown = q.Get()
+	assert.True(t, shutdown)
+}
+
+func TestNamespaceRateLimit(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	q := New(config.ReconcileFairness{
+		NamespaceQPS:       1,
+		NamespaceBurst:     2,
+		PriorityNamespaces: []string{"kube-system"},
+	})("test", workqueue.DefaultControllerRateLimiter()).(*fairQueue)
+	defer q.ShutDown()
+	q.now = func() time.Time { return now }
+
+	// the burst of the namespace is reconciled right away
+	assert.Equal(t, time.Duration(0), q.delay(request("tenant", "a")))
+	assert.Equal(t, time.Duration(0), q.delay(request("tenant", "b")))
+	// the next changes are delayed
+	assert.Equal(t, time.Second, q.delay(request("tenant", "c")))
+	assert.Equal(t, 2*time.Second, q.delay(request("tenant", "d")))
+	// an instance already waiting doesn't consume the rate again
+	assert.Equal(t, time.Second, q.delay(request("tenant", "c")))
+	assert.Equal(t, 3*time.Second, q.delay(request("tenant", "e")))
+
+	// the other namespaces have their own rate, and the priority namespaces aren't rate limited
+	assert.Equal(t, time.Duration(0), q.delay(request("other", "a")))
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, time.Duration(0), q.delay(request("kube-system", "critical")))
+	}
+
+	// the rate of the namespace is replenished over time
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, time.Duration(0), q.delay(request("tenant", "c")))
+	assert.Empty(t, q.waiting)
+}
+
+func TestNamespaceRateLimitDisabled(t *testing.T) {
+	q := New(config.ReconcileFairness{})("test", workqueue.DefaultControllerRateLimiter()).(*fairQueue)
+	defer q.ShutDown()
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, time.Duration(0), q.delay(request("tenant", "a")))
+	}
+}
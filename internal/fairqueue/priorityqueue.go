@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fairqueue
+
+import (
+	"sync"
+)
+
+// priorityQueue is a work queue handing out the priority items before the other ones. Like the queue of client-go,
+// an item is only queued once, and an item added while it's processed is queued again once it's done.
+type priorityQueue struct {
+	cond       *sync.Cond
+	isPriority func(item interface{}) bool
+
+	priority []interface{}
+	other    []interface{}
+	// dirty holds the items to process, and processing the items being processed.
+	dirty      map[interface{}]struct{}
+	processing map[interface{}]struct{}
+
+	shuttingDown bool
+	drain        bool
+}
+
+func newPriorityQueue(isPriority func(item interface{}) bool) *priorityQueue {
+	return &priorityQueue{
+		cond:       sync.NewCond(&sync.Mutex{}),
+		isPriority: isPriority,
+		dirty:      map[interface{}]struct{}{},
+		processing: map[interface{}]struct{}{},
+	}
+}
+
+// Add marks the item as needing processing.
+func (q *priorityQueue) Add(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	if _, found := q.dirty[item]; found {
+		return
+	}
+	q.dirty[item] = struct{}{}
+	if _, found := q.processing[item]; found {
+		return
+	}
+	q.push(item)
+	q.cond.Signal()
+}
+
+func (q *priorityQueue) push(item interface{}) {
+	if q.isPriority(item) {
+		q.priority = append(q.priority, item)
+	} else {
+		q.other = append(q.other, item)
+	}
+}
+
+// Len returns the number of items waiting to be processed.
+func (q *priorityQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.priority) + len(q.other)
+}
+
+// Get blocks until it can return an item to be processed, the oldest priority item if any. If shutdown is true, the
+// caller should end their goroutine. Done must be called with the item when it has been processed.
+func (q *priorityQueue) Get() (item interface{}, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.priority) == 0 && len(q.other) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	switch {
+	case len(q.priority) > 0:
+		item, q.priority[0] = q.priority[0], nil
+		q.priority = q.priority[1:]
+	case len(q.other) > 0:
+		item, q.other[0] = q.other[0], nil
+		q.other = q.other[1:]
+	default:
+		// the queue is shutting down
+		return nil, true
+	}
+	q.processing[item] = struct{}{}
+	delete(q.dirty, item)
+	return item, false
+}
+
+// Done marks the item as done processing, and queues it again if it was added while being processed.
+func (q *priorityQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	delete(q.processing, item)
+	if _, found := q.dirty[item]; found {
+		q.push(item)
+		q.cond.Signal()
+	} else if len(q.processing) == 0 {
+		q.cond.Broadcast()
+	}
+}
+
+// ShutDown makes the queue ignore the items added, and the workers return once the queued items are processed.
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = false
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+// ShutDownWithDrain shuts the queue down like ShutDown, and waits for the items being processed to be done.
+func (q *priorityQueue) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = true
+	q.shuttingDown = true
+	q.cond.Broadcast()
+	for len(q.processing) > 0 && q.drain {
+		q.cond.Wait()
+	}
+}
+
+// ShuttingDown returns whether the queue is shutting down.
+func (q *priorityQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
@@ -37,3 +37,27 @@ func TestEmptyString(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, res, 0)
 }
+
+func TestAnchorsAndMergeKeys(t *testing.T) {
+	// test
+	config, err := adapters.ConfigFromString(`x-tls: &tls
+  ca_file: /etc/certs/ca.crt
+exporters:
+  otlp/a: &otlp
+    endpoint: a:4317
+    tls:
+      <<: *tls
+      insecure: false
+  otlp/b:
+    <<: *otlp
+    endpoint: b:4317
+`)
+
+	// verify
+	assert.NoError(t, err)
+	tls := map[interface{}]interface{}{"ca_file": "/etc/certs/ca.crt", "insecure": false}
+	assert.Equal(t, map[interface{}]interface{}{
+		"otlp/a": map[interface{}]interface{}{"endpoint": "a:4317", "tls": tls},
+		"otlp/b": map[interface{}]interface{}{"endpoint": "b:4317", "tls": tls},
+	}, config["exporters"])
+}
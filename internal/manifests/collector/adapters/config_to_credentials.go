@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapters
+
+import (
+	"sort"
+
+	"github.com/go-logr/logr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser/receiver"
+)
+
+// ConfigToCredentials parses the OpenTelemetry Collector configuration and returns the credentials of the enabled
+// receivers authenticating to the systems they scrape.
+func ConfigToCredentials(logger logr.Logger, config map[interface{}]interface{}) []receiver.Credential {
+	var credentials []receiver.Credential
+	receiversRaw, ok := config["receivers"]
+	if !ok {
+		logger.V(2).Info("no receivers available as part of the configuration")
+		return credentials
+	}
+
+	receivers, ok := receiversRaw.(map[interface{}]interface{})
+	if !ok {
+		logger.V(2).Info("receivers doesn't contain valid components")
+		return credentials
+	}
+
+	enabledReceivers := getEnabledComponents(config, ComponentTypeReceiver)
+
+	for key, val := range receivers {
+		if !enabledReceivers[key] {
+			continue
+		}
+
+		receiverCfg, ok := val.(map[interface{}]interface{})
+		if !ok {
+			logger.V(2).Info("receiver doesn't seem to be a map of properties", "receiver", key)
+			continue
+		}
+
+		receiverName := key.(string)
+		receiverParser, err := receiver.For(logger, receiverName, receiverCfg)
+		if err != nil {
+			logger.V(2).Info("no parser found for", "receiver", receiverName)
+			continue
+		}
+		if credentialsParser, ok := receiverParser.(receiver.CredentialsParser); ok {
+			credentials = append(credentials, credentialsParser.Credentials()...)
+		}
+	}
+
+	sort.SliceStable(credentials, func(i, j int) bool {
+		if credentials[i].Receiver != credentials[j].Receiver {
+			return credentials[i].Receiver < credentials[j].Receiver
+		}
+		return credentials[i].Field < credentials[j].Field
+	})
+	return credentials
+}
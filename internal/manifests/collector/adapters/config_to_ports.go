@@ -24,6 +24,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser"
 	exporterParser "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser/exporter"
 	receiverParser "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser/receiver"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 type ComponentType int
@@ -34,12 +35,41 @@ const (
 	ComponentTypeProcessor
 )
 
+// PortNaming defines how the ports opened for the components are named.
+type PortNaming struct {
+	// HashSuffix names the ports whose component name is too long, or not a valid port name, after the beginning of
+	// their component name and a hash of their component name and port number, rather than after their port number.
+	HashSuffix bool
+	// Overrides are the names of the ports, indexed by the name of their component, when the component opens a single
+	// port, or by the name of their component and their port number, e.g. otlp/internal:4317.
+	Overrides map[string]string
+}
+
 func (c ComponentType) String() string {
 	return [...]string{"receiver", "exporter", "processor"}[c]
 }
 
 // ConfigToComponentPorts converts the incoming configuration object into a set of service ports required by the exporters.
-func ConfigToComponentPorts(logger logr.Logger, cType ComponentType, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
+func ConfigToComponentPorts(logger logr.Logger, cType ComponentType, config map[interface{}]interface{}, portNaming PortNaming) ([]corev1.ServicePort, error) {
+	componentPorts, err := ConfigToPortsByComponent(logger, cType, config, portNaming)
+	if err != nil {
+		return nil, err
+	}
+	ports := []corev1.ServicePort{}
+	for _, cmptPorts := range componentPorts {
+		ports = append(ports, cmptPorts...)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+
+	return ports, nil
+}
+
+// ConfigToPortsByComponent returns the service ports opened by the enabled components of the given type, indexed by
+// the name of their component.
+func ConfigToPortsByComponent(logger logr.Logger, cType ComponentType, config map[interface{}]interface{}, portNaming PortNaming) (map[string][]corev1.ServicePort, error) {
 	// now, we gather which ports we might need to open
 	// for that, we get all the exporters and check their `endpoint` properties,
 	// extracting the port from it. The port name has to be a "DNS_LABEL", so, we try to make it follow the pattern:
@@ -54,6 +84,8 @@ func ConfigToComponentPorts(logger logr.Logger, cType ComponentType, config map[
 	//   componentexample/settings:
 	//     endpoint: 0.0.0.0:12346
 	// in this case, we have 2 ports, named: "componentexample" and "componentexample-settings"
+	// the names that can't follow this pattern fall back to "port-${port}", or to a hash suffixed name with the
+	// hashSuffix naming strategy, and can be overridden in the port naming of the collector spec.
 	componentsProperty, ok := config[fmt.Sprintf("%ss", cType.String())]
 	if !ok {
 		return nil, fmt.Errorf("no %ss available as part of the configuration", cType)
@@ -70,7 +102,7 @@ func ConfigToComponentPorts(logger logr.Logger, cType ComponentType, config map[
 		return nil, fmt.Errorf("no enabled %ss available as part of the configuration", cType)
 	}
 
-	ports := []corev1.ServicePort{}
+	ports := map[string][]corev1.ServicePort{}
 	for key, val := range components {
 		// This check will pass only the enabled components,
 		// then only the related ports will be opened.
@@ -107,25 +139,21 @@ func ConfigToComponentPorts(logger logr.Logger, cType ComponentType, config map[
 		}
 
 		if len(exprtPorts) > 0 {
-			ports = append(ports, exprtPorts...)
+			ports[cmptName] = renamePorts(cmptName, exprtPorts, portNaming)
 		}
 	}
 
-	sort.Slice(ports, func(i, j int) bool {
-		return ports[i].Name < ports[j].Name
-	})
-
 	return ports, nil
 }
 
-func ConfigToPorts(logger logr.Logger, config map[interface{}]interface{}) ([]corev1.ServicePort, error) {
-	ports, err := ConfigToComponentPorts(logger, ComponentTypeReceiver, config)
+func ConfigToPorts(logger logr.Logger, config map[interface{}]interface{}, portNaming PortNaming) ([]corev1.ServicePort, error) {
+	ports, err := ConfigToComponentPorts(logger, ComponentTypeReceiver, config, portNaming)
 	if err != nil {
 		logger.Error(err, "there was a problem while getting the ports from the receivers")
 		return nil, err
 	}
 
-	exporterPorts, err := ConfigToComponentPorts(logger, ComponentTypeExporter, config)
+	exporterPorts, err := ConfigToComponentPorts(logger, ComponentTypeExporter, config, portNaming)
 	if err != nil {
 		logger.Error(err, "there was a problem while getting the ports from the exporters")
 		return nil, err
@@ -139,3 +167,21 @@ func ConfigToPorts(logger logr.Logger, config map[interface{}]interface{}) ([]co
 
 	return ports, nil
 }
+
+// renamePorts applies the port naming to the ports of a component.
+func renamePorts(cmptName string, ports []corev1.ServicePort, portNaming PortNaming) []corev1.ServicePort {
+	for i := range ports {
+		if name, ok := portNaming.Overrides[fmt.Sprintf("%s:%d", cmptName, ports[i].Port)]; ok {
+			ports[i].Name = name
+			continue
+		}
+		if name, ok := portNaming.Overrides[cmptName]; ok && len(ports) == 1 {
+			ports[i].Name = name
+			continue
+		}
+		if portNaming.HashSuffix && ports[i].Name == fmt.Sprintf("port-%d", ports[i].Port) {
+			ports[i].Name = naming.PortNameWithHash(cmptName, ports[i].Port)
+		}
+	}
+	return ports
+}
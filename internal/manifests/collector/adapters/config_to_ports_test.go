@@ -28,6 +28,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser/receiver"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 var logger = logf.Log.WithName("unit-tests")
@@ -84,7 +85,7 @@ func TestExtractPortsFromConfig(t *testing.T) {
 	require.NotEmpty(t, config)
 
 	// test
-	ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config)
+	ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config, adapters.PortNaming{})
 	assert.NoError(t, err)
 	assert.Len(t, ports, 10)
 
@@ -110,6 +111,46 @@ func TestExtractPortsFromConfig(t *testing.T) {
 	assert.ElementsMatch(t, expectedPorts, ports)
 }
 
+func TestExtractPortsFromConfigWithPortNaming(t *testing.T) {
+	// prepare
+	config, err := adapters.ConfigFromString(portConfigStr)
+	require.NoError(t, err)
+	require.NotEmpty(t, config)
+	portNaming := adapters.PortNaming{
+		HashSuffix: true,
+		Overrides: map[string]string{
+			"examplereceiver":   "example",
+			"jaeger/custom":     "jaeger-custom",
+			"jaeger:6831":       "jaeger-compact",
+			"otlp":              "ignored",
+			"otlp/2:55555":      "otlp-2",
+			"otlp/missing:4317": "missing",
+		},
+	}
+
+	// test
+	ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config, portNaming)
+	assert.NoError(t, err)
+
+	// verify
+	names := map[int32]string{}
+	for _, port := range ports {
+		names[port.Port] = port.Name
+	}
+	assert.Equal(t, map[int32]string{
+		12345: "example",
+		12346: naming.PortNameWithHash("examplereceiver/settings", 12346),
+		15268: "jaeger-custom",
+		14250: "jaeger-grpc",
+		6833:  naming.PortNameWithHash("jaeger", 6833),
+		6831:  "jaeger-compact",
+		55555: "otlp-2",
+		4317:  "otlp-grpc",
+		4318:  "otlp-http",
+		9411:  "zipkin",
+	}, names)
+}
+
 func TestNoPortsParsed(t *testing.T) {
 	for _, tt := range []struct {
 		expected  error
@@ -133,7 +174,7 @@ func TestNoPortsParsed(t *testing.T) {
 			require.NoError(t, err)
 
 			// test
-			ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config)
+			ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config, adapters.PortNaming{})
 
 			// verify
 			assert.Nil(t, ports)
@@ -162,7 +203,7 @@ func TestInvalidReceivers(t *testing.T) {
 			require.NoError(t, err)
 
 			// test
-			ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config)
+			ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config, adapters.PortNaming{})
 
 			// verify
 			assert.NoError(t, err)
@@ -198,7 +239,7 @@ func TestParserFailed(t *testing.T) {
 	}
 
 	// test
-	ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config)
+	ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, config, adapters.PortNaming{})
 
 	// verify
 	assert.Len(t, ports, 0)
@@ -38,8 +38,11 @@ var (
 )
 
 type probeConfiguration struct {
-	path string
-	port intstr.IntOrString
+	path   string
+	port   intstr.IntOrString
+	scheme corev1.URIScheme
+	// clientAuth is set when the health check endpoint requires client certificates, which the kubelet can't present.
+	clientAuth bool
 }
 
 const (
@@ -100,11 +103,22 @@ func ConfigToContainerProbe(config map[interface{}]interface{}) (*corev1.Probe,
 
 func createProbeFromExtension(extension interface{}) (*corev1.Probe, error) {
 	probeCfg := extractProbeConfigurationFromExtension(extension)
+	if probeCfg.clientAuth {
+		// the kubelet can't authenticate to the endpoint, so we can only check that it accepts connections
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: probeCfg.port,
+				},
+			},
+		}, nil
+	}
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
-				Path: probeCfg.path,
-				Port: probeCfg.port,
+				Path:   probeCfg.path,
+				Port:   probeCfg.port,
+				Scheme: probeCfg.scheme,
 			},
 		},
 	}, nil
@@ -115,9 +129,12 @@ func extractProbeConfigurationFromExtension(ext interface{}) probeConfiguration
 	if !ok {
 		return defaultProbeConfiguration()
 	}
+	scheme, clientAuth := extractTLSFromExtensionConfig(extensionCfg)
 	return probeConfiguration{
-		path: extractPathFromExtensionConfig(extensionCfg),
-		port: extractPortFromExtensionConfig(extensionCfg),
+		path:       extractPathFromExtensionConfig(extensionCfg),
+		port:       extractPortFromExtensionConfig(extensionCfg),
+		scheme:     scheme,
+		clientAuth: clientAuth,
 	}
 }
 
@@ -137,6 +154,18 @@ func extractPathFromExtensionConfig(cfg map[interface{}]interface{}) string {
 	return defaultHealthCheckPath
 }
 
+// extractTLSFromExtensionConfig returns the HTTPS scheme when TLS is enabled on the health check endpoint, and whether
+// the endpoint requires client certificates. The kubelet doesn't verify the server certificate of HTTPS probes, so
+// the CA of the endpoint doesn't have to be provided.
+func extractTLSFromExtensionConfig(cfg map[interface{}]interface{}) (corev1.URIScheme, bool) {
+	tls, ok := cfg["tls"].(map[interface{}]interface{})
+	if !ok {
+		return "", false
+	}
+	_, clientAuth := tls["client_ca_file"]
+	return corev1.URISchemeHTTPS, clientAuth
+}
+
 func extractPortFromExtensionConfig(cfg map[interface{}]interface{}) intstr.IntOrString {
 	endpoint, ok := cfg["endpoint"]
 	if !ok {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestConfigToProbeShouldCreateProbeFor(t *testing.T) {
@@ -118,6 +119,57 @@ service:
 	}
 }
 
+func TestConfigToProbeWithTLS(t *testing.T) {
+	t.Run("should probe over HTTPS when TLS is enabled", func(t *testing.T) {
+		config, err := ConfigFromString(`extensions:
+  health_check:
+    endpoint: 0.0.0.0:13133
+    tls:
+      cert_file: /certs/tls.crt
+      key_file: /certs/tls.key
+service:
+  extensions: [health_check]`)
+		require.NoError(t, err)
+
+		actualProbe, err := ConfigToContainerProbe(config)
+		require.NoError(t, err)
+		require.NotNil(t, actualProbe.HTTPGet)
+		assert.Equal(t, corev1.URISchemeHTTPS, actualProbe.HTTPGet.Scheme)
+		assert.Equal(t, int32(13133), actualProbe.HTTPGet.Port.IntVal)
+	})
+
+	t.Run("should probe the socket when client certificates are required", func(t *testing.T) {
+		config, err := ConfigFromString(`extensions:
+  health_check:
+    endpoint: 0.0.0.0:1234
+    tls:
+      cert_file: /certs/tls.crt
+      key_file: /certs/tls.key
+      client_ca_file: /certs/ca.crt
+service:
+  extensions: [health_check]`)
+		require.NoError(t, err)
+
+		actualProbe, err := ConfigToContainerProbe(config)
+		require.NoError(t, err)
+		assert.Nil(t, actualProbe.HTTPGet)
+		require.NotNil(t, actualProbe.TCPSocket)
+		assert.Equal(t, int32(1234), actualProbe.TCPSocket.Port.IntVal)
+	})
+
+	t.Run("should keep the default scheme without TLS", func(t *testing.T) {
+		config, err := ConfigFromString(`extensions:
+  health_check:
+service:
+  extensions: [health_check]`)
+		require.NoError(t, err)
+
+		actualProbe, err := ConfigToContainerProbe(config)
+		require.NoError(t, err)
+		assert.Equal(t, corev1.URIScheme(""), actualProbe.HTTPGet.Scheme)
+	})
+}
+
 func TestConfigToProbeShouldErrorIf(t *testing.T) {
 	tests := []struct {
 		expectedErr error
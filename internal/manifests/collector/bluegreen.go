@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// BlueGreenColorLabel is the label set on the pods of the collectors rolled out blue/green, with the color of
+	// their deployment.
+	BlueGreenColorLabel = "opentelemetry.io/color"
+	// BlueGreenRevisionAnnotation is the annotation set on the deployments of the collectors rolled out blue/green,
+	// with the revision of their pod template.
+	BlueGreenRevisionAnnotation = "opentelemetry.io/blue-green-revision"
+)
+
+// BlueGreenDeployment builds the deployment running the current spec of a collector rolled out blue/green. It is the
+// deployment of the active color when the pod template didn't change since the last switch, and the deployment of
+// the other color, previewing the changes, otherwise. The deployment not built is retained as it is.
+func BlueGreenDeployment(params manifests.Params) (*appsv1.Deployment, error) {
+	if !BlueGreenEnabled(params.OtelCol) {
+		return nil, nil
+	}
+	base, err := Deployment(params)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := podTemplateRevision(base)
+	if err != nil {
+		return nil, err
+	}
+
+	// the deployment and its pods share their labels
+	d := base.DeepCopy()
+	color := BlueGreenActiveColor(params.OtelCol)
+	if status := params.OtelCol.Status.BlueGreen; status != nil && status.ActiveRevision != "" && status.ActiveRevision != revision {
+		color = otherColor(color)
+	}
+	d.Name = ColorWorkloadName(params.OtelCol, string(color))
+	d.Annotations[BlueGreenRevisionAnnotation] = revision
+	d.Spec.Selector.MatchLabels[BlueGreenColorLabel] = string(color)
+	d.Spec.Template.Labels[BlueGreenColorLabel] = string(color)
+	return d, nil
+}
+
+// BlueGreenRevision returns the revision of the pod template of the current spec of a collector rolled out blue/green.
+func BlueGreenRevision(params manifests.Params) (string, error) {
+	d, err := Deployment(params)
+	if err != nil {
+		return "", err
+	}
+	return podTemplateRevision(d)
+}
+
+// BlueGreenEnabled returns true when the collector is rolled out blue/green.
+func BlueGreenEnabled(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.BlueGreen != nil && otelcol.Spec.Mode == v1beta1.ModeDeployment
+}
+
+// BlueGreenStarted returns true once the Services of a collector rolled out blue/green select the pods of a color,
+// which happens when the deployment of the first color is ready. The Services select the pods of the deployment of
+// the previous rollouts too until then.
+func BlueGreenStarted(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return BlueGreenEnabled(otelcol) && otelcol.Status.BlueGreen != nil && otelcol.Status.BlueGreen.ActiveRevision != ""
+}
+
+// DeploymentReady returns true when all the replicas of the deployment are updated and available.
+func DeploymentReady(d appsv1.Deployment) bool {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation && d.Status.UpdatedReplicas == desired &&
+		d.Status.Replicas == desired && d.Status.AvailableReplicas == desired
+}
+
+// BlueGreenActiveColor returns the color of the deployment the Services of a collector rolled out blue/green send
+// the data to.
+func BlueGreenActiveColor(otelcol v1beta1.OpenTelemetryCollector) v1beta1.BlueGreenColor {
+	if otelcol.Status.BlueGreen == nil || otelcol.Status.BlueGreen.Active == "" {
+		return v1beta1.BlueGreenColorBlue
+	}
+	return otelcol.Status.BlueGreen.Active
+}
+
+// BlueGreenPreviewColor returns the color of the deployment previewing the changes of a collector rolled out
+// blue/green.
+func BlueGreenPreviewColor(otelcol v1beta1.OpenTelemetryCollector) v1beta1.BlueGreenColor {
+	return otherColor(BlueGreenActiveColor(otelcol))
+}
+
+func otherColor(color v1beta1.BlueGreenColor) v1beta1.BlueGreenColor {
+	if color == v1beta1.BlueGreenColorBlue {
+		return v1beta1.BlueGreenColorGreen
+	}
+	return v1beta1.BlueGreenColorBlue
+}
+
+// serviceSelector returns the selector of the Services the collector receives data from, which only select the
+// pods of the active color of the collectors rolled out blue/green once they started.
+func serviceSelector(otelcol v1beta1.OpenTelemetryCollector) map[string]string {
+	selector := manifestutils.SelectorLabels(otelcol.ObjectMeta, ComponentOpenTelemetryCollector)
+	if BlueGreenStarted(otelcol) {
+		selector[BlueGreenColorLabel] = string(BlueGreenActiveColor(otelcol))
+	}
+	return selector
+}
+
+func podTemplateRevision(d *appsv1.Deployment) (string, error) {
+	b, err := json.Marshal(d.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return fmt.Sprintf("%x", h)[:16], nil
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestBlueGreenDeployment(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:      v1beta1.ModeDeployment,
+			BlueGreen: &v1beta1.BlueGreen{},
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}},
+				}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				}},
+			},
+		},
+	}
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+	revision, err := BlueGreenRevision(params)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name          string
+		status        *v1beta1.BlueGreenStatus
+		expectedColor string
+		serviceColor  string
+	}{
+		{
+			// the Services select the pods of the deployment of the previous rollouts too until the first color is ready
+			name:          "first rollout",
+			expectedColor: "blue",
+		},
+		{
+			name:          "unchanged spec",
+			status:        &v1beta1.BlueGreenStatus{Active: v1beta1.BlueGreenColorGreen, ActiveRevision: revision},
+			expectedColor: "green",
+			serviceColor:  "green",
+		},
+		{
+			name:          "changed spec",
+			status:        &v1beta1.BlueGreenStatus{Active: v1beta1.BlueGreenColorBlue, ActiveRevision: "previous"},
+			expectedColor: "green",
+			serviceColor:  "blue",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := params
+			params.OtelCol.Status.BlueGreen = tt.status
+
+			d, err := BlueGreenDeployment(params)
+			require.NoError(t, err)
+			require.NotNil(t, d)
+			assert.Equal(t, "my-instance-collector-"+tt.expectedColor, d.Name)
+			assert.Equal(t, revision, d.Annotations[BlueGreenRevisionAnnotation])
+			assert.Equal(t, tt.expectedColor, d.Spec.Selector.MatchLabels[BlueGreenColorLabel])
+			assert.Equal(t, tt.expectedColor, d.Spec.Template.Labels[BlueGreenColorLabel])
+			assert.NotContains(t, d.Labels, BlueGreenColorLabel)
+
+			svc, err := Service(params)
+			require.NoError(t, err)
+			require.NotNil(t, svc)
+			if tt.serviceColor == "" {
+				assert.NotContains(t, svc.Spec.Selector, BlueGreenColorLabel)
+			} else {
+				assert.Equal(t, tt.serviceColor, svc.Spec.Selector[BlueGreenColorLabel])
+			}
+
+			monitoring, err := MonitoringService(params)
+			require.NoError(t, err)
+			assert.NotContains(t, monitoring.Spec.Selector, BlueGreenColorLabel)
+		})
+	}
+}
+
+func TestBlueGreenRevision(t *testing.T) {
+	params := deploymentParams()
+	params.OtelCol.Spec.BlueGreen = &v1beta1.BlueGreen{}
+	revision, err := BlueGreenRevision(params)
+	require.NoError(t, err)
+
+	// the replicas are not part of the pod template
+	replicas := int32(5)
+	params.OtelCol.Spec.Replicas = &replicas
+	unchanged, err := BlueGreenRevision(params)
+	require.NoError(t, err)
+	assert.Equal(t, revision, unchanged)
+
+	params.OtelCol.Spec.Image = "collector:v0.0.1"
+	changed, err := BlueGreenRevision(params)
+	require.NoError(t, err)
+	assert.NotEqual(t, revision, changed)
+}
+
+func TestBlueGreenDeploymentDisabled(t *testing.T) {
+	d, err := BlueGreenDeployment(deploymentParams())
+	require.NoError(t, err)
+	assert.Nil(t, d)
+}
@@ -33,7 +33,14 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	var manifestFactories []manifests.K8sManifestFactory[manifests.Params]
 	switch params.OtelCol.Spec.Mode {
 	case v1beta1.ModeDeployment:
-		manifestFactories = append(manifestFactories, manifests.Factory(Deployment))
+		// with zones, the collector is split into one deployment per zone, and with blue/green rollouts, into one
+		// deployment per color
+		switch {
+		case BlueGreenEnabled(params.OtelCol):
+			manifestFactories = append(manifestFactories, manifests.Factory(BlueGreenDeployment))
+		case params.OtelCol.Spec.ZoneSpread == nil || len(params.OtelCol.Spec.ZoneSpread.Zones) == 0:
+			manifestFactories = append(manifestFactories, manifests.Factory(Deployment))
+		}
 		manifestFactories = append(manifestFactories, manifests.Factory(PodDisruptionBudget))
 	case v1beta1.ModeStatefulSet:
 		manifestFactories = append(manifestFactories, manifests.Factory(StatefulSet))
@@ -45,13 +52,21 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	}
 	manifestFactories = append(manifestFactories, []manifests.K8sManifestFactory[manifests.Params]{
 		manifests.Factory(ConfigMap),
+		manifests.Factory(JaegerRemoteSamplingConfigMap),
 		manifests.Factory(HorizontalPodAutoscaler),
 		manifests.Factory(ServiceAccount),
-		manifests.Factory(Service),
-		manifests.Factory(HeadlessService),
+		manifests.Factory(ExporterCheckJob),
+	}...)
+	if !params.OtelCol.Spec.SkipServiceCreation.Enabled {
+		manifestFactories = append(manifestFactories,
+			manifests.Factory(Service),
+			manifests.Factory(HeadlessService),
+		)
+	}
+	manifestFactories = append(manifestFactories,
 		manifests.Factory(MonitoringService),
 		manifests.Factory(Ingress),
-	}...)
+	)
 
 	if params.OtelCol.Spec.Observability.Metrics.EnableMetrics && featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
 		if params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
@@ -84,5 +99,21 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	for _, route := range routes {
 		resourceManifests = append(resourceManifests, route)
 	}
+	zoneDeployments, err := ZoneDeployments(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range zoneDeployments {
+		resourceManifests = append(resourceManifests, deployment)
+	}
+	if !params.OtelCol.Spec.SkipServiceCreation.Enabled {
+		portGroupServices, err := PortGroupServices(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range portGroupServices {
+			resourceManifests = append(resourceManifests, svc)
+		}
+	}
 	return resourceManifests, nil
 }
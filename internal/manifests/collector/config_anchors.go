@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// minAnchoredSize is the size, in bytes of its fingerprint, from which a repeated section of a config is replaced by
+// an alias. Smaller sections would hardly be shorter as aliases, and would be harder to read.
+const minAnchoredSize = 64
+
+// anchorNameRegexp matches the characters that can't be part of the generated anchor names.
+var anchorNameRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// sourceConfig returns the spec.config string the v1alpha1 collector was written with, held by its
+// v1beta1.ConfigSourceAnnotation, when the rendered config decodes to the same config: the anchors, aliases and merge
+// keys of the string are then kept in the config of the collector. The config is rendered from the structured
+// spec.config otherwise, e.g. when the operator changed it or when the string has top-level keys the collector
+// doesn't know.
+func sourceConfig(otelcol v1beta1.OpenTelemetryCollector, rendered string) (string, bool) {
+	source, ok := otelcol.Annotations[v1beta1.ConfigSourceAnnotation]
+	if !ok {
+		return "", false
+	}
+	expected, err := decodedConfig(rendered)
+	if err != nil {
+		return "", false
+	}
+	actual, err := decodedConfig(source)
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		return "", false
+	}
+	return source, true
+}
+
+// decodedConfig decodes the YAML config, resolving its aliases and merge keys, without the empty sequences the
+// rendering sets for the missing pipeline components.
+func decodedConfig(config string) (interface{}, error) {
+	var decoded interface{}
+	if err := yaml.Unmarshal([]byte(config), &decoded); err != nil {
+		return nil, err
+	}
+	// the numbers are compared after their JSON round trip, like the config stored by the API server
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return withoutEmptySequences(normalized), nil
+}
+
+func withoutEmptySequences(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if sequence, ok := child.([]interface{}); ok && len(sequence) == 0 {
+				delete(v, key)
+				continue
+			}
+			v[key] = withoutEmptySequences(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = withoutEmptySequences(child)
+		}
+	}
+	return value
+}
+
+// WithConfigAnchors renders the repeated mappings and sequences of the config as YAML anchors and aliases. The
+// collector resolves the aliases when loading its config, so it is equivalent to the given one, only shorter. The
+// structured spec.config of the v1beta1 collectors can't hold anchors, so the anchors are generated from the repeated
+// sections instead, and named after the key of their first occurrence. The merged mappings are aliased like any
+// other when they are repeated as a whole.
+func WithConfigAnchors(config string) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(config), &doc); err != nil {
+		return "", err
+	}
+	if doc.Kind == 0 {
+		return config, nil
+	}
+
+	a := &anchorer{first: map[string]*yaml.Node{}, firstKey: map[*yaml.Node]string{}, names: map[string]bool{}}
+	a.visit(&doc, "")
+	if len(a.aliases) == 0 {
+		return config, nil
+	}
+	for _, alias := range a.aliases {
+		if alias.target.Anchor == "" {
+			alias.target.Anchor = a.anchorName(alias.targetKey)
+		}
+		*alias.node = yaml.Node{Kind: yaml.AliasNode, Value: alias.target.Anchor, Alias: alias.target}
+	}
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+type anchorer struct {
+	// first holds the first occurrence of each section by fingerprint, and firstKey the key it is found at.
+	first    map[string]*yaml.Node
+	firstKey map[*yaml.Node]string
+	// names holds the anchors of the document.
+	names   map[string]bool
+	aliases []alias
+}
+
+type alias struct {
+	node      *yaml.Node
+	target    *yaml.Node
+	targetKey string
+}
+
+// visit walks the document in order, so that the anchors are always defined before their aliases. The repeated
+// sections aren't walked, as they are replaced by an alias as a whole.
+func (a *anchorer) visit(node *yaml.Node, key string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			a.visit(child, key)
+		}
+		return
+	case yaml.MappingNode, yaml.SequenceNode:
+	default:
+		return
+	}
+
+	if node.Anchor != "" {
+		a.names[node.Anchor] = true
+	} else {
+		fingerprint := nodeFingerprint(node)
+		if len(fingerprint) >= minAnchoredSize {
+			if target, ok := a.first[fingerprint]; ok {
+				a.aliases = append(a.aliases, alias{node: node, target: target, targetKey: a.firstKey[target]})
+				return
+			}
+			a.first[fingerprint] = node
+			a.firstKey[node] = key
+		}
+	}
+
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			a.visit(node.Content[i+1], node.Content[i].Value)
+		}
+		return
+	}
+	for i, child := range node.Content {
+		a.visit(child, fmt.Sprintf("%s-%d", key, i))
+	}
+}
+
+// anchorName returns a name for the anchor of a section found at the given key, unique in the document.
+func (a *anchorer) anchorName(key string) string {
+	base := strings.Trim(anchorNameRegexp.ReplaceAllString(key, "-"), "-")
+	if base == "" {
+		base = "section"
+	}
+	name := base
+	for i := 2; a.names[name]; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	a.names[name] = true
+	return name
+}
+
+// nodeFingerprint returns a string identifying the content of the node: two nodes with the same fingerprint are
+// decoded to the same value.
+func nodeFingerprint(node *yaml.Node) string {
+	var b strings.Builder
+	writeFingerprint(&b, node)
+	return b.String()
+}
+
+func writeFingerprint(b *strings.Builder, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		writeFingerprint(b, node.Alias)
+	case yaml.ScalarNode:
+		fmt.Fprintf(b, "%s%q", node.ShortTag(), node.Value)
+	case yaml.MappingNode:
+		b.WriteString("{")
+		for _, child := range node.Content {
+			writeFingerprint(b, child)
+			b.WriteString(",")
+		}
+		b.WriteString("}")
+	case yaml.SequenceNode:
+		b.WriteString("[")
+		for _, child := range node.Content {
+			writeFingerprint(b, child)
+			b.WriteString(",")
+		}
+		b.WriteString("]")
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+const repeatedSectionsConfig = `receivers:
+  filelog/a:
+    include: [/var/log/a.log]
+    operators:
+      - type: json_parser
+        timestamp:
+          parse_from: attributes.time
+          layout: '%Y-%m-%dT%H:%M:%S.%fZ'
+  filelog/b:
+    include: [/var/log/b.log]
+    operators:
+      - type: json_parser
+        timestamp:
+          parse_from: attributes.time
+          layout: '%Y-%m-%dT%H:%M:%S.%fZ'
+exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+      key_file: /etc/certs/tls.key
+  otlp/b:
+    endpoint: b:4317
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+      key_file: /etc/certs/tls.key
+  otlp/c:
+    endpoint: b:4317
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+      key_file: /etc/certs/tls.key
+`
+
+func TestWithConfigAnchors(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		config   string
+		expected string
+	}{
+		{
+			desc:   "repeated sections",
+			config: repeatedSectionsConfig,
+			expected: `receivers:
+  filelog/a:
+    include: [/var/log/a.log]
+    operators: &operators
+      - type: json_parser
+        timestamp:
+          parse_from: attributes.time
+          layout: '%Y-%m-%dT%H:%M:%S.%fZ'
+  filelog/b:
+    include: [/var/log/b.log]
+    operators: *operators
+exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls: &tls
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+      key_file: /etc/certs/tls.key
+  otlp/b: &otlp-b
+    endpoint: b:4317
+    tls: *tls
+  otlp/c: *otlp-b
+`,
+		},
+		{
+			desc: "small repeated sections",
+			config: `exporters:
+  debug/a:
+    verbosity: basic
+  debug/b:
+    verbosity: basic
+`,
+			expected: `exporters:
+  debug/a:
+    verbosity: basic
+  debug/b:
+    verbosity: basic
+`,
+		},
+		{
+			desc: "existing anchor names",
+			config: `tls: &tls
+  insecure: true
+exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+  otlp/b:
+    endpoint: b:4317
+    tls:
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+`,
+			expected: `tls: &tls
+  insecure: true
+exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls: &tls-2
+      ca_file: /etc/certs/ca.crt
+      cert_file: /etc/certs/tls.crt
+  otlp/b:
+    endpoint: b:4317
+    tls: *tls-2
+`,
+		},
+		{
+			desc:     "empty config",
+			config:   "",
+			expected: "",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual, err := WithConfigAnchors(tt.config)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+			assert.YAMLEq(t, tt.config, actual)
+		})
+	}
+}
+
+func TestWithConfigAnchorsMergeKeys(t *testing.T) {
+	config := `x-tls: &tls
+  ca_file: /etc/certs/ca.crt
+  cert_file: /etc/certs/tls.crt
+  key_file: /etc/certs/tls.key
+exporters:
+  otlp/a:
+    tls:
+      <<: *tls
+      insecure_skip_verify: true
+  otlp/b:
+    tls:
+      <<: *tls
+      insecure_skip_verify: true
+`
+
+	actual, err := WithConfigAnchors(config)
+
+	require.NoError(t, err)
+	assert.YAMLEq(t, config, actual)
+}
+
+func TestWithConfigAnchorsInvalidConfig(t *testing.T) {
+	_, err := WithConfigAnchors("🦄: [")
+	assert.Error(t, err)
+}
+
+func TestSourceConfig(t *testing.T) {
+	rendered := `exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls:
+      ca_file: /etc/certs/ca.crt
+  otlp/b:
+    endpoint: b:4317
+    tls:
+      ca_file: /etc/certs/ca.crt
+service:
+  pipelines:
+    traces:
+      exporters:
+        - otlp/a
+        - otlp/b
+      processors: []
+      receivers: []
+`
+	for _, tt := range []struct {
+		desc     string
+		source   string
+		verbatim bool
+	}{
+		{
+			desc: "same config",
+			source: `exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls: &tls
+      ca_file: /etc/certs/ca.crt
+  otlp/b:
+    <<: {endpoint: b:4317}
+    tls: *tls
+service:
+  pipelines:
+    traces:
+      exporters: [otlp/a, otlp/b]
+`,
+			verbatim: true,
+		},
+		{
+			desc: "changed config",
+			source: `exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls: &tls
+      ca_file: /etc/certs/ca.crt
+  otlp/b:
+    endpoint: c:4317
+    tls: *tls
+service:
+  pipelines:
+    traces:
+      exporters: [otlp/a, otlp/b]
+`,
+		},
+		{
+			desc: "anchors defined in an unknown section",
+			source: `x-tls: &tls
+  ca_file: /etc/certs/ca.crt
+exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls: *tls
+  otlp/b:
+    endpoint: b:4317
+    tls: *tls
+service:
+  pipelines:
+    traces:
+      exporters: [otlp/a, otlp/b]
+`,
+		},
+		{
+			desc:   "invalid config",
+			source: `!!!`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{}
+			otelcol.Annotations = map[string]string{v1beta1.ConfigSourceAnnotation: tt.source}
+
+			source, verbatim := sourceConfig(otelcol, rendered)
+
+			assert.Equal(t, tt.verbatim, verbatim)
+			if tt.verbatim {
+				assert.Equal(t, tt.source, source)
+			}
+		})
+	}
+
+	_, verbatim := sourceConfig(v1beta1.OpenTelemetryCollector{}, rendered)
+	assert.False(t, verbatim)
+}
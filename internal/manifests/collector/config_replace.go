@@ -15,15 +15,21 @@
 package collector
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"net"
 	"time"
 
 	promconfig "github.com/prometheus/prometheus/config"
 	_ "github.com/prometheus/prometheus/discovery/install" // Package install has the side-effect of registering all builtin.
 	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
@@ -42,15 +48,30 @@ type Config struct {
 	TargetAllocConfig *targetAllocator   `yaml:"target_allocator,omitempty"`
 }
 
-func ReplaceConfig(otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1alpha1.TargetAllocator) (string, error) {
+// ReplaceConfig renders the config the collector runs with. The pipeline defaults of the operator are added to the
+// rendered config only, the config of the collector's spec is left as written by the user.
+func ReplaceConfig(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1alpha1.TargetAllocator) (string, error) {
 	collectorSpec := otelcol.Spec
 	taEnabled := targetAllocator != nil
-	cfgStr, err := collectorSpec.Config.Yaml()
+	ipv6Only := isIPv6Only(collectorSpec.IPFamilies)
+	samplingEnabled := collectorSpec.JaegerRemoteSampling != nil
+	collectorCfg := collectorSpec.Config
+	if cfg.EnablePipelineDefaults() && !collectorSpec.SkipPipelineDefaults {
+		collectorCfg = collectorCfg.WithPipelineDefaults()
+	}
+	if collectorSpec.ExporterFailover != nil {
+		failoverCfg, err := collectorCfg.WithExporterFailover(*collectorSpec.ExporterFailover)
+		if err != nil {
+			return "", err
+		}
+		collectorCfg = failoverCfg
+	}
+	cfgStr, err := collectorCfg.Yaml()
 	if err != nil {
 		return "", err
 	}
-	// Check if TargetAllocator is present, if not, return the original config
-	if !taEnabled {
+	// Check if the config needs any changes, if not, return the original config
+	if !taEnabled && !ipv6Only && !samplingEnabled {
 		return cfgStr, nil
 	}
 
@@ -59,25 +80,35 @@ func ReplaceConfig(otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1al
 		return "", err
 	}
 
-	promCfgMap, getCfgPromErr := ta.ConfigToPromConfig(cfgStr)
-	if getCfgPromErr != nil {
-		return "", getCfgPromErr
+	if ipv6Only {
+		replaceIPv4Wildcard(config)
 	}
 
-	validateCfgPromErr := ta.ValidatePromConfig(promCfgMap, taEnabled)
-	if validateCfgPromErr != nil {
-		return "", validateCfgPromErr
+	if samplingEnabled {
+		setJaegerRemoteSamplingSource(config, collectorSpec.JaegerRemoteSampling)
 	}
 
-	// To avoid issues caused by Prometheus validation logic, which fails regex validation when it encounters
-	// $$ in the prom config, we update the YAML file directly without marshaling and unmarshalling.
-	updPromCfgMap, getCfgPromErr := ta.AddTAConfigToPromConfig(promCfgMap, naming.TAService(targetAllocator.Name))
-	if getCfgPromErr != nil {
-		return "", getCfgPromErr
-	}
+	if taEnabled {
+		promCfgMap, getCfgPromErr := ta.ConfigToPromConfig(cfgStr)
+		if getCfgPromErr != nil {
+			return "", getCfgPromErr
+		}
 
-	// type coercion checks are handled in the AddTAConfigToPromConfig method above
-	config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
+		validateCfgPromErr := ta.ValidatePromConfig(promCfgMap, taEnabled)
+		if validateCfgPromErr != nil {
+			return "", validateCfgPromErr
+		}
+
+		// To avoid issues caused by Prometheus validation logic, which fails regex validation when it encounters
+		// $$ in the prom config, we update the YAML file directly without marshaling and unmarshalling.
+		updPromCfgMap, getCfgPromErr := ta.AddTAConfigToPromConfig(promCfgMap, naming.TAService(targetAllocator.Name))
+		if getCfgPromErr != nil {
+			return "", getCfgPromErr
+		}
+
+		// type coercion checks are handled in the AddTAConfigToPromConfig method above
+		config["receivers"].(map[interface{}]interface{})["prometheus"] = updPromCfgMap
+	}
 
 	out, updCfgMarshalErr := yaml.Marshal(config)
 	if updCfgMarshalErr != nil {
@@ -86,3 +117,74 @@ func ReplaceConfig(otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1al
 
 	return string(out), nil
 }
+
+// GetCollectorConfigSHA returns the hash of the config rendered for the collector, so that every change of the config
+// the collector runs with rolls its pods, including the changes made by the operator when rendering it. When the target
+// allocator is enabled, the scrape configs of the prometheus receiver are left out, as the collectors fetch them from
+// the target allocator at runtime, so that changing them doesn't roll the collector pods. The target allocator block
+// added to the prometheus receiver is left out as well, as it only depends on the name of the collector.
+func GetCollectorConfigSHA(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) (string, error) {
+	if otelcol.Spec.TargetAllocator.Enabled {
+		// the receivers are copied, the collector's config is left untouched
+		otelcol.Spec.Config.Receivers = v1beta1.AnyConfig{Object: manifestutils.WithoutScrapeConfigs(otelcol.Spec.Config.Receivers.Object)}
+	}
+	rendered, err := ReplaceConfig(cfg, otelcol, nil)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256([]byte(rendered))
+	return fmt.Sprintf("%x", h), nil
+}
+
+// isIPv6Only returns true when the given IP families only request IPv6.
+func isIPv6Only(families []corev1.IPFamily) bool {
+	if len(families) == 0 {
+		return false
+	}
+	for _, family := range families {
+		if family != corev1.IPv6Protocol {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceIPv4Wildcard makes receivers listening on all IPv4 interfaces listen on all IPv6 interfaces instead,
+// as an IPv6-only pod can't be reached on 0.0.0.0. Both the receiver's own endpoint and the endpoints of its
+// protocols (like the OTLP receiver's grpc and http protocols) are updated.
+func replaceIPv4Wildcard(config map[interface{}]interface{}) {
+	receivers, ok := config["receivers"].(map[interface{}]interface{})
+	if !ok {
+		return
+	}
+	for _, rcv := range receivers {
+		rcvCfg, ok := rcv.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		replaceIPv4WildcardEndpoint(rcvCfg)
+		protocols, ok := rcvCfg["protocols"].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		for _, protocol := range protocols {
+			if protocolCfg, ok := protocol.(map[interface{}]interface{}); ok {
+				replaceIPv4WildcardEndpoint(protocolCfg)
+			}
+		}
+	}
+}
+
+func replaceIPv4WildcardEndpoint(cfg map[interface{}]interface{}) {
+	for _, key := range []string{"endpoint", "listen_address"} {
+		endpoint, ok := cfg[key].(string)
+		if !ok {
+			continue
+		}
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil || host != "0.0.0.0" {
+			continue
+		}
+		cfg[key] = net.JoinHostPort("::", port)
+	}
+}
@@ -17,11 +17,17 @@ package collector
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
 )
 
@@ -31,7 +37,7 @@ func TestPrometheusParser(t *testing.T) {
 
 	t.Run("should update config with targetAllocator block if block not present", func(t *testing.T) {
 		// Set up the test scenario
-		actualConfig, err := ReplaceConfig(param.OtelCol, param.TargetAllocator)
+		actualConfig, err := ReplaceConfig(param.Config, param.OtelCol, param.TargetAllocator)
 		assert.NoError(t, err)
 
 		// Verify the expected changes in the config
@@ -56,7 +62,7 @@ func TestPrometheusParser(t *testing.T) {
 		paramTa, err := newParams("test/test-img", "testdata/http_sd_config_ta_test.yaml")
 		require.NoError(t, err)
 
-		actualConfig, err := ReplaceConfig(paramTa.OtelCol, param.TargetAllocator)
+		actualConfig, err := ReplaceConfig(paramTa.Config, paramTa.OtelCol, param.TargetAllocator)
 		assert.NoError(t, err)
 
 		// Verify the expected changes in the config
@@ -77,7 +83,7 @@ func TestPrometheusParser(t *testing.T) {
 	})
 
 	t.Run("should not update config with http_sd_config", func(t *testing.T) {
-		actualConfig, err := ReplaceConfig(param.OtelCol, nil)
+		actualConfig, err := ReplaceConfig(param.Config, param.OtelCol, nil)
 		assert.NoError(t, err)
 
 		// prepare
@@ -119,7 +125,7 @@ func TestReplaceConfig(t *testing.T) {
 		assert.NoError(t, err)
 		expectedConfig := string(expectedConfigBytes)
 
-		actualConfig, err := ReplaceConfig(param.OtelCol, nil)
+		actualConfig, err := ReplaceConfig(param.Config, param.OtelCol, nil)
 		assert.NoError(t, err)
 
 		assert.YAMLEq(t, expectedConfig, actualConfig)
@@ -131,9 +137,249 @@ func TestReplaceConfig(t *testing.T) {
 		assert.NoError(t, err)
 		expectedConfig := string(expectedConfigBytes)
 
-		actualConfig, err := ReplaceConfig(param.OtelCol, param.TargetAllocator)
+		actualConfig, err := ReplaceConfig(param.Config, param.OtelCol, param.TargetAllocator)
 		assert.NoError(t, err)
 
 		assert.YAMLEq(t, expectedConfig, actualConfig)
 	})
 }
+
+func TestReplaceConfigIPv6Only(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{
+					Object: map[string]interface{}{
+						"otlp": map[string]interface{}{
+							"protocols": map[string]interface{}{
+								"grpc": map[string]interface{}{
+									"endpoint": "0.0.0.0:4317",
+								},
+								"http": map[string]interface{}{
+									"endpoint": "localhost:4318",
+								},
+							},
+						},
+						"statsd": map[string]interface{}{
+							"endpoint": "0.0.0.0:8125",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("should not modify listen addresses when IPv4 is requested", func(t *testing.T) {
+		otelcol.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}
+		actualConfig, err := ReplaceConfig(config.New(), otelcol, nil)
+		require.NoError(t, err)
+
+		cfg, err := adapters.ConfigFromString(actualConfig)
+		require.NoError(t, err)
+		statsd := cfg["receivers"].(map[interface{}]interface{})["statsd"].(map[interface{}]interface{})
+		assert.Equal(t, "0.0.0.0:8125", statsd["endpoint"])
+	})
+
+	t.Run("should listen on all IPv6 interfaces when only IPv6 is requested", func(t *testing.T) {
+		otelcol.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+		actualConfig, err := ReplaceConfig(config.New(), otelcol, nil)
+		require.NoError(t, err)
+
+		cfg, err := adapters.ConfigFromString(actualConfig)
+		require.NoError(t, err)
+		receivers := cfg["receivers"].(map[interface{}]interface{})
+		statsd := receivers["statsd"].(map[interface{}]interface{})
+		assert.Equal(t, "[::]:8125", statsd["endpoint"])
+		protocols := receivers["otlp"].(map[interface{}]interface{})["protocols"].(map[interface{}]interface{})
+		assert.Equal(t, "[::]:4317", protocols["grpc"].(map[interface{}]interface{})["endpoint"])
+		assert.Equal(t, "localhost:4318", protocols["http"].(map[interface{}]interface{})["endpoint"])
+	})
+
+	t.Run("should change the config hash when the listen addresses are rewritten", func(t *testing.T) {
+		otelcol.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}
+		dualStackHash, err := GetCollectorConfigSHA(config.New(), otelcol)
+		require.NoError(t, err)
+		otelcol.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+		ipv6OnlyHash, err := GetCollectorConfigSHA(config.New(), otelcol)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, dualStackHash, ipv6OnlyHash)
+	})
+}
+
+func TestReplaceConfigExporterFailover(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				}},
+			},
+			ExporterFailover: &v1beta1.ExporterFailover{
+				Endpoints: []v1beta1.FailoverEndpoint{{Endpoint: "primary:4317"}, {Endpoint: "secondary:4317"}},
+			},
+		},
+	}
+
+	actualConfig, err := ReplaceConfig(config.New(), otelcol, nil)
+	require.NoError(t, err)
+
+	cfg, err := adapters.ConfigFromString(actualConfig)
+	require.NoError(t, err)
+	exporters := cfg["exporters"].(map[interface{}]interface{})
+	assert.Equal(t, "primary:4317", exporters["otlp/failover-0"].(map[interface{}]interface{})["endpoint"])
+	assert.Equal(t, "secondary:4317", exporters["otlp/failover-1"].(map[interface{}]interface{})["endpoint"])
+	connectors := cfg["connectors"].(map[interface{}]interface{})
+	assert.Equal(t, []interface{}{[]interface{}{"traces/failover-0"}, []interface{}{"traces/failover-1"}},
+		connectors["failover/traces"].(map[interface{}]interface{})["priority_levels"])
+	pipelines := cfg["service"].(map[interface{}]interface{})["pipelines"].(map[interface{}]interface{})
+	assert.Equal(t, []interface{}{"debug", "failover/traces"}, pipelines["traces"].(map[interface{}]interface{})["exporters"])
+	assert.Equal(t, []interface{}{"failover/traces"}, pipelines["traces/failover-0"].(map[interface{}]interface{})["receivers"])
+}
+
+func TestReplaceConfigPipelineDefaults(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		enabled    bool
+		skip       bool
+		processors interface{}
+	}{
+		{name: "policy disabled", enabled: false, processors: []interface{}{}},
+		{name: "policy enabled", enabled: true, processors: []interface{}{"batch"}},
+		{name: "collector opted out", enabled: true, skip: true, processors: []interface{}{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					SkipPipelineDefaults: tt.skip,
+					Config: v1beta1.Config{
+						Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": nil}},
+						Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+						Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+							"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+						}},
+					},
+				},
+			}
+
+			actualConfig, err := ReplaceConfig(config.New(config.WithEnablePipelineDefaults(tt.enabled)), otelcol, nil)
+			require.NoError(t, err)
+
+			cfg, err := adapters.ConfigFromString(actualConfig)
+			require.NoError(t, err)
+			pipelines := cfg["service"].(map[interface{}]interface{})["pipelines"].(map[interface{}]interface{})
+			assert.Equal(t, tt.processors, pipelines["traces"].(map[interface{}]interface{})["processors"])
+			assert.Empty(t, otelcol.Spec.Config.Service.Pipelines["traces"].Processors, "the spec should be left untouched")
+		})
+	}
+}
+
+func TestCollectorConfigSHAIgnoresTargetAllocatorScrapeConfigs(t *testing.T) {
+	// prepare
+	withScrapeConfigs := func(jobName string) v1beta1.OpenTelemetryCollector {
+		return v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config: v1beta1.Config{
+					Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+						"prometheus": map[string]interface{}{
+							"config": map[string]interface{}{
+								"scrape_interval": "10s",
+								"scrape_configs": []interface{}{
+									map[string]interface{}{"job_name": jobName},
+								},
+							},
+						},
+					}},
+				},
+				TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+					Enabled: true,
+				},
+			},
+		}
+	}
+	first := withScrapeConfigs("first")
+	second := withScrapeConfigs("second")
+
+	// test
+	firstHash, err := GetCollectorConfigSHA(config.New(), first)
+	require.NoError(t, err)
+	secondHash, err := GetCollectorConfigSHA(config.New(), second)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, firstHash, secondHash)
+	assert.Len(t, first.Spec.Config.Receivers.Object["prometheus"].(map[string]interface{})["config"], 2, "the config should be left untouched")
+
+	// without the target allocator, the scrape configs are part of the collector config
+	first.Spec.TargetAllocator.Enabled = false
+	second.Spec.TargetAllocator.Enabled = false
+	firstHash, err = GetCollectorConfigSHA(config.New(), first)
+	require.NoError(t, err)
+	secondHash, err = GetCollectorConfigSHA(config.New(), second)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstHash, secondHash)
+}
+
+func TestCollectorConfigSHAWithJaegerRemoteSampling(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Extensions: &v1beta1.AnyConfig{Object: map[string]interface{}{"jaegerremotesampling": nil}},
+			},
+		},
+	}
+	withoutSampling, err := GetCollectorConfigSHA(config.New(), otelcol)
+	require.NoError(t, err)
+
+	// test
+	otelcol.Spec.JaegerRemoteSampling = &v1beta1.JaegerRemoteSampling{
+		Strategies: &v1beta1.AnyConfig{Object: map[string]interface{}{"default_strategy": map[string]interface{}{"param": 0.5}}},
+	}
+	withSampling, err := GetCollectorConfigSHA(config.New(), otelcol)
+	require.NoError(t, err)
+	otelcol.Spec.JaegerRemoteSampling.Strategies.Object["default_strategy"] = map[string]interface{}{"param": 0.1}
+	withOtherStrategies, err := GetCollectorConfigSHA(config.New(), otelcol)
+	require.NoError(t, err)
+	otelcol.Spec.JaegerRemoteSampling.ReloadInterval = &metav1.Duration{Duration: time.Minute}
+	withReloadInterval, err := GetCollectorConfigSHA(config.New(), otelcol)
+	require.NoError(t, err)
+
+	// verify
+	assert.NotEqual(t, withoutSampling, withSampling)
+	assert.Equal(t, withSampling, withOtherStrategies, "the strategies are reloaded by the collector")
+	assert.NotEqual(t, withSampling, withReloadInterval)
+}
+
+func TestCollectorConfigSHAWithExporterFailover(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": nil}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				}},
+			},
+		},
+	}
+	withoutFailover, err := GetCollectorConfigSHA(config.New(), otelcol)
+	require.NoError(t, err)
+
+	// test
+	otelcol.Spec.ExporterFailover = &v1beta1.ExporterFailover{
+		Endpoints: []v1beta1.FailoverEndpoint{{Endpoint: "primary:4317"}, {Endpoint: "secondary:4317"}},
+	}
+	withFailover, err := GetCollectorConfigSHA(config.New(), otelcol)
+	require.NoError(t, err)
+	otelcol.Spec.ExporterFailover.Endpoints[1].Endpoint = "other:4317"
+	withOtherEndpoint, err := GetCollectorConfigSHA(config.New(), otelcol)
+	require.NoError(t, err)
+
+	// verify
+	assert.NotEqual(t, withoutFailover, withFailover)
+	assert.NotEqual(t, withFailover, withOtherEndpoint)
+}
@@ -20,33 +20,94 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
-	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
+// ScrapeConfigsHashAnnotation is set on the collector ConfigMap to the hash of the scrape configs served by the target
+// allocator. They aren't part of the collector config, which only changes when the rest of the config does.
+const ScrapeConfigsHashAnnotation = "opentelemetry-operator-config/scrape-configs-sha256"
+
 func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
-	hash, err := manifestutils.GetConfigMapSHA(params.OtelCol.Spec.Config)
+	hash, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
-	name := naming.ConfigMap(params.OtelCol.Name, hash)
-	collectorName := naming.Collector(params.OtelCol.Name)
+	name := ConfigMapName(params.OtelCol, hash)
+	collectorName := WorkloadName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, collectorName, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
 
-	replacedConf, err := ReplaceConfig(params.OtelCol, params.TargetAllocator)
+	replacedConf, err := ReplaceConfig(params.Config, params.OtelCol, params.TargetAllocator)
 	if err != nil {
 		params.Log.V(2).Info("failed to update prometheus config to use sharded targets: ", "err", err)
 		return nil, err
 	}
 
+	var annotations map[string]string
+	for k, v := range params.OtelCol.Annotations {
+		if manifestutils.IsFilteredSet(k, params.Config.AnnotationsFilter()) {
+			continue
+		}
+		if annotations == nil {
+			// new map, so that we don't touch the instance's annotations
+			annotations = map[string]string{}
+		}
+		annotations[k] = v
+	}
+	if params.TargetAllocator != nil {
+		scrapeConfigsHash, err := manifestutils.GetScrapeConfigsSHA(params.OtelCol)
+		if err != nil {
+			return nil, err
+		}
+		if scrapeConfigsHash != "" {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[ScrapeConfigsHashAnnotation] = scrapeConfigsHash
+		}
+	}
+
+	entry := params.Config.CollectorConfigMapEntry()
+	data := map[string]string{
+		entry: replacedConf,
+	}
+	// the anchors written in the config of the v1alpha1 collectors are kept
+	source, verbatim := sourceConfig(params.OtelCol, replacedConf)
+	if verbatim {
+		data[entry] = source
+	}
+	if PipelinesSplit(params.OtelCol) {
+		collectors, err := signalCollectors(params.OtelCol)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range collectors {
+			signalConf, err := ReplaceConfig(params.Config, c.otelcol, params.TargetAllocator)
+			if err != nil {
+				return nil, err
+			}
+			data[signalConfigMapEntry(entry, c.signal)] = signalConf
+		}
+	}
+	if featuregate.EnableConfigAnchors.IsEnabled() {
+		for e, conf := range data {
+			if verbatim && e == entry {
+				continue
+			}
+			anchoredConf, err := WithConfigAnchors(conf)
+			if err != nil {
+				return nil, err
+			}
+			data[e] = anchoredConf
+		}
+	}
+
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
-			Annotations: params.OtelCol.Annotations,
-		},
-		Data: map[string]string{
-			"collector.yaml": replacedConf,
+			Annotations: annotations,
 		},
+		Data: data,
 	}, nil
 }
@@ -18,9 +18,16 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	go_yaml "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestDesiredConfigMap(t *testing.T) {
@@ -58,7 +65,7 @@ service:
 		}
 
 		param := deploymentParams()
-		hash, _ := manifestutils.GetConfigMapSHA(param.OtelCol.Spec.Config)
+		hash, _ := GetCollectorConfigSHA(param.Config, param.OtelCol)
 		expectedName := naming.ConfigMap("test", hash)
 
 		expectedLables["app.kubernetes.io/component"] = "opentelemetry-collector"
@@ -101,14 +108,14 @@ service:
 		param, err := newParams("test/test-img", "testdata/http_sd_config_servicemonitor_test.yaml")
 		assert.NoError(t, err)
 
-		hash, _ := manifestutils.GetConfigMapSHA(param.OtelCol.Spec.Config)
+		param.OtelCol.Spec.TargetAllocator.Enabled = true
+		hash, _ := GetCollectorConfigSHA(param.Config, param.OtelCol)
 		expectedName := naming.ConfigMap("test", hash)
 
 		expectedLables["app.kubernetes.io/component"] = "opentelemetry-collector"
 		expectedLables["app.kubernetes.io/name"] = "test-collector"
 		expectedLables["app.kubernetes.io/version"] = "latest"
 
-		param.OtelCol.Spec.TargetAllocator.Enabled = true
 		actual, err := ConfigMap(param)
 
 		assert.NoError(t, err)
@@ -125,4 +132,121 @@ service:
 
 	})
 
+	t.Run("should keep the collector config map when the target allocator scrape configs change", func(t *testing.T) {
+		param, err := newParams("test/test-img", "testdata/http_sd_config_servicemonitor_test.yaml")
+		assert.NoError(t, err)
+
+		before, err := ConfigMap(param)
+		assert.NoError(t, err)
+		scrapeConfigsHash, _ := manifestutils.GetScrapeConfigsSHA(param.OtelCol)
+		assert.Equal(t, scrapeConfigsHash, before.Annotations[ScrapeConfigsHashAnnotation])
+
+		param.OtelCol.Spec.Config.Receivers.Object["prometheus"] = map[string]interface{}{
+			"config": map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{
+						"job_name": "other",
+						"static_configs": []interface{}{
+							map[string]interface{}{"targets": []interface{}{"prom.domain:1004"}},
+						},
+					},
+				},
+			},
+		}
+		after, err := ConfigMap(param)
+		assert.NoError(t, err)
+
+		assert.Equal(t, before.Name, after.Name)
+		assert.Equal(t, before.Data, after.Data)
+		assert.NotEqual(t, before.Annotations[ScrapeConfigsHashAnnotation], after.Annotations[ScrapeConfigsHashAnnotation])
+		assert.Nil(t, param.OtelCol.Annotations)
+	})
+
+	t.Run("should filter the annotations of the collector", func(t *testing.T) {
+		param := deploymentParams()
+		param.OtelCol.Annotations = map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			upgrade.UpgradeStartedAnnotation:                   "2024-01-01T00:00:00Z",
+			"custom":                                           "value",
+		}
+
+		actual, err := ConfigMap(param)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"custom": "value"}, actual.Annotations)
+		assert.Len(t, param.OtelCol.Annotations, 3)
+	})
+
+	t.Run("should keep the anchors of the v1alpha1 config", func(t *testing.T) {
+		source := `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+        tls: &tls
+          ca_file: /etc/certs/ca.crt
+          cert_file: /etc/certs/tls.crt
+exporters:
+  otlp/a:
+    endpoint: a:4317
+    tls: *tls
+  otlp/b:
+    endpoint: b:4317
+    tls:
+      <<: *tls
+      insecure_skip_verify: true
+service:
+  pipelines:
+    traces: &pipeline
+      receivers: [otlp]
+      exporters: [otlp/a, otlp/b]
+    metrics: *pipeline
+`
+		param := deploymentParams()
+		param.OtelCol.Spec.Config = v1beta1.Config{}
+		require.NoError(t, go_yaml.Unmarshal([]byte(source), &param.OtelCol.Spec.Config))
+		param.OtelCol.Annotations = map[string]string{v1beta1.ConfigSourceAnnotation: source}
+
+		actual, err := ConfigMap(param)
+
+		require.NoError(t, err)
+		assert.Equal(t, source, actual.Data["collector.yaml"])
+		assert.NotContains(t, actual.Annotations, v1beta1.ConfigSourceAnnotation)
+
+		// the config changed by the operator is rendered from the structured config
+		param.OtelCol.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+
+		actual, err = ConfigMap(param)
+
+		require.NoError(t, err)
+		assert.NotContains(t, actual.Data["collector.yaml"], "*tls")
+		assert.Contains(t, actual.Data["collector.yaml"], "[::]:4317")
+	})
+
+	t.Run("should render the repeated sections of the config as aliases", func(t *testing.T) {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableConfigAnchors.ID(), true))
+		t.Cleanup(func() {
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableConfigAnchors.ID(), false))
+		})
+
+		param := deploymentParams()
+		tls := map[string]interface{}{
+			"ca_file":   "/etc/certs/ca.crt",
+			"cert_file": "/etc/certs/tls.crt",
+			"key_file":  "/etc/certs/tls.key",
+		}
+		param.OtelCol.Spec.Config.Exporters.Object = map[string]interface{}{
+			"otlp/a": map[string]interface{}{"endpoint": "a:4317", "tls": tls},
+			"otlp/b": map[string]interface{}{"endpoint": "b:4317", "tls": tls},
+		}
+		expected, err := param.OtelCol.Spec.Config.Yaml()
+		require.NoError(t, err)
+
+		actual, err := ConfigMap(param)
+
+		require.NoError(t, err)
+		assert.Contains(t, actual.Data["collector.yaml"], "tls: &tls")
+		assert.Contains(t, actual.Data["collector.yaml"], "tls: *tls")
+		assert.YAMLEq(t, expected, actual.Data["collector.yaml"])
+	})
 }
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// scheduleExpression parses the cron expression of the schedule, and returns the time zone it is evaluated in.
+func scheduleExpression(schedule v1beta1.ConfigSchedule) (*cronexpr.Expression, *time.Location, error) {
+	if schedule.Duration.Duration <= 0 {
+		return nil, nil, fmt.Errorf("the duration should be greater than zero")
+	}
+	expr, err := cronexpr.Parse(schedule.Schedule)
+	if err != nil {
+		return nil, nil, err
+	}
+	location := time.UTC
+	if schedule.TimeZone != "" {
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, nil, err
+		}
+	}
+	return expr, location, nil
+}
+
+// ActiveConfigSchedule returns the first config schedule of the collector whose window is open at the given time,
+// or nil if none is. The schedules with an invalid cron expression or time zone are ignored.
+func ActiveConfigSchedule(otelcol v1beta1.OpenTelemetryCollector, t time.Time) *v1beta1.ConfigSchedule {
+	if otelcol.Spec.Mode == v1beta1.ModeSidecar {
+		return nil
+	}
+	for i, schedule := range otelcol.Spec.ConfigSchedules {
+		expr, location, err := scheduleExpression(schedule)
+		if err != nil {
+			continue
+		}
+		// the window is open if it opened within the last duration
+		opening := expr.Next(t.In(location).Add(-schedule.Duration.Duration))
+		if !opening.IsZero() && !opening.After(t) {
+			return &otelcol.Spec.ConfigSchedules[i]
+		}
+	}
+	return nil
+}
+
+// NextConfigScheduleChange returns the next time after the given one a window of the config schedules of the
+// collector opens or closes, or the zero time if none does.
+func NextConfigScheduleChange(otelcol v1beta1.OpenTelemetryCollector, t time.Time) time.Time {
+	if otelcol.Spec.Mode == v1beta1.ModeSidecar {
+		return time.Time{}
+	}
+	var next time.Time
+	earliest := func(candidate time.Time) {
+		if !candidate.IsZero() && candidate.After(t) && (next.IsZero() || candidate.Before(next)) {
+			next = candidate
+		}
+	}
+	for _, schedule := range otelcol.Spec.ConfigSchedules {
+		expr, location, err := scheduleExpression(schedule)
+		if err != nil {
+			continue
+		}
+		local := t.In(location)
+		if opening := expr.Next(local.Add(-schedule.Duration.Duration)); !opening.IsZero() && !opening.After(t) {
+			earliest(opening.Add(schedule.Duration.Duration))
+		}
+		earliest(expr.Next(local))
+	}
+	return next
+}
+
+// WithConfigSchedule returns the collector with the config of the schedule active at the given time merged into its
+// config, or the collector as is when no schedule is active.
+func WithConfigSchedule(otelcol v1beta1.OpenTelemetryCollector, t time.Time) (v1beta1.OpenTelemetryCollector, error) {
+	schedule := ActiveConfigSchedule(otelcol, t)
+	if schedule == nil {
+		return otelcol, nil
+	}
+	merged, err := otelcol.Spec.Config.WithOverlay(schedule.Config)
+	if err != nil {
+		return otelcol, fmt.Errorf("failed to merge the config of the %s schedule: %w", schedule.Name, err)
+	}
+	scheduled := *otelcol.DeepCopy()
+	scheduled.Spec.Config = merged
+	return scheduled, nil
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func scheduledCollector() v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Processors: &v1beta1.AnyConfig{Object: map[string]interface{}{
+					"probabilistic_sampler": map[string]interface{}{"sampling_percentage": float64(100)},
+				}},
+			},
+			ConfigSchedules: []v1beta1.ConfigSchedule{
+				{
+					Name:     "night",
+					Schedule: "0 20 * * *",
+					Duration: metav1.Duration{Duration: 12 * time.Hour},
+					Config: v1beta1.AnyConfig{Object: map[string]interface{}{
+						"processors": map[string]interface{}{
+							"probabilistic_sampler": map[string]interface{}{"sampling_percentage": float64(10)},
+						},
+					}},
+				},
+				{
+					Name:     "weekend",
+					Schedule: "0 0 * * SAT",
+					Duration: metav1.Duration{Duration: 48 * time.Hour},
+				},
+			},
+		},
+	}
+}
+
+func TestActiveConfigSchedule(t *testing.T) {
+	otelcol := scheduledCollector()
+
+	for _, tt := range []struct {
+		name     string
+		time     time.Time
+		expected string
+	}{
+		{name: "day", time: time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)},
+		{name: "evening", time: time.Date(2024, time.June, 5, 21, 0, 0, 0, time.UTC), expected: "night"},
+		{name: "early morning", time: time.Date(2024, time.June, 6, 7, 59, 0, 0, time.UTC), expected: "night"},
+		{name: "saturday", time: time.Date(2024, time.June, 8, 12, 0, 0, 0, time.UTC), expected: "weekend"},
+		// the first open window wins
+		{name: "saturday night", time: time.Date(2024, time.June, 8, 21, 0, 0, 0, time.UTC), expected: "night"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := ActiveConfigSchedule(otelcol, tt.time)
+			if tt.expected == "" {
+				assert.Nil(t, schedule)
+				return
+			}
+			require.NotNil(t, schedule)
+			assert.Equal(t, tt.expected, schedule.Name)
+		})
+	}
+}
+
+func TestNextConfigScheduleChange(t *testing.T) {
+	otelcol := scheduledCollector()
+
+	// the night window opens
+	next := NextConfigScheduleChange(otelcol, time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC), next)
+
+	// the night window closes
+	next = NextConfigScheduleChange(otelcol, time.Date(2024, time.June, 5, 21, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, time.June, 6, 8, 0, 0, 0, time.UTC), next)
+
+	otelcol.Spec.ConfigSchedules = nil
+	assert.True(t, NextConfigScheduleChange(otelcol, time.Now()).IsZero())
+}
+
+func TestConfigScheduleTimeZone(t *testing.T) {
+	otelcol := scheduledCollector()
+	otelcol.Spec.ConfigSchedules[0].TimeZone = "Asia/Tokyo"
+
+	// 20:00 in Tokyo is 11:00 UTC
+	schedule := ActiveConfigSchedule(otelcol, time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC))
+	require.NotNil(t, schedule)
+	assert.Equal(t, "night", schedule.Name)
+	assert.Nil(t, ActiveConfigSchedule(otelcol, time.Date(2024, time.June, 5, 10, 0, 0, 0, time.UTC)))
+
+	next := NextConfigScheduleChange(otelcol, time.Date(2024, time.June, 5, 10, 0, 0, 0, time.UTC))
+	assert.True(t, time.Date(2024, time.June, 5, 11, 0, 0, 0, time.UTC).Equal(next), next)
+
+	// the schedules with an unknown time zone are ignored
+	otelcol.Spec.ConfigSchedules[0].TimeZone = "Nowhere/Unknown"
+	assert.Nil(t, ActiveConfigSchedule(otelcol, time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestWithConfigSchedule(t *testing.T) {
+	otelcol := scheduledCollector()
+
+	scheduled, err := WithConfigSchedule(otelcol, time.Date(2024, time.June, 5, 21, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"sampling_percentage": float64(10)}, scheduled.Spec.Config.Processors.Object["probabilistic_sampler"])
+	assert.Equal(t, map[string]interface{}{"sampling_percentage": float64(100)}, otelcol.Spec.Config.Processors.Object["probabilistic_sampler"])
+
+	unscheduled, err := WithConfigSchedule(otelcol, time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, otelcol, unscheduled)
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// configVarReferenceRegexp matches the ${var:NAME} references of the configs, with the $ escaping them, if any.
+var configVarReferenceRegexp = regexp.MustCompile(`\$?\$\{var:([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+
+// ReferencesConfigVars returns true when the config of the collector references config variables.
+func ReferencesConfigVars(otelcol v1beta1.OpenTelemetryCollector) bool {
+	configYaml, err := otelcol.Spec.Config.Yaml()
+	if err != nil {
+		return false
+	}
+	for _, match := range configVarReferenceRegexp.FindAllString(configYaml, -1) {
+		if !strings.HasPrefix(match, "$$") {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigVars returns the variables of the OpenTelemetryConfigVars, indexed by name. A variable can only be defined
+// once.
+func ConfigVars(catalogs []v1alpha1.OpenTelemetryConfigVars) (map[string]string, error) {
+	// the catalogs are sorted to report the conflicts deterministically, without reordering the ones of the caller
+	catalogs = append([]v1alpha1.OpenTelemetryConfigVars(nil), catalogs...)
+	sort.Slice(catalogs, func(i, j int) bool {
+		return catalogs[i].Name < catalogs[j].Name
+	})
+	vars := map[string]string{}
+	definedBy := map[string]string{}
+	for _, catalog := range catalogs {
+		for name, value := range catalog.Spec.Vars {
+			if other, ok := definedBy[name]; ok {
+				return nil, fmt.Errorf("the config variable %s is defined by both the %s and %s OpenTelemetryConfigVars", name, other, catalog.Name)
+			}
+			vars[name] = value
+			definedBy[name] = catalog.Name
+		}
+	}
+	return vars, nil
+}
+
+// WithConfigVars returns the collector with the ${var:NAME} references of its config replaced by the values of the
+// variables. The references escaped as $${var:NAME} are left for the collector, which unescapes them.
+func WithConfigVars(otelcol v1beta1.OpenTelemetryCollector, vars map[string]string) (v1beta1.OpenTelemetryCollector, error) {
+	configJSON, err := json.Marshal(&otelcol.Spec.Config)
+	if err != nil {
+		return otelcol, err
+	}
+	var undefined []string
+	resolved := configVarReferenceRegexp.ReplaceAllStringFunc(string(configJSON), func(reference string) string {
+		if strings.HasPrefix(reference, "$$") {
+			return reference
+		}
+		name := configVarReferenceRegexp.FindStringSubmatch(reference)[1]
+		value, ok := vars[name]
+		if !ok {
+			if !slices.Contains(undefined, name) {
+				undefined = append(undefined, name)
+			}
+			return reference
+		}
+		// the references are inside JSON strings
+		quoted, _ := json.Marshal(value)
+		return string(quoted[1 : len(quoted)-1])
+	})
+	if len(undefined) > 0 {
+		return otelcol, fmt.Errorf("the config references undefined variables: %s", strings.Join(undefined, ", "))
+	}
+
+	config := v1beta1.Config{}
+	if err := json.Unmarshal([]byte(resolved), &config); err != nil {
+		return otelcol, err
+	}
+	resolvedOtelCol := *otelcol.DeepCopy()
+	resolvedOtelCol.Spec.Config = config
+	return resolvedOtelCol, nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func configVarsCollector(endpoint string) v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"otlp": map[string]interface{}{
+						"endpoint": endpoint,
+						"headers": map[string]interface{}{
+							"x-tenant":  "${var:TENANT_ID}",
+							"x-escaped": "$${var:TENANT_ID}",
+						},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestReferencesConfigVars(t *testing.T) {
+	assert.True(t, ReferencesConfigVars(configVarsCollector("tempo:4317")))
+
+	otelcol := configVarsCollector("tempo:4317")
+	delete(otelcol.Spec.Config.Exporters.Object["otlp"].(map[string]interface{})["headers"].(map[string]interface{}), "x-tenant")
+	assert.False(t, ReferencesConfigVars(otelcol))
+}
+
+func TestConfigVars(t *testing.T) {
+	catalogs := []v1alpha1.OpenTelemetryConfigVars{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenants"},
+			Spec:       v1alpha1.OpenTelemetryConfigVarsSpec{Vars: map[string]string{"TENANT_ID": "acme"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backends"},
+			Spec:       v1alpha1.OpenTelemetryConfigVarsSpec{Vars: map[string]string{"TRACES_BACKEND": "tempo:4317"}},
+		},
+	}
+
+	vars, err := ConfigVars(catalogs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"TENANT_ID": "acme", "TRACES_BACKEND": "tempo:4317"}, vars)
+
+	catalogs[1].Spec.Vars["TENANT_ID"] = "other"
+	_, err = ConfigVars(catalogs)
+	assert.EqualError(t, err, "the config variable TENANT_ID is defined by both the backends and tenants OpenTelemetryConfigVars")
+}
+
+func TestWithConfigVars(t *testing.T) {
+	otelcol := configVarsCollector("${var:TRACES_BACKEND}")
+
+	resolved, err := WithConfigVars(otelcol, map[string]string{"TENANT_ID": `acme "eu"`, "TRACES_BACKEND": "tempo:4317"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "tempo:4317",
+		"headers": map[string]interface{}{
+			"x-tenant":  `acme "eu"`,
+			"x-escaped": "$${var:TENANT_ID}",
+		},
+	}, resolved.Spec.Config.Exporters.Object["otlp"])
+	// the CR is left as is
+	assert.Equal(t, "${var:TRACES_BACKEND}", otelcol.Spec.Config.Exporters.Object["otlp"].(map[string]interface{})["endpoint"])
+}
+
+func TestWithUndefinedConfigVars(t *testing.T) {
+	_, err := WithConfigVars(configVarsCollector("${var:TRACES_BACKEND}"), map[string]string{})
+	assert.EqualError(t, err, "the config references undefined variables: TRACES_BACKEND, TENANT_ID")
+}
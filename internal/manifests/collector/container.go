@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/operator-framework/operator-lib/proxy"
@@ -32,12 +33,20 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
+// ConfigEnvVar is the environment variable holding the config of the collector, when it is not mounted as a file.
+const ConfigEnvVar = "OTEL_CONFIG"
+
 // maxPortLen allows us to truncate a port name according to what is considered valid port syntax:
 // https://pkg.go.dev/k8s.io/apimachinery/pkg/util/validation#IsValidPortName
 const maxPortLen = 15
 
 // Container builds a container for the given collector.
-func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, addConfig bool) corev1.Container {
+func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, addConfig bool) (corev1.Container, error) {
+	return container(cfg, logger, otelcol, addConfig, ContainerName(otelcol), cfg.CollectorConfigMapEntry())
+}
+
+// container builds a collector container with the given name, running the config of the given ConfigMap entry.
+func container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, addConfig bool, name, configEntry string) (corev1.Container, error) {
 	image := otelcol.Spec.Image
 	if len(image) == 0 {
 		image = cfg.CollectorImage()
@@ -45,12 +54,11 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 
 	configYaml, err := otelcol.Spec.Config.Yaml()
 	if err != nil {
-		logger.Error(err, "could not convert json to yaml")
-		return corev1.Container{}
+		return corev1.Container{}, fmt.Errorf("could not convert json to yaml: %w", err)
 	}
 
 	// build container ports from service ports
-	ports, err := getConfigContainerPorts(logger, configYaml, otelcol.Spec.Config)
+	ports, err := getConfigContainerPorts(logger, configYaml, otelcol.Spec.Config, portNaming(otelcol))
 	if err != nil {
 		logger.Error(err, "container ports config")
 	}
@@ -65,9 +73,13 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 	}
 
 	var volumeMounts []corev1.VolumeMount
-	argsMap := otelcol.Spec.Args
-	if argsMap == nil {
-		argsMap = map[string]string{}
+	// copy to avoid modifying otelcol.Spec.Args
+	argsMap := map[string]string{}
+	for k, v := range otelcol.Spec.Args {
+		argsMap[k] = v
+	}
+	if providers := otelcol.Spec.ConfigProviders; providers != nil && providers.ExpandConverter != nil && !*providers.ExpandConverter {
+		argsMap["feature-gates"] = withFeatureGate(argsMap["feature-gates"], v1beta1.UnifyEnvVarExpansionFeatureGate)
 	}
 	// defines the output (sorted) array for final output
 	var args []string
@@ -77,18 +89,46 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 	// are present they should be merged in a deterministic manner using the order given, and because
 	// v1alpha1.OpenTelemetryCollectorSpec.Config is a required field we assume that it will always be the
 	// "primary" config and in the future additional configs can be appended to the container args in a simple manner.
-	if addConfig {
+	var configEnvVar *corev1.EnvVar
+	if addConfig && otelcol.Spec.ConfigMode != v1beta1.ConfigModeStdin {
 		// if key exists then delete key and excluded from the iteration after this block
 		if _, exists := argsMap["config"]; exists {
 			logger.Info("the 'config' flag isn't allowed and is being ignored")
 			delete(argsMap, "config")
 		}
-		args = append(args, fmt.Sprintf("--config=/conf/%s", cfg.CollectorConfigMapEntry()))
-		volumeMounts = append(volumeMounts,
-			corev1.VolumeMount{
-				Name:      naming.ConfigMapVolume(),
-				MountPath: "/conf",
-			})
+	}
+	if addConfig {
+		switch otelcol.Spec.ConfigMode {
+		case v1beta1.ConfigModeEnv:
+			hash, err := GetCollectorConfigSHA(cfg, otelcol)
+			if err != nil {
+				return corev1.Container{}, err
+			}
+			args = append(args, fmt.Sprintf("--config=env:%s", ConfigEnvVar))
+			configEnvVar = &corev1.EnvVar{
+				Name: ConfigEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: ConfigMapName(otelcol, hash)},
+						Key:                  configEntry,
+					},
+				},
+			}
+		case v1beta1.ConfigModeStdin:
+			// the config is redirected to the standard input by the command, see below
+			volumeMounts = append(volumeMounts,
+				corev1.VolumeMount{
+					Name:      naming.ConfigMapVolume(),
+					MountPath: "/conf",
+				})
+		default:
+			args = append(args, fmt.Sprintf("--config=/conf/%s", configEntry))
+			volumeMounts = append(volumeMounts,
+				corev1.VolumeMount{
+					Name:      naming.ConfigMapVolume(),
+					MountPath: "/conf",
+				})
+		}
 	}
 
 	// ensure that the v1alpha1.OpenTelemetryCollectorSpec.Args are ordered when moved to container.Args,
@@ -110,6 +150,10 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		envVars = []corev1.EnvVar{}
 	}
 
+	if configEnvVar != nil {
+		envVars = append(envVars, *configEnvVar)
+	}
+
 	envVars = append(envVars, corev1.EnvVar{
 		Name: "POD_NAME",
 		ValueFrom: &corev1.EnvVarSource{
@@ -128,6 +172,16 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		}
 	}
 
+	if otelcol.Spec.JaegerRemoteSampling != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.JaegerRemoteSamplingVolume(),
+			MountPath: jaegerRemoteSamplingMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	volumeMounts = append(volumeMounts, credentialSecretVolumeMounts(logger, otelcol)...)
+
 	if otelcol.Spec.TargetAllocator.Enabled {
 		// We need to add a SHARD here so the collector is able to keep targets after the hashmod operation which is
 		// added by default by the Prometheus operator's config generator.
@@ -170,7 +224,7 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 			ValueFrom: &corev1.EnvVarSource{
 				ResourceFieldRef: &corev1.ResourceFieldSelector{
 					Resource:      "limits.memory",
-					ContainerName: naming.Container(),
+					ContainerName: name,
 				},
 			},
 		},
@@ -179,20 +233,31 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 				ValueFrom: &corev1.EnvVarSource{
 					ResourceFieldRef: &corev1.ResourceFieldSelector{
 						Resource:      "limits.cpu",
-						ContainerName: naming.Container(),
+						ContainerName: name,
 					},
 				},
 			},
 		)
 	}
 
+	var command []string
+	if len(otelcol.Spec.Command) > 0 {
+		command = otelcol.Spec.Command
+		if addConfig && otelcol.Spec.ConfigMode == v1beta1.ConfigModeStdin {
+			// the shell runs the command with the arguments, reading the config from the standard input
+			command = []string{"/bin/sh", "-c", fmt.Sprintf(`exec "$@" < /conf/%s`, configEntry), "sh"}
+			args = append(append([]string{}, otelcol.Spec.Command...), args...)
+		}
+	}
+
 	envVars = append(envVars, proxy.ReadProxyVarsFromEnv()...)
 	return corev1.Container{
-		Name:            naming.Container(),
+		Name:            name,
 		Image:           image,
 		ImagePullPolicy: otelcol.Spec.ImagePullPolicy,
 		Ports:           portMapToList(ports),
 		VolumeMounts:    volumeMounts,
+		Command:         command,
 		Args:            args,
 		Env:             envVars,
 		EnvFrom:         otelcol.Spec.EnvFrom,
@@ -201,17 +266,19 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		LivenessProbe:   livenessProbe,
 		ReadinessProbe:  readinessProbe,
 		Lifecycle:       otelcol.Spec.Lifecycle,
-	}
+		// the tail of the logs is reported in the status when the collector crashes
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}, nil
 }
 
-func getConfigContainerPorts(logger logr.Logger, cfgYaml string, conf v1beta1.Config) (map[string]corev1.ContainerPort, error) {
+func getConfigContainerPorts(logger logr.Logger, cfgYaml string, conf v1beta1.Config, portNaming adapters.PortNaming) (map[string]corev1.ContainerPort, error) {
 	ports := map[string]corev1.ContainerPort{}
 	c, err := adapters.ConfigFromString(cfgYaml)
 	if err != nil {
 		logger.Error(err, "couldn't extract the configuration")
 		return ports, err
 	}
-	ps, err := adapters.ConfigToPorts(logger, c)
+	ps, err := adapters.ConfigToPorts(logger, c, portNaming)
 	if err != nil {
 		return ports, err
 	}
@@ -287,3 +354,16 @@ func getProbe(config map[interface{}]interface{}, probeConfig *v1beta1.Probe) (*
 	}
 	return probe, nil
 }
+
+// withFeatureGate enables the gate in the comma-separated feature gates, unless they already set it.
+func withFeatureGate(featureGates, gate string) string {
+	for _, g := range strings.Split(featureGates, ",") {
+		if strings.TrimLeft(g, "+-") == gate {
+			return featureGates
+		}
+	}
+	if featureGates == "" {
+		return gate
+	}
+	return featureGates + "," + gate
+}
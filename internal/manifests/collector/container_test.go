@@ -23,6 +23,7 @@ import (
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
@@ -71,7 +72,8 @@ func TestContainerNewDefault(t *testing.T) {
 	cfg := config.New(config.WithCollectorImage("default-image"))
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Equal(t, "default-image", c.Image)
@@ -90,7 +92,8 @@ func TestContainerWithImageOverridden(t *testing.T) {
 	cfg := config.New(config.WithCollectorImage("default-image"))
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Equal(t, "overridden-image", c.Image)
@@ -395,7 +398,8 @@ service:
 			cfg := config.New(config.WithCollectorImage("default-image"))
 
 			// test
-			c := Container(cfg, logger, otelcol, true)
+			c, err := Container(cfg, logger, otelcol, true)
+			require.NoError(t, err)
 			// verify
 			assert.ElementsMatch(t, testCase.expectedPorts, c.Ports, testCase.description)
 		})
@@ -417,12 +421,76 @@ func TestContainerConfigFlagIsIgnored(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, c.Args, 2)
 	assert.Contains(t, c.Args, "--key=value")
 	assert.NotContains(t, c.Args, "--config=/some-custom-file.yaml")
+	// the spec is not modified
+	assert.Contains(t, otelcol.Spec.Args, "config")
+}
+
+func TestContainerConfigModeEnv(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			ConfigMode: v1beta1.ConfigModeEnv,
+		},
+	}
+	cfg := config.New()
+
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"--config=env:OTEL_CONFIG"}, c.Args)
+	assert.Empty(t, c.VolumeMounts)
+	require.NotEmpty(t, c.Env)
+	assert.Equal(t, "OTEL_CONFIG", c.Env[0].Name)
+	require.NotNil(t, c.Env[0].ValueFrom)
+	assert.Equal(t, "collector.yaml", c.Env[0].ValueFrom.ConfigMapKeyRef.Key)
+	assert.Contains(t, c.Env[0].ValueFrom.ConfigMapKeyRef.Name, "my-instance-collector-")
+}
+
+func TestContainerConfigModeStdin(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Args: map[string]string{
+					"config": "/dev/stdin",
+				},
+			},
+			ConfigMode: v1beta1.ConfigModeStdin,
+			Command:    []string{"/otelcol-custom", "run"},
+		},
+	}
+	cfg := config.New()
+
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/bin/sh", "-c", `exec "$@" < /conf/collector.yaml`, "sh"}, c.Command)
+	assert.Equal(t, []string{"/otelcol-custom", "run", "--config=/dev/stdin"}, c.Args)
+	require.Len(t, c.VolumeMounts, 1)
+	assert.Equal(t, "/conf", c.VolumeMounts[0].MountPath)
+}
+
+func TestContainerCommand(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Command: []string{"/otelcol-custom"},
+		},
+	}
+	cfg := config.New()
+
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/otelcol-custom"}, c.Command)
+	assert.Equal(t, []string{"--config=/conf/collector.yaml"}, c.Args)
 }
 
 func TestContainerCustomVolumes(t *testing.T) {
@@ -439,7 +507,8 @@ func TestContainerCustomVolumes(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, c.VolumeMounts, 2)
@@ -462,7 +531,8 @@ func TestContainerCustomConfigMapsVolumes(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, c.VolumeMounts, 3)
@@ -474,7 +544,8 @@ func TestContainerCustomConfigMapsVolumes(t *testing.T) {
 
 func TestContainerCustomSecurityContext(t *testing.T) {
 	// default config without security context
-	c1 := Container(config.New(), logger, v1beta1.OpenTelemetryCollector{Spec: v1beta1.OpenTelemetryCollectorSpec{}}, true)
+	c1, err := Container(config.New(), logger, v1beta1.OpenTelemetryCollector{Spec: v1beta1.OpenTelemetryCollectorSpec{}}, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Nil(t, c1.SecurityContext)
@@ -484,7 +555,7 @@ func TestContainerCustomSecurityContext(t *testing.T) {
 	uid := int64(1234)
 
 	// test
-	c2 := Container(config.New(), logger, v1beta1.OpenTelemetryCollector{
+	c2, err := Container(config.New(), logger, v1beta1.OpenTelemetryCollector{
 		Spec: v1beta1.OpenTelemetryCollectorSpec{
 			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
 
@@ -495,6 +566,7 @@ func TestContainerCustomSecurityContext(t *testing.T) {
 			},
 		},
 	}, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.NotNil(t, c2.SecurityContext)
@@ -519,7 +591,8 @@ func TestContainerEnvVarsOverridden(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, c.Env, 2)
@@ -535,7 +608,8 @@ func TestContainerDefaultEnvVars(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, c.Env, 1)
@@ -553,7 +627,8 @@ func TestContainerProxyEnvVars(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	require.Len(t, c.Env, 3)
@@ -583,7 +658,8 @@ func TestContainerResourceRequirements(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Equal(t, resource.MustParse("100m"), *c.Resources.Limits.Cpu())
@@ -600,7 +676,8 @@ func TestContainerDefaultResourceRequirements(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Empty(t, c.Resources)
@@ -621,7 +698,8 @@ func TestContainerArgs(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Contains(t, c.Args, "--metrics-level=detailed")
@@ -643,7 +721,8 @@ func TestContainerOrderedArgs(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify that the first args is (always) the config, and the remaining args are ordered alphabetically
 	// by the key
@@ -664,12 +743,55 @@ func TestContainerImagePullPolicy(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Equal(t, c.ImagePullPolicy, corev1.PullIfNotPresent)
 }
 
+func TestContainerExpandConverterDisabled(t *testing.T) {
+	disabled := false
+	for _, tt := range []struct {
+		name     string
+		args     map[string]string
+		expected string
+	}{
+		{
+			name:     "without feature gates",
+			expected: "--feature-gates=confmap.unifyEnvVarExpansion",
+		},
+		{
+			name:     "with other feature gates",
+			args:     map[string]string{"feature-gates": "-component.UseLocalHostAsDefaultHost"},
+			expected: "--feature-gates=-component.UseLocalHostAsDefaultHost,confmap.unifyEnvVarExpansion",
+		},
+		{
+			name:     "with the feature gate already enabled",
+			args:     map[string]string{"feature-gates": "+confmap.unifyEnvVarExpansion"},
+			expected: "--feature-gates=+confmap.unifyEnvVarExpansion",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Args: tt.args,
+					},
+					ConfigProviders: &v1beta1.ConfigProviders{ExpandConverter: &disabled},
+				},
+			}
+
+			c, err := Container(config.New(), logger, otelcol, true)
+			require.NoError(t, err)
+
+			assert.Contains(t, c.Args, tt.expected)
+			// the spec is not modified
+			assert.Equal(t, tt.args, otelcol.Spec.Args)
+		})
+	}
+}
+
 func TestContainerEnvFrom(t *testing.T) {
 	//prepare
 	envFrom1 := corev1.EnvFromSource{
@@ -699,7 +821,8 @@ func TestContainerEnvFrom(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Contains(t, c.EnvFrom, envFrom1)
@@ -741,7 +864,8 @@ service:
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	// liveness
@@ -785,7 +909,8 @@ service:
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	// liveness
@@ -811,7 +936,8 @@ service:
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	// verify
 	assert.Equal(t, "/", c.LivenessProbe.HTTPGet.Path)
@@ -838,7 +964,8 @@ func TestContainerLifecycle(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	c := Container(cfg, logger, otelcol, true)
+	c, err := Container(cfg, logger, otelcol, true)
+	require.NoError(t, err)
 
 	expectedLifecycleHooks := corev1.Lifecycle{
 		PostStart: &corev1.LifecycleHandler{
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"path"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser/receiver"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// credentialSecrets returns the sorted names of the Secrets the receivers of the collector read their credentials
+// from, with ${file:/etc/otelcol-secrets/<secret>/<key>} references.
+func credentialSecrets(logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector) []string {
+	configYaml, err := otelcol.Spec.Config.Yaml()
+	if err != nil {
+		return nil
+	}
+	configFromString, err := adapters.ConfigFromString(configYaml)
+	if err != nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var secrets []string
+	for _, credential := range adapters.ConfigToCredentials(logger, configFromString) {
+		if credential.Secret == "" || seen[credential.Secret] {
+			continue
+		}
+		seen[credential.Secret] = true
+		secrets = append(secrets, credential.Secret)
+	}
+	sort.Strings(secrets)
+	return secrets
+}
+
+// CredentialSecretVolumes returns the volumes of the Secrets holding the credentials of the receivers.
+func CredentialSecretVolumes(logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, secret := range credentialSecrets(logger, otelcol) {
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.CredentialsSecretVolume(secret),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secret},
+			},
+		})
+	}
+	return volumes
+}
+
+// credentialSecretVolumeMounts returns the read-only mounts of the Secrets holding the credentials of the receivers,
+// each in the directory named after it in receiver.CredentialsMountPath.
+func credentialSecretVolumeMounts(logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector) []corev1.VolumeMount {
+	var volumeMounts []corev1.VolumeMount
+	for _, secret := range credentialSecrets(logger, otelcol) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.CredentialsSecretVolume(secret),
+			MountPath: path.Join(receiver.CredentialsMountPath, secret),
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func credentialsCollector() v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"postgresql": map[string]interface{}{
+						"endpoint": "postgres:5432",
+						"username": "${file:/etc/otelcol-secrets/postgres-credentials/username}",
+						"password": "${file:/etc/otelcol-secrets/postgres-credentials/password}",
+					},
+					"sqlquery": map[string]interface{}{
+						"driver":     "postgres",
+						"datasource": "host=postgres user=otel password=${file:/etc/otelcol-secrets/orders-db/password}",
+					},
+					"redis": map[string]interface{}{
+						"endpoint": "redis:6379",
+						"password": "${file:/etc/otelcol-secrets/redis-credentials/password}",
+					},
+					"redis/file": map[string]interface{}{
+						"endpoint":      "redis:6379",
+						"password_file": "/etc/otelcol-secrets/redis-file/password",
+					},
+				}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"debug": map[string]interface{}{},
+				}},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"metrics": {
+							Receivers: []string{"postgresql", "sqlquery", "redis/file"},
+							Exporters: []string{"debug"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCredentialSecrets(t *testing.T) {
+	// the redis receiver isn't in a pipeline
+	assert.Equal(t, []string{"orders-db", "postgres-credentials", "redis-file"}, credentialSecrets(logger, credentialsCollector()))
+	assert.Empty(t, credentialSecrets(logger, v1beta1.OpenTelemetryCollector{}))
+}
+
+func TestCredentialSecretVolumes(t *testing.T) {
+	otelcol := credentialsCollector()
+
+	volumes, err := Volumes(config.New(), otelcol)
+	require.NoError(t, err)
+	require.Len(t, volumes, 4)
+	assert.Equal(t, "secret-orders-db", volumes[1].Name)
+	assert.Equal(t, "orders-db", volumes[1].Secret.SecretName)
+	assert.Equal(t, "secret-postgres-credentials", volumes[2].Name)
+	assert.Equal(t, "postgres-credentials", volumes[2].Secret.SecretName)
+
+	c, err := Container(config.New(), logger, otelcol, true)
+	require.NoError(t, err)
+	require.Len(t, c.VolumeMounts, 4)
+	assert.Equal(t, "secret-orders-db", c.VolumeMounts[1].Name)
+	assert.Equal(t, "/etc/otelcol-secrets/orders-db", c.VolumeMounts[1].MountPath)
+	assert.True(t, c.VolumeMounts[1].ReadOnly)
+	assert.Equal(t, "secret-postgres-credentials", c.VolumeMounts[2].Name)
+	assert.Equal(t, "/etc/otelcol-secrets/postgres-credentials", c.VolumeMounts[2].MountPath)
+}
@@ -21,27 +21,33 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
-	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 // DaemonSet builds the deployment for the given instance.
 func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
-	name := naming.Collector(params.OtelCol.Name)
+	name := WorkloadName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
 
-	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	hash, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
+	annotations := manifestutils.Annotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
 
-	podAnnotations, err := manifestutils.PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	podAnnotations := manifestutils.PodAnnotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
+	containers, err := Containers(params.Config, params.Log, params.OtelCol, true)
 	if err != nil {
 		return nil, err
 	}
+	volumes, err := Volumes(params.Config, params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+	addServiceMeshAnnotations(params, containers, podAnnotations)
 
 	return &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Collector(params.OtelCol.Name),
+			Name:        WorkloadName(params.OtelCol),
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
 			Annotations: annotations,
@@ -58,13 +64,15 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 				Spec: corev1.PodSpec{
 					ServiceAccountName:    ServiceAccountName(params.OtelCol),
 					InitContainers:        params.OtelCol.Spec.InitContainers,
-					Containers:            append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
-					Volumes:               Volumes(params.Config, params.OtelCol),
+					Containers:            append(params.OtelCol.Spec.AdditionalContainers, containers...),
+					Volumes:               volumes,
 					Tolerations:           params.OtelCol.Spec.Tolerations,
 					NodeSelector:          params.OtelCol.Spec.NodeSelector,
 					HostNetwork:           params.OtelCol.Spec.HostNetwork,
 					ShareProcessNamespace: &params.OtelCol.Spec.ShareProcessNamespace,
-					DNSPolicy:             manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork),
+					DNSPolicy:             manifestutils.GetDNSPolicyWithOverride(params.OtelCol.Spec.DNSPolicy, params.OtelCol.Spec.HostNetwork),
+					DNSConfig:             params.OtelCol.Spec.DNSConfig,
+					HostAliases:           params.OtelCol.Spec.HostAliases,
 					SecurityContext:       params.OtelCol.Spec.PodSecurityContext,
 					PriorityClassName:     params.OtelCol.Spec.PriorityClassName,
 					Affinity:              params.OtelCol.Spec.Affinity,
@@ -51,6 +51,8 @@ func TestDaemonSetNewDefault(t *testing.T) {
 	// test
 	d, err := DaemonSet(params)
 	require.NoError(t, err)
+	configSHA, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
+	require.NoError(t, err)
 
 	// verify
 	assert.Equal(t, "my-instance-collector", d.Name)
@@ -64,7 +66,7 @@ func TestDaemonSetNewDefault(t *testing.T) {
 
 	// verify sha256 podAnnotation
 	expectedAnnotations := map[string]string{
-		"opentelemetry-operator-config/sha256": "fbcdae6a02b2115cd5ca4f34298202ab041d1dfe62edebfaadb48b1ee178231d",
+		"opentelemetry-operator-config/sha256": configSHA,
 		"prometheus.io/path":                   "/metrics",
 		"prometheus.io/port":                   "8888",
 		"prometheus.io/scrape":                 "true",
@@ -135,6 +137,39 @@ func TestDaemonsetHostNetwork(t *testing.T) {
 	assert.Equal(t, d2.Spec.Template.Spec.DNSPolicy, v1.DNSClusterFirstWithHostNet)
 }
 
+func TestDaemonSetDNS(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-dns",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				HostNetwork: true,
+			},
+			DNSPolicy: v1.DNSNone,
+			DNSConfig: &v1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Searches:    []string{"corp.example.com"},
+			},
+			HostAliases: []v1.HostAlias{
+				{IP: "10.0.0.20", Hostnames: []string{"backend.corp.example.com"}},
+			},
+		},
+	}
+
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	d, err := DaemonSet(params)
+	require.NoError(t, err)
+	assert.Equal(t, v1.DNSNone, d.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, otelcol.Spec.DNSConfig, d.Spec.Template.Spec.DNSConfig)
+	assert.Equal(t, otelcol.Spec.HostAliases, d.Spec.Template.Spec.HostAliases)
+}
+
 func TestDaemonsetPodAnnotations(t *testing.T) {
 	// prepare
 	testPodAnnotationValues := map[string]string{"annotation-key": "annotation-value"}
@@ -159,13 +194,15 @@ func TestDaemonsetPodAnnotations(t *testing.T) {
 	// test
 	ds, err := DaemonSet(params)
 	require.NoError(t, err)
+	configSHA, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
+	require.NoError(t, err)
 
 	// Add sha256 podAnnotation
-	testPodAnnotationValues["opentelemetry-operator-config/sha256"] = "fbcdae6a02b2115cd5ca4f34298202ab041d1dfe62edebfaadb48b1ee178231d"
+	testPodAnnotationValues["opentelemetry-operator-config/sha256"] = configSHA
 
 	expectedAnnotations := map[string]string{
 		"annotation-key":                       "annotation-value",
-		"opentelemetry-operator-config/sha256": "fbcdae6a02b2115cd5ca4f34298202ab041d1dfe62edebfaadb48b1ee178231d",
+		"opentelemetry-operator-config/sha256": configSHA,
 		"prometheus.io/path":                   "/metrics",
 		"prometheus.io/port":                   "8888",
 		"prometheus.io/scrape":                 "true",
@@ -21,22 +21,28 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
-	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 // Deployment builds the deployment for the given instance.
 func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
-	name := naming.Collector(params.OtelCol.Name)
+	name := WorkloadName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
-	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	hash, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
+	annotations := manifestutils.Annotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
 
-	podAnnotations, err := manifestutils.PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	podAnnotations := manifestutils.PodAnnotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
+	containers, err := Containers(params.Config, params.Log, params.OtelCol, true)
 	if err != nil {
 		return nil, err
 	}
+	volumes, err := Volumes(params.Config, params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+	addServiceMeshAnnotations(params, containers, podAnnotations)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -59,9 +65,11 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            ServiceAccountName(params.OtelCol),
 					InitContainers:                params.OtelCol.Spec.InitContainers,
-					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
-					Volumes:                       Volumes(params.Config, params.OtelCol),
-					DNSPolicy:                     manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork),
+					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, containers...),
+					Volumes:                       volumes,
+					DNSPolicy:                     manifestutils.GetDNSPolicyWithOverride(params.OtelCol.Spec.DNSPolicy, params.OtelCol.Spec.HostNetwork),
+					DNSConfig:                     params.OtelCol.Spec.DNSConfig,
+					HostAliases:                   params.OtelCol.Spec.HostAliases,
 					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
 					ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
 					Tolerations:                   params.OtelCol.Spec.Tolerations,
@@ -70,7 +78,7 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
 					Affinity:                      params.OtelCol.Spec.Affinity,
 					TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
-					TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
+					TopologySpreadConstraints:     zoneTopologySpreadConstraints(params.OtelCol),
 				},
 			},
 		},
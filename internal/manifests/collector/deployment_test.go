@@ -25,9 +25,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 )
 
 var testTolerationValues = []v1.Toleration{
@@ -93,6 +95,8 @@ func TestDeploymentNewDefault(t *testing.T) {
 	// test
 	d, err := Deployment(params)
 	require.NoError(t, err)
+	configSHA, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
+	require.NoError(t, err)
 
 	// verify
 	assert.Equal(t, "my-instance-collector", d.Name)
@@ -106,7 +110,7 @@ func TestDeploymentNewDefault(t *testing.T) {
 
 	// verify sha256 podAnnotation
 	expectedAnnotations := map[string]string{
-		"opentelemetry-operator-config/sha256": "fbcdae6a02b2115cd5ca4f34298202ab041d1dfe62edebfaadb48b1ee178231d",
+		"opentelemetry-operator-config/sha256": configSHA,
 		"prometheus.io/path":                   "/metrics",
 		"prometheus.io/port":                   "8888",
 		"prometheus.io/scrape":                 "true",
@@ -161,13 +165,15 @@ func TestDeploymentPodAnnotations(t *testing.T) {
 	// test
 	d, err := Deployment(params)
 	require.NoError(t, err)
+	configSHA, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
+	require.NoError(t, err)
 
 	// Add sha256 podAnnotation
-	testPodAnnotationValues["opentelemetry-operator-config/sha256"] = "fbcdae6a02b2115cd5ca4f34298202ab041d1dfe62edebfaadb48b1ee178231d"
+	testPodAnnotationValues["opentelemetry-operator-config/sha256"] = configSHA
 
 	expectedPodAnnotationValues := map[string]string{
 		"annotation-key":                       "annotation-value",
-		"opentelemetry-operator-config/sha256": "fbcdae6a02b2115cd5ca4f34298202ab041d1dfe62edebfaadb48b1ee178231d",
+		"opentelemetry-operator-config/sha256": configSHA,
 		"prometheus.io/path":                   "/metrics",
 		"prometheus.io/port":                   "8888",
 		"prometheus.io/scrape":                 "true",
@@ -295,6 +301,39 @@ func TestDeploymentHostNetwork(t *testing.T) {
 	assert.Equal(t, d2.Spec.Template.Spec.DNSPolicy, v1.DNSClusterFirstWithHostNet)
 }
 
+func TestDeploymentDNS(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance-dns",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				HostNetwork: true,
+			},
+			DNSPolicy: v1.DNSNone,
+			DNSConfig: &v1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Searches:    []string{"corp.example.com"},
+			},
+			HostAliases: []v1.HostAlias{
+				{IP: "10.0.0.20", Hostnames: []string{"backend.corp.example.com"}},
+			},
+		},
+	}
+
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, v1.DNSNone, d.Spec.Template.Spec.DNSPolicy)
+	assert.Equal(t, otelcol.Spec.DNSConfig, d.Spec.Template.Spec.DNSConfig)
+	assert.Equal(t, otelcol.Spec.HostAliases, d.Spec.Template.Spec.HostAliases)
+}
+
 func TestDeploymentFilterLabels(t *testing.T) {
 	excludedLabels := map[string]string{
 		"foo":         "1",
@@ -357,6 +396,55 @@ func TestDeploymentFilterAnnotations(t *testing.T) {
 	}
 }
 
+func TestDeploymentFiltersRollbackAnnotations(t *testing.T) {
+	rollbackAnnotations := map[string]string{
+		upgrade.UpgradeStartedAnnotation:  "2024-01-01T00:00:00Z",
+		upgrade.RollbackVersionAnnotation: "0.1.0",
+	}
+
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-instance",
+			Annotations: rollbackAnnotations,
+		},
+	}
+
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+
+	for k := range rollbackAnnotations {
+		assert.NotContains(t, d.ObjectMeta.Annotations, k)
+		assert.NotContains(t, d.Spec.Template.Annotations, k)
+	}
+}
+
+func TestDeploymentFiltersDefaultingAnnotation(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-instance",
+			Annotations: map[string]string{auditlog.DefaultingAnnotation: `{"source":"defaulting","patch":"{}"}`},
+		},
+	}
+
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+
+	assert.NotContains(t, d.ObjectMeta.Annotations, auditlog.DefaultingAnnotation)
+	assert.NotContains(t, d.Spec.Template.Annotations, auditlog.DefaultingAnnotation)
+}
+
 func TestDeploymentNodeSelector(t *testing.T) {
 	// Test default
 	otelcol1 := v1beta1.OpenTelemetryCollector{
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	ComponentExporterCheck = "opentelemetry-collector-exporter-check"
+
+	defaultExporterCheckTimeout = 5 * time.Second
+
+	// exporterCheckUser is the unprivileged user running the check, the nobody user of the busybox image.
+	exporterCheckUser = int64(65534)
+
+	// exporterCheckScript connects to each host and port pair of its arguments, and writes the unreachable ones to
+	// the termination log, so that they can be reported in the status of the collector.
+	exporterCheckScript = `failed=""
+while [ "$#" -gt 1 ]; do
+  if ! nc -w "$TIMEOUT" "$1" "$2" </dev/null >/dev/null 2>&1; then
+    failed="$failed $1:$2"
+  fi
+  shift 2
+done
+if [ -n "$failed" ]; then
+  echo "unreachable:$failed" | tee /dev/termination-log
+  exit 1
+fi`
+)
+
+// ExporterCheckJob builds the job checking that the exporter endpoints of the current config are reachable. The check
+// only reports the unreachable endpoints in the status, it doesn't block the rollout of the config.
+func ExporterCheckJob(params manifests.Params) (*batchv1.Job, error) {
+	check := params.OtelCol.Spec.ExporterCheck
+	if check == nil || !check.Enabled || params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		return nil, nil
+	}
+
+	endpoints := exporterEndpoints(params.OtelCol.Spec.Config)
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+
+	name, err := ExporterCheckJobName(params.Config, params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+	timeout := exporterCheckTimeout(check)
+
+	// the job is labelled as a collector object so that it's cleaned up with the other owned objects, while its pods
+	// aren't, so that they aren't selected by the collector services
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	podLabels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.Config.ExporterCheckImage(), ComponentExporterCheck, params.Config.LabelsFilter())
+
+	args := []string{"-c", exporterCheckScript, "exporter-check"}
+	for _, endpoint := range endpoints {
+		host, port, _ := net.SplitHostPort(endpoint)
+		args = append(args, host, port)
+	}
+
+	backoffLimit := int32(1)
+	runAsNonRoot := true
+	runAsUser := exporterCheckUser
+	allowPrivilegeEscalation := false
+	readOnlyRootFilesystem := true
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: params.OtelCol.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: ServiceAccountName(params.OtelCol),
+					NodeSelector:       params.OtelCol.Spec.NodeSelector,
+					Tolerations:        params.OtelCol.Spec.Tolerations,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: &runAsNonRoot,
+						RunAsUser:    &runAsUser,
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{{
+						Name:    "exporter-check",
+						Image:   params.Config.ExporterCheckImage(),
+						Command: []string{"sh"},
+						Args:    args,
+						Env: []corev1.EnvVar{{
+							Name:  "TIMEOUT",
+							Value: fmt.Sprintf("%d", int(timeout.Seconds())),
+						}},
+						TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+							ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"ALL"},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+// ExporterCheckJobName returns the name of the exporter check job of the collector. The spec of a job is not updated
+// once it is created, so the job is named after the hash of the config rendered for the collector and of the timeout
+// of the check, so that changing either runs a new check.
+func ExporterCheckJobName(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) (string, error) {
+	configHash, err := GetCollectorConfigSHA(cfg, otelcol)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", configHash, exporterCheckTimeout(otelcol.Spec.ExporterCheck))))
+	return naming.ExporterCheckJob(otelcol.Name, fmt.Sprintf("%x", h)), nil
+}
+
+// exporterCheckTimeout returns the maximum time to connect to an endpoint.
+func exporterCheckTimeout(check *v1beta1.ExporterCheck) time.Duration {
+	if check != nil && check.Timeout != nil {
+		return check.Timeout.Duration
+	}
+	return defaultExporterCheckTimeout
+}
+
+// exporterEndpoints returns the sorted host:port endpoints of the exporters used in the pipelines of the config.
+// Endpoints relying on environment variables can't be resolved by the operator and are skipped.
+func exporterEndpoints(config v1beta1.Config) []string {
+	seen := map[string]struct{}{}
+	for exporter := range config.GetEnabledComponents()[v1beta1.ComponentTypeExporter] {
+		exporterConfig, ok := config.Exporters.Object[exporter].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		endpoint, ok := exporterConfig["endpoint"].(string)
+		if !ok || endpoint == "" || strings.Contains(endpoint, "${") {
+			continue
+		}
+		if hostPort := endpointHostPort(endpoint); hostPort != "" {
+			seen[hostPort] = struct{}{}
+		}
+	}
+
+	endpoints := make([]string, 0, len(seen))
+	for endpoint := range seen {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// endpointHostPort parses endpoints either in the host:port or in the URL form.
+func endpointHostPort(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Hostname() == "" {
+			return ""
+		}
+		port := u.Port()
+		if port == "" {
+			switch u.Scheme {
+			case "http":
+				port = "80"
+			case "https":
+				port = "443"
+			default:
+				return ""
+			}
+		}
+		return net.JoinHostPort(u.Hostname(), port)
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil || host == "" || port == "" {
+		return ""
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestExporterCheckJob(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Exporters: v1beta1.AnyConfig{
+					Object: map[string]interface{}{
+						"otlp": map[string]interface{}{
+							"endpoint": "tempo.observability:4317",
+						},
+					},
+				},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"traces": {Exporters: []string{"otlp"}},
+					},
+				},
+			},
+		},
+	}
+	cfg := config.New(config.WithExporterCheckImage("busybox:test"))
+
+	t.Run("should not build the job when the check is disabled", func(t *testing.T) {
+		job, err := ExporterCheckJob(manifests.Params{OtelCol: otelcol, Config: cfg})
+		require.NoError(t, err)
+		assert.Nil(t, job)
+	})
+
+	t.Run("should not build the job in sidecar mode", func(t *testing.T) {
+		sidecar := *otelcol.DeepCopy()
+		sidecar.Spec.Mode = v1beta1.ModeSidecar
+		sidecar.Spec.ExporterCheck = &v1beta1.ExporterCheck{Enabled: true}
+		job, err := ExporterCheckJob(manifests.Params{OtelCol: sidecar, Config: cfg})
+		require.NoError(t, err)
+		assert.Nil(t, job)
+	})
+
+	t.Run("should check the exporter endpoints", func(t *testing.T) {
+		enabled := *otelcol.DeepCopy()
+		enabled.Spec.ExporterCheck = &v1beta1.ExporterCheck{Enabled: true, Timeout: &metav1.Duration{Duration: 2 * time.Second}}
+		job, err := ExporterCheckJob(manifests.Params{OtelCol: enabled, Config: cfg})
+		require.NoError(t, err)
+		require.NotNil(t, job)
+
+		assert.Regexp(t, "^test-exporter-check-[0-9a-f]{8}$", job.Name)
+		assert.Equal(t, ComponentOpenTelemetryCollector, job.Labels["app.kubernetes.io/component"])
+		assert.Equal(t, ComponentExporterCheck, job.Spec.Template.Labels["app.kubernetes.io/component"])
+		assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+		require.Len(t, job.Spec.Template.Spec.Containers, 1)
+		container := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "busybox:test", container.Image)
+		assert.Equal(t, []string{"tempo.observability", "4317"}, container.Args[3:])
+		assert.Equal(t, []corev1.EnvVar{{Name: "TIMEOUT", Value: "2"}}, container.Env)
+		require.NotNil(t, job.Spec.Template.Spec.SecurityContext)
+		assert.True(t, *job.Spec.Template.Spec.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, job.Spec.Template.Spec.SecurityContext.SeccompProfile.Type)
+		require.NotNil(t, container.SecurityContext)
+		assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation)
+		assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+
+		// the spec of a job is not updated, a new job checks the endpoints with another timeout
+		enabled.Spec.ExporterCheck.Timeout = &metav1.Duration{Duration: 10 * time.Second}
+		other, err := ExporterCheckJob(manifests.Params{OtelCol: enabled, Config: cfg})
+		require.NoError(t, err)
+		require.NotNil(t, other)
+		assert.NotEqual(t, job.Name, other.Name)
+	})
+}
+
+func TestExporterEndpoints(t *testing.T) {
+	config := v1beta1.Config{
+		Exporters: v1beta1.AnyConfig{
+			Object: map[string]interface{}{
+				"otlp":           map[string]interface{}{"endpoint": "collector:4317"},
+				"otlp/duplicate": map[string]interface{}{"endpoint": "collector:4317"},
+				"otlphttp":       map[string]interface{}{"endpoint": "https://otlp.example.com/v1"},
+				"otlphttp/port":  map[string]interface{}{"endpoint": "http://backend:4318"},
+				"otlp/env":       map[string]interface{}{"endpoint": "${env:OTLP_ENDPOINT}"},
+				"debug":          map[string]interface{}{},
+				"otlp/unused":    map[string]interface{}{"endpoint": "unused:4317"},
+			},
+		},
+		Service: v1beta1.Service{
+			Pipelines: map[string]*v1beta1.Pipeline{
+				"traces":  {Exporters: []string{"otlp", "otlp/duplicate", "otlp/env", "debug"}},
+				"metrics": {Exporters: []string{"otlphttp", "otlphttp/port"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"backend:4318", "collector:4317", "otlp.example.com:443"}, exporterEndpoints(config))
+}
@@ -26,17 +26,18 @@ import (
 )
 
 func HorizontalPodAutoscaler(params manifests.Params) (*autoscalingv2.HorizontalPodAutoscaler, error) {
-	name := naming.Collector(params.OtelCol.Name)
+	name := WorkloadName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
-	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	hash, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
+	annotations := manifestutils.Annotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
 
 	var result *autoscalingv2.HorizontalPodAutoscaler
 
 	objectMeta := metav1.ObjectMeta{
-		Name:        naming.HorizontalPodAutoscaler(params.OtelCol.Name),
+		Name:        WorkloadName(params.OtelCol),
 		Namespace:   params.OtelCol.Namespace,
 		Labels:      labels,
 		Annotations: annotations,
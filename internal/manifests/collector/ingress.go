@@ -51,9 +51,9 @@ func Ingress(params manifests.Params) (*networkingv1.Ingress, error) {
 	var rules []networkingv1.IngressRule
 	switch params.OtelCol.Spec.Ingress.RuleType {
 	case v1beta1.IngressRuleTypePath, "":
-		rules = []networkingv1.IngressRule{createPathIngressRules(params.OtelCol.Name, params.OtelCol.Spec.Ingress.Hostname, ports)}
+		rules = []networkingv1.IngressRule{createPathIngressRules(ServiceName(params.OtelCol), params.OtelCol.Spec.Ingress.Hostname, ports)}
 	case v1beta1.IngressRuleTypeSubdomain:
-		rules = createSubdomainIngressRules(params.OtelCol.Name, params.OtelCol.Spec.Ingress.Hostname, ports)
+		rules = createSubdomainIngressRules(ServiceName(params.OtelCol), params.OtelCol.Spec.Ingress.Hostname, ports)
 	}
 
 	return &networkingv1.Ingress{
@@ -71,7 +71,7 @@ func Ingress(params manifests.Params) (*networkingv1.Ingress, error) {
 	}, nil
 }
 
-func createPathIngressRules(otelcol string, hostname string, ports []corev1.ServicePort) networkingv1.IngressRule {
+func createPathIngressRules(service string, hostname string, ports []corev1.ServicePort) networkingv1.IngressRule {
 	pathType := networkingv1.PathTypePrefix
 	paths := make([]networkingv1.HTTPIngressPath, len(ports))
 	for i, port := range ports {
@@ -81,7 +81,7 @@ func createPathIngressRules(otelcol string, hostname string, ports []corev1.Serv
 			PathType: &pathType,
 			Backend: networkingv1.IngressBackend{
 				Service: &networkingv1.IngressServiceBackend{
-					Name: naming.Service(otelcol),
+					Name: service,
 					Port: networkingv1.ServiceBackendPort{
 						Name: portName,
 					},
@@ -99,7 +99,7 @@ func createPathIngressRules(otelcol string, hostname string, ports []corev1.Serv
 	}
 }
 
-func createSubdomainIngressRules(otelcol string, hostname string, ports []corev1.ServicePort) []networkingv1.IngressRule {
+func createSubdomainIngressRules(service string, hostname string, ports []corev1.ServicePort) []networkingv1.IngressRule {
 	var rules []networkingv1.IngressRule
 	pathType := networkingv1.PathTypePrefix
 	for _, port := range ports {
@@ -120,7 +120,7 @@ func createSubdomainIngressRules(otelcol string, hostname string, ports []corev1
 							PathType: &pathType,
 							Backend: networkingv1.IngressBackend{
 								Service: &networkingv1.IngressServiceBackend{
-									Name: naming.Service(otelcol),
+									Name: service,
 									Port: networkingv1.ServiceBackendPort{
 										Name: portName,
 									},
@@ -146,7 +146,7 @@ func servicePortsFromCfg(logger logr.Logger, otelcol v1beta1.OpenTelemetryCollec
 		return nil, err
 	}
 
-	ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, configFromString)
+	ports, err := adapters.ConfigToComponentPorts(logger, adapters.ComponentTypeReceiver, configFromString, portNaming(otelcol))
 	if err != nil {
 		logger.Error(err, "couldn't build the ingress for this instance")
 		return nil, err
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// JaegerRemoteSamplingStrategiesFile is the entry of the sampling strategies ConfigMap holding the strategies file.
+	JaegerRemoteSamplingStrategiesFile = "strategies.json"
+
+	jaegerRemoteSamplingMountPath             = "/etc/otelcol-sampling"
+	defaultJaegerRemoteSamplingReloadInterval = 30 * time.Second
+)
+
+// JaegerRemoteSamplingConfigMap builds the ConfigMap holding the strategies file generated from the sampling
+// strategies of the collector, when they aren't read from an existing ConfigMap.
+func JaegerRemoteSamplingConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
+	sampling := params.OtelCol.Spec.JaegerRemoteSampling
+	if sampling == nil || sampling.Strategies == nil {
+		return nil, nil
+	}
+	strategies, err := json.MarshalIndent(sampling.Strategies, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	collectorName := WorkloadName(params.OtelCol)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, collectorName, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        JaegerRemoteSamplingConfigMapName(params.OtelCol),
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: params.OtelCol.Annotations,
+		},
+		Data: map[string]string{
+			JaegerRemoteSamplingStrategiesFile: string(strategies),
+		},
+	}, nil
+}
+
+// jaegerRemoteSamplingConfigMapName returns the name of the ConfigMap holding the strategies file of the collector.
+func jaegerRemoteSamplingConfigMapName(otelcol v1beta1.OpenTelemetryCollector) string {
+	if otelcol.Spec.JaegerRemoteSampling.ConfigMap != "" {
+		return otelcol.Spec.JaegerRemoteSampling.ConfigMap
+	}
+	return JaegerRemoteSamplingConfigMapName(otelcol)
+}
+
+// setJaegerRemoteSamplingSource makes the jaegerremotesampling extensions without a source serve the mounted
+// strategies file. The extensions reload the file, which is updated along with the ConfigMap, at the reload interval.
+func setJaegerRemoteSamplingSource(config map[interface{}]interface{}, sampling *v1beta1.JaegerRemoteSampling) {
+	extensions, ok := config["extensions"].(map[interface{}]interface{})
+	if !ok {
+		return
+	}
+	reloadInterval := defaultJaegerRemoteSamplingReloadInterval
+	if sampling.ReloadInterval != nil {
+		reloadInterval = sampling.ReloadInterval.Duration
+	}
+	for key, extension := range extensions {
+		name, ok := key.(string)
+		if !ok || (name != "jaegerremotesampling" && !strings.HasPrefix(name, "jaegerremotesampling/")) {
+			continue
+		}
+		extensionCfg, ok := extension.(map[interface{}]interface{})
+		if !ok {
+			if extension != nil {
+				continue
+			}
+			extensionCfg = map[interface{}]interface{}{}
+			extensions[key] = extensionCfg
+		}
+		if _, ok := extensionCfg["source"]; ok {
+			continue
+		}
+		extensionCfg["source"] = map[interface{}]interface{}{
+			"file":            path.Join(jaegerRemoteSamplingMountPath, JaegerRemoteSamplingStrategiesFile),
+			"reload_interval": reloadInterval.String(),
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
+)
+
+func TestJaegerRemoteSamplingConfigMap(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	t.Run("should not build the config map without strategies", func(t *testing.T) {
+		otelcol.Spec.JaegerRemoteSampling = &v1beta1.JaegerRemoteSampling{ConfigMap: "sampling"}
+		cm, err := JaegerRemoteSamplingConfigMap(manifests.Params{OtelCol: otelcol})
+		require.NoError(t, err)
+		assert.Nil(t, cm)
+	})
+
+	t.Run("should generate the strategies file", func(t *testing.T) {
+		otelcol.Spec.JaegerRemoteSampling = &v1beta1.JaegerRemoteSampling{
+			Strategies: &v1beta1.AnyConfig{
+				Object: map[string]interface{}{
+					"default_strategy": map[string]interface{}{
+						"type":  "probabilistic",
+						"param": 0.5,
+					},
+					"service_strategies": []interface{}{
+						map[string]interface{}{
+							"service": "frontend",
+							"type":    "ratelimiting",
+							"param":   5,
+						},
+					},
+				},
+			},
+		}
+		cm, err := JaegerRemoteSamplingConfigMap(manifests.Params{OtelCol: otelcol})
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-collector-sampling", cm.Name)
+		assert.Equal(t, "default", cm.Namespace)
+		assert.Equal(t, "opentelemetry-collector", cm.Labels["app.kubernetes.io/component"])
+		assert.JSONEq(t, `{
+			"default_strategy": {"type": "probabilistic", "param": 0.5},
+			"service_strategies": [{"service": "frontend", "type": "ratelimiting", "param": 5}]
+		}`, cm.Data[JaegerRemoteSamplingStrategiesFile])
+	})
+}
+
+func TestJaegerRemoteSamplingVolume(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			JaegerRemoteSampling: &v1beta1.JaegerRemoteSampling{ConfigMap: "sampling"},
+		},
+	}
+
+	volumes, err := Volumes(config.New(), otelcol)
+	require.NoError(t, err)
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "otc-sampling", volumes[1].Name)
+	assert.Equal(t, "sampling", volumes[1].ConfigMap.Name)
+
+	otelcol.Spec.JaegerRemoteSampling = &v1beta1.JaegerRemoteSampling{Strategies: &v1beta1.AnyConfig{}}
+	volumes, err = Volumes(config.New(), otelcol)
+	require.NoError(t, err)
+	require.Len(t, volumes, 2)
+	assert.Equal(t, "test-collector-sampling", volumes[1].ConfigMap.Name)
+
+	c, err := Container(config.New(), logger, otelcol, true)
+	require.NoError(t, err)
+	require.Len(t, c.VolumeMounts, 2)
+	assert.Equal(t, "otc-sampling", c.VolumeMounts[1].Name)
+	assert.Equal(t, "/etc/otelcol-sampling", c.VolumeMounts[1].MountPath)
+	assert.True(t, c.VolumeMounts[1].ReadOnly)
+}
+
+func TestReplaceConfigJaegerRemoteSampling(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Extensions: &v1beta1.AnyConfig{
+					Object: map[string]interface{}{
+						"jaegerremotesampling": map[string]interface{}{
+							"grpc": map[string]interface{}{
+								"endpoint": "0.0.0.0:14250",
+							},
+						},
+						"jaegerremotesampling/remote": map[string]interface{}{
+							"source": map[string]interface{}{
+								"remote": map[string]interface{}{
+									"endpoint": "jaeger-collector:14250",
+								},
+							},
+						},
+						"health_check": nil,
+					},
+				},
+			},
+			JaegerRemoteSampling: &v1beta1.JaegerRemoteSampling{
+				ConfigMap:      "sampling",
+				ReloadInterval: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+
+	actualConfig, err := ReplaceConfig(config.New(), otelcol, nil)
+	require.NoError(t, err)
+
+	cfg, err := adapters.ConfigFromString(actualConfig)
+	require.NoError(t, err)
+	extensions := cfg["extensions"].(map[interface{}]interface{})
+
+	// the source is set on the extensions without one
+	expectedSource := map[interface{}]interface{}{
+		"file":            "/etc/otelcol-sampling/strategies.json",
+		"reload_interval": "1m0s",
+	}
+	assert.Equal(t, expectedSource, extensions["jaegerremotesampling"].(map[interface{}]interface{})["source"])
+	remoteSource := extensions["jaegerremotesampling/remote"].(map[interface{}]interface{})["source"].(map[interface{}]interface{})
+	assert.NotContains(t, remoteSource, "file")
+	assert.Nil(t, extensions["health_check"])
+}
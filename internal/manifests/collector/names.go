@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// The names of the resources generated for a collector are derived from the name pinned by its
+// v1beta1.ResourceNameAnnotation when it is set, and from the default <name>-collector otherwise. The collectors
+// without the annotation keep exactly the names of the previous versions of the operator.
+
+// WorkloadName returns the name of the Deployment, DaemonSet or StatefulSet of the collector, which is also the name
+// of its HorizontalPodAutoscaler and PodDisruptionBudget.
+func WorkloadName(otelcol v1beta1.OpenTelemetryCollector) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s", 63, pinned))
+	}
+	return naming.Collector(otelcol.Name)
+}
+
+// ServiceName returns the name of the Service of the collector.
+func ServiceName(otelcol v1beta1.OpenTelemetryCollector) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s", 63, pinned))
+	}
+	return naming.Service(otelcol.Name)
+}
+
+// HeadlessServiceName returns the name of the headless Service of the collector.
+func HeadlessServiceName(otelcol v1beta1.OpenTelemetryCollector) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-headless", 63, pinned))
+	}
+	return naming.HeadlessService(otelcol.Name)
+}
+
+// MonitoringServiceName returns the name of the Service exposing the metrics of the collector.
+func MonitoringServiceName(otelcol v1beta1.OpenTelemetryCollector) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-monitoring", 63, pinned))
+	}
+	return naming.MonitoringService(otelcol.Name)
+}
+
+// PortGroupServiceName returns the name of the Service of the given port group of the collector.
+func PortGroupServiceName(otelcol v1beta1.OpenTelemetryCollector, portGroup string) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-%s", 63, pinned, portGroup))
+	}
+	return naming.PortGroupService(otelcol.Name, portGroup)
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the version of the config of the collector with the given
+// hash.
+func ConfigMapName(otelcol v1beta1.OpenTelemetryCollector, configHash string) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-%s", 63, pinned, configHash[:8]))
+	}
+	return naming.ConfigMap(otelcol.Name, configHash)
+}
+
+// JaegerRemoteSamplingConfigMapName returns the name of the ConfigMap holding the sampling strategies generated for
+// the collector.
+func JaegerRemoteSamplingConfigMapName(otelcol v1beta1.OpenTelemetryCollector) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-sampling", 63, pinned))
+	}
+	return naming.JaegerRemoteSamplingConfigMap(otelcol.Name)
+}
+
+// ZoneWorkloadName returns the name of the Deployment of the collector running in the given zone.
+func ZoneWorkloadName(otelcol v1beta1.OpenTelemetryCollector, zone string) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-%s", 63, pinned, zone))
+	}
+	return naming.CollectorZone(otelcol.Name, zone)
+}
+
+// ColorWorkloadName returns the name of the Deployment of the collector with the given blue/green color.
+func ColorWorkloadName(otelcol v1beta1.OpenTelemetryCollector, color string) string {
+	if pinned := otelcol.Annotations[v1beta1.ResourceNameAnnotation]; pinned != "" {
+		return naming.DNSName(naming.Truncate("%s-%s", 63, pinned, color))
+	}
+	return naming.CollectorColor(otelcol.Name, color)
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestResourceNames(t *testing.T) {
+	const hash = "0123abcd4567ef89"
+	for _, tt := range []struct {
+		desc        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			desc: "default names",
+			expected: []string{
+				"gateway-collector",
+				"gateway-collector",
+				"gateway-collector-headless",
+				"gateway-collector-monitoring",
+				"gateway-collector-grpc",
+				"gateway-collector-0123abcd",
+				"gateway-collector-sampling",
+				"gateway-collector-zone-a",
+				"gateway-collector-blue",
+			},
+		},
+		{
+			desc:        "pinned names",
+			annotations: map[string]string{v1beta1.ResourceNameAnnotation: "otel-observability-gateway"},
+			expected: []string{
+				"otel-observability-gateway",
+				"otel-observability-gateway",
+				"otel-observability-gateway-headless",
+				"otel-observability-gateway-monitoring",
+				"otel-observability-gateway-grpc",
+				"otel-observability-gateway-0123abcd",
+				"otel-observability-gateway-sampling",
+				"otel-observability-gateway-zone-a",
+				"otel-observability-gateway-blue",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "gateway",
+					Namespace:   "observability",
+					Annotations: tt.annotations,
+				},
+			}
+			assert.Equal(t, tt.expected, []string{
+				WorkloadName(otelcol),
+				ServiceName(otelcol),
+				HeadlessServiceName(otelcol),
+				MonitoringServiceName(otelcol),
+				PortGroupServiceName(otelcol, "grpc"),
+				ConfigMapName(otelcol, hash),
+				JaegerRemoteSamplingConfigMapName(otelcol),
+				ZoneWorkloadName(otelcol, "zone-a"),
+				ColorWorkloadName(otelcol, "blue"),
+			})
+		})
+	}
+}
+
+func TestResourceNamesPinnedDeployment(t *testing.T) {
+	params := deploymentParams()
+	params.OtelCol.Annotations = map[string]string{v1beta1.ResourceNameAnnotation: "otel-gateway"}
+
+	d, err := Deployment(params)
+	assert.NoError(t, err)
+	assert.Equal(t, "otel-gateway", d.Name)
+	assert.Equal(t, "otel-gateway", d.Labels["app.kubernetes.io/name"])
+
+	s, err := Service(params)
+	assert.NoError(t, err)
+	assert.Equal(t, "otel-gateway", s.Name)
+}
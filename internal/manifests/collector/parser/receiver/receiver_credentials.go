@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiver
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser"
+)
+
+// CredentialsMountPath is the directory the Secrets holding the credentials of the receivers are mounted in, each in
+// the directory named after it.
+const CredentialsMountPath = "/etc/otelcol-secrets"
+
+var (
+	// secretFileReferenceRegexp matches the ${file:/etc/otelcol-secrets/<secret>/<key>} references reading a
+	// credential from a key of a mounted Secret.
+	secretFileReferenceRegexp = regexp.MustCompile(`\$\{file:` + CredentialsMountPath + `/([a-z0-9]([-a-z0-9.]*[a-z0-9])?)/([-._a-zA-Z0-9]+)\}`)
+	// secretFilePathRegexp matches the /etc/otelcol-secrets/<secret>/<key> paths of the fields reading a credential
+	// from a file, such as password_file.
+	secretFilePathRegexp = regexp.MustCompile(`^` + CredentialsMountPath + `/([a-z0-9]([-a-z0-9.]*[a-z0-9])?)/([-._a-zA-Z0-9]+)$`)
+	// usernameFields are the spellings of the fields holding a user name in the configs of the receivers.
+	usernameFields = []string{"username", "user"}
+	// referenceRegexp matches the ${<scheme>:<value>}, ${VAR} and $VAR references expanded by the collector.
+	referenceRegexp = regexp.MustCompile(`\$\{[^}]+\}|\$[A-Za-z_][A-Za-z0-9_]*`)
+	// datasourcePasswordRegexps match the password of the URL and key/value data source names.
+	datasourcePasswordRegexps = []*regexp.Regexp{
+		regexp.MustCompile(`://[^/:@]*:([^@]+)@`),
+		regexp.MustCompile(`(?i)\bpassword=([^\s;&]+)`),
+	}
+)
+
+// credentialField is the kind of a credential field of the config of a receiver.
+type credentialField int
+
+const (
+	// credentialUsername is a field holding a user name, which isn't a secret.
+	credentialUsername credentialField = iota
+	// credentialPassword is a field holding a password.
+	credentialPassword
+	// credentialDatasource is a field holding a data source name, which can embed a password.
+	credentialDatasource
+	// credentialFile is a field holding the path of a file a credential is read from, such as password_file.
+	credentialFile
+)
+
+// credentialFileSuffix is the suffix of the fields reading the value of a credential field from a file.
+const credentialFileSuffix = "_file"
+
+// Credential is a credential set in the config of a receiver.
+type Credential struct {
+	// Receiver is the name of the receiver.
+	Receiver string
+	// Field is the field of the config of the receiver holding the credential.
+	Field string
+	// Secret and Key are the Secret and its key the credential is read from, when it references a file of a Secret
+	// mounted in CredentialsMountPath.
+	Secret string
+	Key    string
+	// Inline is set when a password is written in the config as is.
+	Inline bool
+}
+
+// CredentialsParser is implemented by the parsers of the receivers authenticating to the systems they scrape.
+type CredentialsParser interface {
+	// Credentials returns the credentials set in the config of the receiver.
+	Credentials() []Credential
+}
+
+var (
+	_ parser.ComponentPortParser = &CredentialsReceiver{}
+	_ CredentialsParser          = &CredentialsReceiver{}
+)
+
+// CredentialsReceiver is the parser of the scrapers authenticating with the credentials of the given fields.
+type CredentialsReceiver struct {
+	config     map[interface{}]interface{}
+	logger     logr.Logger
+	name       string
+	parserName string
+	fields     map[string]credentialField
+}
+
+// Ports returns no ports, the scrapers aren't exposed.
+func (c *CredentialsReceiver) Ports() ([]corev1.ServicePort, error) {
+	return nil, nil
+}
+
+// ParserName returns the name of this parser.
+func (c *CredentialsReceiver) ParserName() string {
+	return c.parserName
+}
+
+// Credentials returns the credentials set in the fields of the config of the receiver. Next to the fields of the
+// parser, the user names set in any of the usernameFields, and the fields reading a credential field from a file,
+// e.g. password_file, are recognized.
+func (c *CredentialsReceiver) Credentials() []Credential {
+	kinds := map[string]credentialField{}
+	for field := range c.config {
+		name, ok := field.(string)
+		if !ok {
+			continue
+		}
+		if kind, ok := c.fieldKind(name); ok {
+			kinds[name] = kind
+		}
+	}
+	var fields []string
+	for field := range kinds {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var credentials []Credential
+	for _, field := range fields {
+		value, ok := c.config[field].(string)
+		if !ok || value == "" {
+			continue
+		}
+		if kinds[field] == credentialFile {
+			if match := secretFilePathRegexp.FindStringSubmatch(value); match != nil {
+				credentials = append(credentials, Credential{Receiver: c.name, Field: field, Secret: match[1], Key: match[3]})
+			}
+			continue
+		}
+		for _, match := range secretFileReferenceRegexp.FindAllStringSubmatch(value, -1) {
+			credentials = append(credentials, Credential{Receiver: c.name, Field: field, Secret: match[1], Key: match[3]})
+		}
+		if isInlinePassword(kinds[field], value) {
+			c.logger.V(2).Info("the receiver has an inline password", "receiver", c.name, "field", field)
+			credentials = append(credentials, Credential{Receiver: c.name, Field: field, Inline: true})
+		}
+	}
+	return credentials
+}
+
+// fieldKind returns the kind of the credential held by the field of the config, if any.
+func (c *CredentialsReceiver) fieldKind(field string) (credentialField, bool) {
+	if kind, ok := c.fields[field]; ok {
+		return kind, true
+	}
+	for _, username := range usernameFields {
+		if field == username {
+			return credentialUsername, true
+		}
+	}
+	if base := strings.TrimSuffix(field, credentialFileSuffix); base != field {
+		if _, ok := c.fieldKind(base); ok {
+			return credentialFile, true
+		}
+	}
+	return 0, false
+}
+
+// isInlinePassword returns whether the value of the field holds a password not referencing the environment, a file
+// or another provider.
+func isInlinePassword(kind credentialField, value string) bool {
+	switch kind {
+	case credentialPassword:
+		return !referenceRegexp.MatchString(value)
+	case credentialDatasource:
+		for _, passwordRegexp := range datasourcePasswordRegexps {
+			if match := passwordRegexp.FindStringSubmatch(value); match != nil && !referenceRegexp.MatchString(match[1]) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
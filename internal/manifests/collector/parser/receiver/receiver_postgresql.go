@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiver
+
+import (
+	"github.com/go-logr/logr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser"
+)
+
+const parserNamePostgreSQL = "__postgresql"
+
+// NewPostgreSQLReceiverParser builds a new parser for PostgreSQL receivers, from the contrib repository.
+func NewPostgreSQLReceiverParser(logger logr.Logger, name string, config map[interface{}]interface{}) parser.ComponentPortParser {
+	return &CredentialsReceiver{
+		logger:     logger,
+		name:       name,
+		config:     config,
+		parserName: parserNamePostgreSQL,
+		fields: map[string]credentialField{
+			"username": credentialUsername,
+			"password": credentialPassword,
+		},
+	}
+}
+
+func init() {
+	Register("postgresql", NewPostgreSQLReceiverParser)
+}
@@ -20,7 +20,6 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
-	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 func PodDisruptionBudget(params manifests.Params) (*policyV1.PodDisruptionBudget, error) {
@@ -30,15 +29,16 @@ func PodDisruptionBudget(params manifests.Params) (*policyV1.PodDisruptionBudget
 		return nil, nil
 	}
 
-	name := naming.Collector(params.OtelCol.Name)
+	name := WorkloadName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
-	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	hash, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
+	annotations := manifestutils.Annotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
 
 	objectMeta := metav1.ObjectMeta{
-		Name:        naming.PodDisruptionBudget(params.OtelCol.Name),
+		Name:        WorkloadName(params.OtelCol),
 		Namespace:   params.OtelCol.Namespace,
 		Labels:      labels,
 		Annotations: annotations,
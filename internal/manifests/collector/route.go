@@ -87,7 +87,7 @@ func Routes(params manifests.Params) ([]*routev1.Route, error) {
 				Host: host,
 				To: routev1.RouteTargetReference{
 					Kind: "Service",
-					Name: naming.Service(params.OtelCol.Name),
+					Name: ServiceName(params.OtelCol),
 				},
 				Port: &routev1.RoutePort{
 					TargetPort: intstr.FromString(portName),
@@ -21,12 +21,12 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
-	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 // headless and monitoring labels are to differentiate the base/headless/monitoring services from the clusterIP service.
@@ -34,28 +34,36 @@ const (
 	headlessLabel    = "operator.opentelemetry.io/collector-headless-service"
 	monitoringLabel  = "operator.opentelemetry.io/collector-monitoring-service"
 	serviceTypeLabel = "operator.opentelemetry.io/collector-service-type"
+	portGroupLabel   = "operator.opentelemetry.io/collector-port-group"
 	valueExists      = "Exists"
 )
 
+// topology aware routing annotations, the hints annotation is deprecated since Kubernetes 1.27 but still set for older clusters.
+const (
+	topologyModeAnnotation       = "service.kubernetes.io/topology-mode"
+	topologyAwareHintsAnnotation = "service.kubernetes.io/topology-aware-hints"
+)
+
 type ServiceType int
 
 const (
 	BaseServiceType ServiceType = iota
 	HeadlessServiceType
 	MonitoringServiceType
+	PortGroupServiceType
 )
 
 func (s ServiceType) String() string {
-	return [...]string{"base", "headless", "monitoring"}[s]
+	return [...]string{"base", "headless", "monitoring", "port-group"}[s]
 }
 
 func HeadlessService(params manifests.Params) (*corev1.Service, error) {
-	h, err := Service(params)
+	h, err := collectorService(params, params.OtelCol.Spec.Services.Headless)
 	if h == nil || err != nil {
 		return h, err
 	}
 
-	h.Name = naming.HeadlessService(params.OtelCol.Name)
+	h.Name = HeadlessServiceName(params.OtelCol)
 	h.Labels[headlessLabel] = valueExists
 	h.Labels[serviceTypeLabel] = HeadlessServiceType.String()
 
@@ -69,12 +77,18 @@ func HeadlessService(params manifests.Params) (*corev1.Service, error) {
 	h.Annotations = annotations
 
 	h.Spec.ClusterIP = "None"
+	if params.OtelCol.Spec.Mode == v1beta1.ModeStatefulSet && params.OtelCol.Spec.ScaleDownDrain != nil {
+		// the replicas being drained are taken out of the loadbalancing exporters ring
+		h.Spec.Selector[RingMemberLabel] = "true"
+	}
 	return h, nil
 }
 
+// MonitoringService builds the Service exposing the metrics of the collector. It selects both colors of the
+// collectors rolled out blue/green, so that the changes can be verified before the switch.
 func MonitoringService(params manifests.Params) (*corev1.Service, error) {
 
-	name := naming.MonitoringService(params.OtelCol.Name)
+	name := MonitoringServiceName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
 	labels[monitoringLabel] = valueExists
 	labels[serviceTypeLabel] = MonitoringServiceType.String()
@@ -89,24 +103,71 @@ func MonitoringService(params manifests.Params) (*corev1.Service, error) {
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
-			Annotations: params.OtelCol.Annotations,
+			Annotations: serviceAnnotations(params.OtelCol, params.OtelCol.Spec.Services.Monitoring),
 		},
 		Spec: corev1.ServiceSpec{
-			Selector:  manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
-			ClusterIP: "",
+			InternalTrafficPolicy: params.OtelCol.Spec.Services.Monitoring.InternalTrafficPolicy,
+			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			ClusterIP:             "",
 			Ports: []corev1.ServicePort{{
 				Name: "monitoring",
 				Port: metricsPort,
 			}},
+			IPFamilies:     params.OtelCol.Spec.IPFamilies,
+			IPFamilyPolicy: params.OtelCol.Spec.IPFamilyPolicy,
 		},
 	}, nil
 }
 
 func Service(params manifests.Params) (*corev1.Service, error) {
-	name := naming.Service(params.OtelCol.Name)
+	return collectorService(params, params.OtelCol.Spec.Services.Base)
+}
+
+func collectorService(params manifests.Params, serviceSpec v1beta1.ServiceSpec) (*corev1.Service, error) {
+	name := ServiceName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
 	labels[serviceTypeLabel] = BaseServiceType.String()
 
+	ports, err := ServicePorts(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// if we have no ports, we don't need a service
+	if len(ports) == 0 {
+
+		params.Log.V(1).Info("the instance's configuration didn't yield any ports to open, skipping service", "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+		return nil, err
+	}
+
+	trafficPolicy := corev1.ServiceInternalTrafficPolicyCluster
+	if params.OtelCol.Spec.Mode == v1beta1.ModeDaemonSet {
+		trafficPolicy = corev1.ServiceInternalTrafficPolicyLocal
+	}
+	if serviceSpec.InternalTrafficPolicy != nil {
+		trafficPolicy = *serviceSpec.InternalTrafficPolicy
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ServiceName(params.OtelCol),
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: serviceAnnotations(params.OtelCol, serviceSpec),
+		},
+		Spec: corev1.ServiceSpec{
+			InternalTrafficPolicy: &trafficPolicy,
+			Selector:              serviceSelector(params.OtelCol),
+			ClusterIP:             "",
+			Ports:                 ports,
+			IPFamilies:            params.OtelCol.Spec.IPFamilies,
+			IPFamilyPolicy:        params.OtelCol.Spec.IPFamilyPolicy,
+		},
+	}, nil
+}
+
+// ServicePorts returns the ports to expose for the instance, inferred from its configuration and spec.ports.
+func ServicePorts(params manifests.Params) ([]corev1.ServicePort, error) {
 	out, err := params.OtelCol.Spec.Config.Yaml()
 	if err != nil {
 		return nil, err
@@ -118,7 +179,7 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 		return nil, err
 	}
 
-	ports, err := adapters.ConfigToPorts(params.Log, configFromString)
+	ports, err := adapters.ConfigToPorts(params.Log, configFromString, portNaming(params.OtelCol))
 	if err != nil {
 		return nil, err
 	}
@@ -151,32 +212,132 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 		ports = append(toServicePorts(params.OtelCol.Spec.Ports), resultingInferredPorts...)
 	}
 
-	// if we have no ports, we don't need a service
-	if len(ports) == 0 {
+	if params.OtelCol.Spec.ServiceMesh != "" {
+		addAppProtocolHints(ports)
+	}
 
-		params.Log.V(1).Info("the instance's configuration didn't yield any ports to open, skipping service", "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+	return ports, nil
+}
+
+// PortGroupServices builds a Service for each port group of the instance, exposing only the ports of the group.
+func PortGroupServices(params manifests.Params) ([]*corev1.Service, error) {
+	if len(params.OtelCol.Spec.Services.PortGroups) == 0 {
+		return nil, nil
+	}
+
+	servicePorts, err := ServicePorts(params)
+	if err != nil {
 		return nil, err
 	}
+	portsByName := map[string]corev1.ServicePort{}
+	for _, port := range servicePorts {
+		portsByName[port.Name] = port
+	}
 
-	trafficPolicy := corev1.ServiceInternalTrafficPolicyCluster
-	if params.OtelCol.Spec.Mode == v1beta1.ModeDaemonSet {
-		trafficPolicy = corev1.ServiceInternalTrafficPolicyLocal
+	var services []*corev1.Service
+	for _, group := range params.OtelCol.Spec.Services.PortGroups {
+		var ports []corev1.ServicePort
+		for _, portName := range group.Ports {
+			port, ok := portsByName[portName]
+			if !ok {
+				params.Log.V(1).Info("the port group references a port that isn't exposed by the collector, skipping port", "port-group", group.Name, "port", portName)
+				continue
+			}
+			ports = append(ports, port)
+		}
+		if len(ports) == 0 {
+			params.Log.V(1).Info("the port group didn't yield any ports to open, skipping service", "port-group", group.Name, "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+			continue
+		}
+
+		name := PortGroupServiceName(params.OtelCol, group.Name)
+		labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+		labels[serviceTypeLabel] = PortGroupServiceType.String()
+		labels[portGroupLabel] = group.Name
+
+		serviceType := group.Type
+		if serviceType == "" {
+			serviceType = corev1.ServiceTypeClusterIP
+		}
+
+		services = append(services, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   params.OtelCol.Namespace,
+				Labels:      labels,
+				Annotations: serviceAnnotations(params.OtelCol, group.ServiceSpec),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                  serviceType,
+				InternalTrafficPolicy: group.InternalTrafficPolicy,
+				Selector:              serviceSelector(params.OtelCol),
+				Ports:                 ports,
+				IPFamilies:            params.OtelCol.Spec.IPFamilies,
+				IPFamilyPolicy:        params.OtelCol.Spec.IPFamilyPolicy,
+			},
+		})
 	}
+	return services, nil
+}
 
-	return &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Service(params.OtelCol.Name),
-			Namespace:   params.OtelCol.Namespace,
-			Labels:      labels,
-			Annotations: params.OtelCol.Annotations,
-		},
-		Spec: corev1.ServiceSpec{
-			InternalTrafficPolicy: &trafficPolicy,
-			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
-			ClusterIP:             "",
-			Ports:                 ports,
-		},
-	}, nil
+// MissingServicePorts returns the expected ports which aren't exposed by any of the given Services.
+// A port is exposed when a Service port of the same protocol targets it, by number or by name.
+func MissingServicePorts(expected []corev1.ServicePort, services []corev1.Service) []corev1.ServicePort {
+	var missing []corev1.ServicePort
+	for _, port := range expected {
+		if !isPortExposed(port, services) {
+			missing = append(missing, port)
+		}
+	}
+	return missing
+}
+
+func isPortExposed(port corev1.ServicePort, services []corev1.Service) bool {
+	targetPort := port.Port
+	if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0 {
+		targetPort = port.TargetPort.IntVal
+	}
+	expectedKey := newPortNumberKey(targetPort, port.Protocol)
+
+	for _, svc := range services {
+		for _, svcPort := range svc.Spec.Ports {
+			if svcPort.TargetPort.Type == intstr.String && svcPort.TargetPort.StrVal != "" {
+				if svcPort.TargetPort.StrVal == port.Name && newPortNumberKey(targetPort, svcPort.Protocol) == expectedKey {
+					return true
+				}
+				continue
+			}
+			svcTargetPort := svcPort.Port
+			if svcPort.TargetPort.IntVal != 0 {
+				svcTargetPort = svcPort.TargetPort.IntVal
+			}
+			if newPortNumberKey(svcTargetPort, svcPort.Protocol) == expectedKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serviceAnnotations returns the annotations of the instance, merged with the ones configured for the Service.
+func serviceAnnotations(otelcol v1beta1.OpenTelemetryCollector, serviceSpec v1beta1.ServiceSpec) map[string]string {
+	if len(serviceSpec.Annotations) == 0 && !serviceSpec.TopologyAwareRouting {
+		return otelcol.Annotations
+	}
+
+	// copy to avoid modifying otelcol.Annotations
+	annotations := map[string]string{}
+	for k, v := range otelcol.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range serviceSpec.Annotations {
+		annotations[k] = v
+	}
+	if serviceSpec.TopologyAwareRouting {
+		annotations[topologyModeAnnotation] = "Auto"
+		annotations[topologyAwareHintsAnnotation] = "auto"
+	}
+	return annotations
 }
 
 type PortNumberKey struct {
@@ -233,3 +394,11 @@ func extractPortNumbersAndNames(ports []v1beta1.PortsSpec) (map[PortNumberKey]bo
 
 	return numbers, names
 }
+
+// portNaming returns how the ports opened for the components of the collector config are named.
+func portNaming(otelcol v1beta1.OpenTelemetryCollector) adapters.PortNaming {
+	return adapters.PortNaming{
+		HashSuffix: otelcol.Spec.PortNaming.Strategy == v1beta1.PortNamingStrategyHashSuffix,
+		Overrides:  otelcol.Spec.PortNaming.Overrides,
+	}
+}
@@ -18,8 +18,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -226,6 +228,57 @@ func TestDesiredService(t *testing.T) {
 		assert.Equal(t, expected, *actual)
 	})
 
+	t.Run("should return service with ip families", func(t *testing.T) {
+		policy := v1.IPFamilyPolicyPreferDualStack
+		params := deploymentParams()
+		params.OtelCol.Spec.IPFamilies = []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}
+		params.OtelCol.Spec.IPFamilyPolicy = &policy
+
+		actual, err := Service(params)
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}, actual.Spec.IPFamilies)
+		assert.Equal(t, &policy, actual.Spec.IPFamilyPolicy)
+
+		headless, err := HeadlessService(params)
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}, headless.Spec.IPFamilies)
+		assert.Equal(t, &policy, headless.Spec.IPFamilyPolicy)
+
+		monitoring, err := MonitoringService(params)
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}, monitoring.Spec.IPFamilies)
+		assert.Equal(t, &policy, monitoring.Spec.IPFamilyPolicy)
+	})
+
+	t.Run("should return service with configured traffic policy and topology aware routing", func(t *testing.T) {
+		local := v1.ServiceInternalTrafficPolicyLocal
+		params := deploymentParams()
+		params.OtelCol.Annotations = map[string]string{"foo": "bar"}
+		params.OtelCol.Spec.Services.Base = v1beta1.ServiceSpec{
+			Annotations:           map[string]string{"service": "base"},
+			InternalTrafficPolicy: &local,
+			TopologyAwareRouting:  true,
+		}
+
+		actual, err := Service(params)
+		assert.NoError(t, err)
+		assert.Equal(t, &local, actual.Spec.InternalTrafficPolicy)
+		assert.Equal(t, map[string]string{
+			"foo":                        "bar",
+			"service":                    "base",
+			topologyModeAnnotation:       "Auto",
+			topologyAwareHintsAnnotation: "auto",
+		}, actual.Annotations)
+		assert.Equal(t, map[string]string{"foo": "bar"}, params.OtelCol.Annotations)
+
+		headless, err := HeadlessService(params)
+		assert.NoError(t, err)
+		cluster := v1.ServiceInternalTrafficPolicyCluster
+		assert.Equal(t, &cluster, headless.Spec.InternalTrafficPolicy)
+		assert.NotContains(t, headless.Annotations, topologyModeAnnotation)
+		assert.NotContains(t, headless.Annotations, "service")
+	})
+
 }
 
 func TestHeadlessService(t *testing.T) {
@@ -277,6 +330,21 @@ func TestMonitoringService(t *testing.T) {
 		assert.NotNil(t, actual)
 		assert.Equal(t, expected, actual.Spec.Ports)
 	})
+
+	t.Run("returned service should use the monitoring service settings", func(t *testing.T) {
+		local := v1.ServiceInternalTrafficPolicyLocal
+		params := deploymentParams()
+		params.OtelCol.Spec.Services.Monitoring = v1beta1.ServiceSpec{
+			InternalTrafficPolicy: &local,
+			TopologyAwareRouting:  true,
+		}
+
+		actual, err := MonitoringService(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, &local, actual.Spec.InternalTrafficPolicy)
+		assert.Equal(t, "Auto", actual.Annotations[topologyModeAnnotation])
+	})
 }
 
 func service(name string, ports []v1beta1.PortsSpec) v1.Service {
@@ -308,3 +376,105 @@ func serviceWithInternalTrafficPolicy(name string, ports []v1beta1.PortsSpec, in
 		},
 	}
 }
+
+func TestPortGroupServices(t *testing.T) {
+	t.Run("should not return services without port groups", func(t *testing.T) {
+		actual, err := PortGroupServices(deploymentParams())
+		assert.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+
+	t.Run("should return a service per port group", func(t *testing.T) {
+		params := deploymentParams()
+		params.OtelCol.Spec.Services.PortGroups = []v1beta1.ServicePortGroup{
+			{
+				Name:  "ingest",
+				Ports: []string{"jaeger-grpc", "unknown"},
+				Type:  v1.ServiceTypeLoadBalancer,
+				ServiceSpec: v1beta1.ServiceSpec{
+					Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+				},
+			},
+			{
+				Name:  "web",
+				Ports: []string{"web"},
+			},
+			{
+				Name:  "empty",
+				Ports: []string{"unknown"},
+			},
+		}
+
+		actual, err := PortGroupServices(params)
+		assert.NoError(t, err)
+		assert.Len(t, actual, 2)
+
+		assert.Equal(t, "test-collector-ingest", actual[0].Name)
+		assert.Equal(t, v1.ServiceTypeLoadBalancer, actual[0].Spec.Type)
+		assert.Equal(t, "true", actual[0].Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+		assert.Equal(t, PortGroupServiceType.String(), actual[0].Labels[serviceTypeLabel])
+		assert.Equal(t, "ingest", actual[0].Labels[portGroupLabel])
+		assert.Len(t, actual[0].Spec.Ports, 1)
+		assert.Equal(t, "jaeger-grpc", actual[0].Spec.Ports[0].Name)
+		assert.Equal(t, int32(14250), actual[0].Spec.Ports[0].Port)
+
+		assert.Equal(t, "test-collector-web", actual[1].Name)
+		assert.Equal(t, v1.ServiceTypeClusterIP, actual[1].Spec.Type)
+		assert.Len(t, actual[1].Spec.Ports, 1)
+		assert.Equal(t, "web", actual[1].Spec.Ports[0].Name)
+	})
+}
+
+func TestMissingServicePorts(t *testing.T) {
+	expected := []v1.ServicePort{
+		{Name: "otlp-grpc", Port: 4317},
+		{Name: "otlp-http", Port: 4318},
+		{Name: "jaeger-thrift-compact", Port: 6831, Protocol: v1.ProtocolUDP},
+	}
+
+	t.Run("all ports exposed", func(t *testing.T) {
+		services := []v1.Service{
+			{Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+				{Name: "grpc", Port: 443, TargetPort: intstr.FromInt32(4317)},
+				{Name: "http", Port: 4318, Protocol: v1.ProtocolTCP},
+			}}},
+			{Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+				{Name: "jaeger", Port: 6831, TargetPort: intstr.FromString("jaeger-thrift-compact"), Protocol: v1.ProtocolUDP},
+			}}},
+		}
+		assert.Empty(t, MissingServicePorts(expected, services))
+	})
+
+	t.Run("ports missing or with a different protocol", func(t *testing.T) {
+		services := []v1.Service{
+			{Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+				{Name: "grpc", Port: 4317},
+				{Name: "jaeger", Port: 6831},
+			}}},
+		}
+		assert.Equal(t, expected[1:], MissingServicePorts(expected, services))
+	})
+
+	t.Run("no services", func(t *testing.T) {
+		assert.Equal(t, expected, MissingServicePorts(expected, nil))
+	})
+}
+
+func TestStatefulSetScaleDownDrain(t *testing.T) {
+	params := paramsWithMode(v1beta1.ModeStatefulSet)
+	params.OtelCol.Spec.ScaleDownDrain = &v1beta1.ScaleDownDrain{}
+
+	ss, err := StatefulSet(params)
+	require.NoError(t, err)
+	assert.Equal(t, "true", ss.Spec.Template.Labels[RingMemberLabel])
+	assert.NotContains(t, ss.Labels, RingMemberLabel)
+	assert.NotContains(t, ss.Spec.Selector.MatchLabels, RingMemberLabel)
+
+	headless, err := HeadlessService(params)
+	require.NoError(t, err)
+	assert.Equal(t, "true", headless.Spec.Selector[RingMemberLabel])
+
+	service, err := Service(params)
+	require.NoError(t, err)
+	assert.NotContains(t, service.Spec.Selector, RingMemberLabel)
+}
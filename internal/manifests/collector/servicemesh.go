@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+const (
+	istioExcludeInboundPortsAnnotation   = "traffic.sidecar.istio.io/excludeInboundPorts"
+	istioProxyConfigAnnotation           = "proxy.istio.io/config"
+	istioRewriteAppHTTPProbersAnnotation = "sidecar.istio.io/rewriteAppHTTPProbers"
+	linkerdSkipInboundPortsAnnotation    = "config.linkerd.io/skip-inbound-ports"
+	linkerdProxyAwaitAnnotation          = "config.linkerd.io/proxy-await"
+)
+
+// addServiceMeshAnnotations adds the pod annotations required to run the collector in the configured service mesh.
+// The receiver and probe ports bypass the proxy, as the telemetry protocols are handled by the collector itself
+// and the kubelet probes would otherwise be intercepted, and the collector waits for the proxy to be ready before
+// starting, so that exporters don't fail while the proxy isn't able to route traffic yet.
+// Annotations already set on the pod are preserved.
+func addServiceMeshAnnotations(params manifests.Params, containers []corev1.Container, podAnnotations map[string]string) {
+	if params.OtelCol.Spec.ServiceMesh == "" {
+		return
+	}
+
+	inboundPorts := map[int32]bool{}
+	probePorts := false
+	for _, container := range containers {
+		for _, port := range container.Ports {
+			inboundPorts[port.ContainerPort] = true
+		}
+		for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe} {
+			if probe == nil {
+				continue
+			}
+			if probe.HTTPGet != nil && probe.HTTPGet.Port.IntVal != 0 {
+				inboundPorts[probe.HTTPGet.Port.IntVal] = true
+				probePorts = true
+			}
+			if probe.TCPSocket != nil && probe.TCPSocket.Port.IntVal != 0 {
+				inboundPorts[probe.TCPSocket.Port.IntVal] = true
+				probePorts = true
+			}
+		}
+	}
+	var ports []int
+	for port := range inboundPorts {
+		ports = append(ports, int(port))
+	}
+	sort.Ints(ports)
+	portList := make([]string, len(ports))
+	for i, port := range ports {
+		portList[i] = strconv.Itoa(port)
+	}
+
+	meshAnnotations := map[string]string{}
+	switch params.OtelCol.Spec.ServiceMesh {
+	case v1beta1.ServiceMeshIstio:
+		meshAnnotations[istioProxyConfigAnnotation] = `{"holdApplicationUntilProxyStarts":true}`
+		if len(portList) > 0 {
+			meshAnnotations[istioExcludeInboundPortsAnnotation] = strings.Join(portList, ",")
+		}
+		if probePorts {
+			// the probe ports bypass the proxy, the probes don't need to be rewritten
+			meshAnnotations[istioRewriteAppHTTPProbersAnnotation] = "false"
+		}
+	case v1beta1.ServiceMeshLinkerd:
+		meshAnnotations[linkerdProxyAwaitAnnotation] = "enabled"
+		if len(portList) > 0 {
+			meshAnnotations[linkerdSkipInboundPortsAnnotation] = strings.Join(portList, ",")
+		}
+	}
+
+	for k, v := range meshAnnotations {
+		if _, found := podAnnotations[k]; !found {
+			podAnnotations[k] = v
+		}
+	}
+}
+
+// addAppProtocolHints sets the application protocol of the ports which don't have one, based on their name,
+// so that the service mesh proxies of the clients don't have to detect the protocol.
+func addAppProtocolHints(ports []corev1.ServicePort) {
+	for i := range ports {
+		if ports[i].AppProtocol != nil || (ports[i].Protocol != "" && ports[i].Protocol != corev1.ProtocolTCP) {
+			continue
+		}
+		var appProtocol string
+		switch {
+		case strings.Contains(ports[i].Name, "grpc"):
+			appProtocol = "grpc"
+		case strings.Contains(ports[i].Name, "http"):
+			appProtocol = "http"
+		default:
+			continue
+		}
+		ports[i].AppProtocol = &appProtocol
+	}
+}
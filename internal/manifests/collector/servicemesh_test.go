@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestAddServiceMeshAnnotations(t *testing.T) {
+	t.Run("should not add annotations without service mesh", func(t *testing.T) {
+		podAnnotations := map[string]string{}
+		addServiceMeshAnnotations(deploymentParams(), nil, podAnnotations)
+		assert.Empty(t, podAnnotations)
+	})
+
+	t.Run("should add istio annotations", func(t *testing.T) {
+		params := deploymentParams()
+		params.OtelCol.Spec.ServiceMesh = v1beta1.ServiceMeshIstio
+		podAnnotations := map[string]string{}
+		containers, err := Containers(params.Config, params.Log, params.OtelCol, true)
+		require.NoError(t, err)
+		addServiceMeshAnnotations(params, containers, podAnnotations)
+
+		assert.Equal(t, `{"holdApplicationUntilProxyStarts":true}`, podAnnotations[istioProxyConfigAnnotation])
+		ports := strings.Split(podAnnotations[istioExcludeInboundPortsAnnotation], ",")
+		assert.Contains(t, ports, "80")
+		assert.Contains(t, ports, "14250")
+		assert.NotContains(t, podAnnotations, linkerdProxyAwaitAnnotation)
+	})
+
+	t.Run("should add linkerd annotations", func(t *testing.T) {
+		params := deploymentParams()
+		params.OtelCol.Spec.ServiceMesh = v1beta1.ServiceMeshLinkerd
+		podAnnotations := map[string]string{}
+		containers, err := Containers(params.Config, params.Log, params.OtelCol, true)
+		require.NoError(t, err)
+		addServiceMeshAnnotations(params, containers, podAnnotations)
+
+		assert.Equal(t, "enabled", podAnnotations[linkerdProxyAwaitAnnotation])
+		ports := strings.Split(podAnnotations[linkerdSkipInboundPortsAnnotation], ",")
+		assert.Contains(t, ports, "80")
+		assert.Contains(t, ports, "14250")
+		assert.NotContains(t, podAnnotations, istioProxyConfigAnnotation)
+	})
+
+	t.Run("should not override pod annotations", func(t *testing.T) {
+		params := deploymentParams()
+		params.OtelCol.Spec.ServiceMesh = v1beta1.ServiceMeshLinkerd
+		podAnnotations := map[string]string{linkerdSkipInboundPortsAnnotation: "4317"}
+		containers, err := Containers(params.Config, params.Log, params.OtelCol, true)
+		require.NoError(t, err)
+		addServiceMeshAnnotations(params, containers, podAnnotations)
+
+		assert.Equal(t, "4317", podAnnotations[linkerdSkipInboundPortsAnnotation])
+		assert.Equal(t, "enabled", podAnnotations[linkerdProxyAwaitAnnotation])
+	})
+}
+
+func TestAddAppProtocolHints(t *testing.T) {
+	custom := "kubernetes.io/h2c"
+	ports := []v1.ServicePort{
+		{Name: "otlp-grpc", Port: 4317},
+		{Name: "otlp-http", Port: 4318, Protocol: v1.ProtocolTCP},
+		{Name: "jaeger-grpc", Port: 14250, AppProtocol: &custom},
+		{Name: "syslog-udp", Port: 514, Protocol: v1.ProtocolUDP},
+		{Name: "web", Port: 80},
+	}
+	addAppProtocolHints(ports)
+
+	assert.Equal(t, "grpc", *ports[0].AppProtocol)
+	assert.Equal(t, "http", *ports[1].AppProtocol)
+	assert.Equal(t, custom, *ports[2].AppProtocol)
+	assert.Nil(t, ports[3].AppProtocol)
+	assert.Nil(t, ports[4].AppProtocol)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// signalCollector is the collector running the pipelines of a signal in its own container.
+type signalCollector struct {
+	signal  string
+	otelcol v1beta1.OpenTelemetryCollector
+}
+
+// ContainerName returns the name of the collector container. The sidecars keep the default name, which identifies
+// them in the pods.
+func ContainerName(otelcol v1beta1.OpenTelemetryCollector) string {
+	if otelcol.Spec.ContainerName != "" && otelcol.Spec.Mode != v1beta1.ModeSidecar {
+		return otelcol.Spec.ContainerName
+	}
+	return naming.Container()
+}
+
+// PipelinesSplit returns true when the pipelines of each signal run in a separate container.
+func PipelinesSplit(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return otelcol.Spec.SplitPipelinesIntoContainers != nil && otelcol.Spec.SplitPipelinesIntoContainers.Enabled &&
+		otelcol.Spec.Mode != v1beta1.ModeSidecar
+}
+
+// IsCollectorContainer returns true when the container of the given name runs the collector, or the pipelines of one
+// of its signals.
+func IsCollectorContainer(otelcol v1beta1.OpenTelemetryCollector, name string) bool {
+	container := ContainerName(otelcol)
+	if name == container {
+		return true
+	}
+	return PipelinesSplit(otelcol) && strings.HasPrefix(name, container+"-")
+}
+
+// Containers builds the collector containers for the given collector: a single one, or one per signal of the
+// pipelines when they are split.
+func Containers(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, addConfig bool) ([]corev1.Container, error) {
+	if !PipelinesSplit(otelcol) {
+		return singleContainer(cfg, logger, otelcol, addConfig)
+	}
+	collectors, err := signalCollectors(otelcol)
+	if err != nil {
+		return nil, err
+	}
+	if len(collectors) == 0 {
+		return singleContainer(cfg, logger, otelcol, addConfig)
+	}
+
+	containers := make([]corev1.Container, 0, len(collectors))
+	for _, c := range collectors {
+		name := naming.SignalContainer(ContainerName(otelcol), c.signal)
+		signalContainer, err := container(cfg, logger, c.otelcol, addConfig, name, signalConfigMapEntry(cfg.CollectorConfigMapEntry(), c.signal))
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, signalContainer)
+	}
+	return containers, nil
+}
+
+// singleContainer builds the single collector container of the given collector.
+func singleContainer(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, addConfig bool) ([]corev1.Container, error) {
+	c, err := Container(cfg, logger, otelcol, addConfig)
+	if err != nil {
+		return nil, err
+	}
+	return []corev1.Container{c}, nil
+}
+
+// signalCollectors returns a copy of the collector per signal of its pipelines, only running these pipelines with
+// the resources of the signal. The ports of the spec are only exposed by the first one.
+func signalCollectors(otelcol v1beta1.OpenTelemetryCollector) ([]signalCollector, error) {
+	configs, err := otelcol.Spec.Config.SplitBySignal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to split the pipelines by signal: %w", err)
+	}
+	collectors := make([]signalCollector, 0, len(configs))
+	for i, signalConfig := range configs {
+		c := otelcol
+		c.Spec.Config = signalConfig.Config
+		if resources, ok := otelcol.Spec.SplitPipelinesIntoContainers.Resources[signalConfig.Signal]; ok {
+			c.Spec.Resources = resources
+		}
+		if i > 0 {
+			c.Spec.Ports = nil
+		}
+		collectors = append(collectors, signalCollector{signal: signalConfig.Signal, otelcol: c})
+	}
+	return collectors, nil
+}
+
+// signalConfigMapEntry returns the ConfigMap entry holding the config of the signal, e.g. collector-traces.yaml.
+func signalConfigMapEntry(entry, signal string) string {
+	ext := path.Ext(entry)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(entry, ext), signal, ext)
+}
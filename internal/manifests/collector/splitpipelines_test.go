@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func splitPipelinesCollector() v1beta1.OpenTelemetryCollector {
+	return v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			SplitPipelinesIntoContainers: &v1beta1.SplitPipelinesIntoContainers{
+				Enabled: true,
+				Resources: map[string]corev1.ResourceRequirements{
+					"metrics": {Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+				},
+			},
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Ports: []v1beta1.PortsSpec{{ServicePort: corev1.ServicePort{Name: "custom", Port: 9999}}},
+			},
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"otlp":       map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}},
+					"prometheus": map[string]interface{}{},
+				}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+					"metrics": {Receivers: []string{"prometheus"}, Exporters: []string{"debug"}},
+				}},
+			},
+		},
+	}
+}
+
+func TestContainerName(t *testing.T) {
+	otelcol := splitPipelinesCollector()
+	otelcol.Spec.SplitPipelinesIntoContainers = nil
+	otelcol.Spec.ContainerName = "collector"
+
+	containers, err := Containers(config.New(), logger, otelcol, true)
+	require.NoError(t, err)
+
+	require.Len(t, containers, 1)
+	assert.Equal(t, "collector", containers[0].Name)
+	assert.True(t, IsCollectorContainer(otelcol, "collector"))
+	assert.False(t, IsCollectorContainer(otelcol, "collector-traces"))
+}
+
+func TestSplitPipelinesContainers(t *testing.T) {
+	otelcol := splitPipelinesCollector()
+
+	containers, err := Containers(config.New(), logger, otelcol, true)
+	require.NoError(t, err)
+
+	require.Len(t, containers, 2)
+	traces, metrics := containers[0], containers[1]
+
+	assert.Equal(t, "otc-container-traces", traces.Name)
+	assert.Contains(t, traces.Args, "--config=/conf/collector-traces.yaml")
+	assert.Equal(t, []string{"custom", "metrics", "otlp-grpc"}, portNames(traces.Ports))
+	assert.Empty(t, traces.Resources.Limits)
+
+	assert.Equal(t, "otc-container-metrics", metrics.Name)
+	assert.Contains(t, metrics.Args, "--config=/conf/collector-metrics.yaml")
+	assert.Equal(t, []string{"metrics"}, portNames(metrics.Ports))
+	assert.Equal(t, int32(8889), metrics.Ports[0].ContainerPort)
+	assert.Equal(t, resource.MustParse("1Gi"), metrics.Resources.Limits[corev1.ResourceMemory])
+
+	assert.True(t, IsCollectorContainer(otelcol, "otc-container-metrics"))
+}
+
+func TestSplitPipelinesConfigMapAndVolume(t *testing.T) {
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: splitPipelinesCollector(),
+		Log:     logger,
+	}
+
+	configMap, err := ConfigMap(params)
+	require.NoError(t, err)
+	assert.Contains(t, configMap.Data, "collector.yaml")
+	assert.Contains(t, configMap.Data["collector-traces.yaml"], "otlp")
+	assert.NotContains(t, configMap.Data["collector-traces.yaml"], "prometheus")
+	assert.Contains(t, configMap.Data["collector-metrics.yaml"], "prometheus")
+	assert.Contains(t, configMap.Data["collector-metrics.yaml"], "0.0.0.0:8889")
+
+	volumes, err := Volumes(params.Config, params.OtelCol)
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.KeyToPath{
+		{Key: "collector.yaml", Path: "collector.yaml"},
+		{Key: "collector-traces.yaml", Path: "collector-traces.yaml"},
+		{Key: "collector-metrics.yaml", Path: "collector-metrics.yaml"},
+	}, volumes[0].ConfigMap.Items)
+}
+
+func TestSplitPipelinesInvalidMetricsAddress(t *testing.T) {
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: splitPipelinesCollector(),
+		Log:     logger,
+	}
+	params.OtelCol.Spec.Config.Service.Telemetry = &v1beta1.AnyConfig{Object: map[string]interface{}{
+		"metrics": map[string]interface{}{"address": "0.0.0.0:metrics"},
+	}}
+
+	_, err := Containers(params.Config, params.Log, params.OtelCol, true)
+	assert.ErrorContains(t, err, "failed to split the pipelines by signal")
+	_, err = Volumes(params.Config, params.OtelCol)
+	assert.ErrorContains(t, err, "failed to split the pipelines by signal")
+	_, err = ConfigMap(params)
+	assert.ErrorContains(t, err, "failed to split the pipelines by signal")
+}
+
+func portNames(ports []corev1.ContainerPort) []string {
+	var names []string
+	for _, p := range ports {
+		names = append(names, p.Name)
+	}
+	return names
+}
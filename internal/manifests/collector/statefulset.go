@@ -21,23 +21,42 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
-	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
+// RingMemberLabel is set on the pods of a statefulset collector with scale down drain. Only the pods with the label set
+// to true are selected by the headless service, the pods being drained have it set to false.
+const RingMemberLabel = "operator.opentelemetry.io/collector-ring-member"
+
 // StatefulSet builds the statefulset for the given instance.
 func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
-	name := naming.Collector(params.OtelCol.Name)
+	name := WorkloadName(params.OtelCol)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
 
-	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	hash, err := GetCollectorConfigSHA(params.Config, params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
+	annotations := manifestutils.Annotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
 
-	podAnnotations, err := manifestutils.PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	podAnnotations := manifestutils.PodAnnotations(params.OtelCol, hash, params.Config.AnnotationsFilter())
+	containers, err := Containers(params.Config, params.Log, params.OtelCol, true)
+	if err != nil {
+		return nil, err
+	}
+	volumes, err := Volumes(params.Config, params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
+	addServiceMeshAnnotations(params, containers, podAnnotations)
+
+	podLabels := labels
+	if params.OtelCol.Spec.ScaleDownDrain != nil {
+		// copy to avoid adding the label to the statefulset
+		podLabels = map[string]string{RingMemberLabel: "true"}
+		for k, v := range labels {
+			podLabels[k] = v
+		}
+	}
 
 	return &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -47,21 +66,23 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 			Annotations: annotations,
 		},
 		Spec: appsv1.StatefulSetSpec{
-			ServiceName: naming.Service(params.OtelCol.Name),
+			ServiceName: ServiceName(params.OtelCol),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      podLabels,
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName:        ServiceAccountName(params.OtelCol),
 					InitContainers:            params.OtelCol.Spec.InitContainers,
-					Containers:                append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
-					Volumes:                   Volumes(params.Config, params.OtelCol),
-					DNSPolicy:                 manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork),
+					Containers:                append(params.OtelCol.Spec.AdditionalContainers, containers...),
+					Volumes:                   volumes,
+					DNSPolicy:                 manifestutils.GetDNSPolicyWithOverride(params.OtelCol.Spec.DNSPolicy, params.OtelCol.Spec.HostNetwork),
+					DNSConfig:                 params.OtelCol.Spec.DNSConfig,
+					HostAliases:               params.OtelCol.Spec.HostAliases,
 					HostNetwork:               params.OtelCol.Spec.HostNetwork,
 					ShareProcessNamespace:     &params.OtelCol.Spec.ShareProcessNamespace,
 					Tolerations:               params.OtelCol.Spec.Tolerations,
@@ -62,6 +62,8 @@ func TargetAllocator(params manifests.Params) (*v1alpha1.TargetAllocator, error)
 				Env:                       taSpec.Env,
 				PodAnnotations:            params.OtelCol.Spec.PodAnnotations,
 				PodDisruptionBudget:       taSpec.PodDisruptionBudget,
+				IPFamilies:                params.OtelCol.Spec.IPFamilies,
+				IPFamilyPolicy:            params.OtelCol.Spec.IPFamilyPolicy,
 			},
 			AllocationStrategy: taSpec.AllocationStrategy,
 			FilterStrategy:     taSpec.FilterStrategy,
@@ -16,27 +16,41 @@
 package collector
 
 import (
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
-	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 // Volumes builds the volumes for the given instance, including the config map volume.
-func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1.Volume {
-	hash, _ := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
-	configMapName := naming.ConfigMap(otelcol.Name, hash)
+func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) ([]corev1.Volume, error) {
+	hash, err := GetCollectorConfigSHA(cfg, otelcol)
+	if err != nil {
+		return nil, err
+	}
+	configMapName := ConfigMapName(otelcol, hash)
+	items := []corev1.KeyToPath{{
+		Key:  cfg.CollectorConfigMapEntry(),
+		Path: cfg.CollectorConfigMapEntry(),
+	}}
+	if PipelinesSplit(otelcol) {
+		collectors, err := signalCollectors(otelcol)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range collectors {
+			entry := signalConfigMapEntry(cfg.CollectorConfigMapEntry(), c.signal)
+			items = append(items, corev1.KeyToPath{Key: entry, Path: entry})
+		}
+	}
 	volumes := []corev1.Volume{{
 		Name: naming.ConfigMapVolume(),
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
 				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
-				Items: []corev1.KeyToPath{{
-					Key:  cfg.CollectorConfigMapEntry(),
-					Path: cfg.CollectorConfigMapEntry(),
-				}},
+				Items:                items,
 			},
 		},
 	}}
@@ -60,5 +74,22 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1
 		}
 	}
 
-	return volumes
+	if otelcol.Spec.JaegerRemoteSampling != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: naming.JaegerRemoteSamplingVolume(),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: jaegerRemoteSamplingConfigMapName(otelcol)},
+					Items: []corev1.KeyToPath{{
+						Key:  JaegerRemoteSamplingStrategiesFile,
+						Path: JaegerRemoteSamplingStrategiesFile,
+					}},
+				},
+			},
+		})
+	}
+
+	volumes = append(volumes, CredentialSecretVolumes(logr.Discard(), otelcol)...)
+
+	return volumes, nil
 }
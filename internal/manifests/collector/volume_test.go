@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
@@ -32,7 +33,8 @@ func TestVolumeNewDefault(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	volumes := Volumes(cfg, otelcol)
+	volumes, err := Volumes(cfg, otelcol)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, volumes, 1)
@@ -55,7 +57,8 @@ func TestVolumeAllowsMoreToBeAdded(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	volumes := Volumes(cfg, otelcol)
+	volumes, err := Volumes(cfg, otelcol)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, volumes, 2)
@@ -80,7 +83,8 @@ func TestVolumeWithMoreConfigMaps(t *testing.T) {
 	cfg := config.New()
 
 	// test
-	volumes := Volumes(cfg, otelcol)
+	volumes, err := Volumes(cfg, otelcol)
+	require.NoError(t, err)
 
 	// verify
 	assert.Len(t, volumes, 3)
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+// ZoneLabel is the label set on the pods of the per-zone deployments, with the zone they run in.
+const ZoneLabel = "opentelemetry.io/zone"
+
+// ZoneDeployments builds one deployment per zone listed in spec.zoneSpread.zones. The replicas of the collector are
+// divided among the zones, and each zone runs at least spec.zoneSpread.minPerZone replicas.
+func ZoneDeployments(params manifests.Params) ([]*appsv1.Deployment, error) {
+	zoneSpread := params.OtelCol.Spec.ZoneSpread
+	if params.OtelCol.Spec.Mode != v1beta1.ModeDeployment || zoneSpread == nil || len(zoneSpread.Zones) == 0 {
+		return nil, nil
+	}
+	base, err := Deployment(params)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := zoneReplicas(params.OtelCol.Spec.Replicas, zoneSpread)
+	var deployments []*appsv1.Deployment
+	for i, zone := range zoneSpread.Zones {
+		d := base.DeepCopy()
+		d.Name = ZoneWorkloadName(params.OtelCol, zone)
+		d.Spec.Replicas = &replicas[i]
+		d.Spec.Selector.MatchLabels[ZoneLabel] = zone
+		d.Spec.Template.Labels[ZoneLabel] = zone
+		d.Spec.Template.Spec.Affinity = zoneAffinity(d.Spec.Template.Spec.Affinity, zoneTopologyKey(zoneSpread), zone)
+		deployments = append(deployments, d)
+	}
+	return deployments, nil
+}
+
+// zoneReplicas divides the replicas of the collector among the zones, with at least minPerZone replicas per zone.
+func zoneReplicas(replicas *int32, zoneSpread *v1beta1.ZoneSpread) []int32 {
+	total := int32(1)
+	if replicas != nil {
+		total = *replicas
+	}
+	zones := int32(len(zoneSpread.Zones))
+	result := make([]int32, zones)
+	for i := range result {
+		result[i] = total / zones
+		if int32(i) < total%zones {
+			result[i]++
+		}
+		result[i] = max(result[i], zoneSpread.MinPerZone)
+	}
+	return result
+}
+
+// zoneAffinity requires the pods to run in the given zone, on top of the node affinity of the collector.
+func zoneAffinity(affinity *corev1.Affinity, topologyKey, zone string) *corev1.Affinity {
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	// the terms are ORed, the zone has to be required by each of them
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      topologyKey,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{zone},
+		})
+	}
+	return affinity
+}
+
+// zoneTopologySpreadConstraints adds a constraint spreading the replicas evenly across the zones, when the collector
+// has a zone spread without zones.
+func zoneTopologySpreadConstraints(otelcol v1beta1.OpenTelemetryCollector) []corev1.TopologySpreadConstraint {
+	zoneSpread := otelcol.Spec.ZoneSpread
+	if zoneSpread == nil || len(zoneSpread.Zones) > 0 {
+		return otelcol.Spec.TopologySpreadConstraints
+	}
+	constraints := append([]corev1.TopologySpreadConstraint{}, otelcol.Spec.TopologySpreadConstraints...)
+	return append(constraints, corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       zoneTopologyKey(zoneSpread),
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: manifestutils.SelectorLabels(otelcol.ObjectMeta, ComponentOpenTelemetryCollector),
+		},
+	})
+}
+
+func zoneTopologyKey(zoneSpread *v1beta1.ZoneSpread) string {
+	if zoneSpread.TopologyKey != "" {
+		return zoneSpread.TopologyKey
+	}
+	return corev1.LabelTopologyZone
+}
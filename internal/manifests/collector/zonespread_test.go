@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+func TestZoneDeployments(t *testing.T) {
+	replicas := int32(5)
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Replicas: &replicas,
+				Affinity: testAffinityValue,
+			},
+			ZoneSpread: &v1beta1.ZoneSpread{
+				MinPerZone: 2,
+				Zones:      []string{"zone-a", "zone-b", "zone-c"},
+			},
+		},
+	}
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	deployments, err := ZoneDeployments(params)
+	require.NoError(t, err)
+	require.Len(t, deployments, 3)
+
+	expectedReplicas := []int32{2, 2, 2}
+	for i, zone := range []string{"zone-a", "zone-b", "zone-c"} {
+		d := deployments[i]
+		assert.Equal(t, "my-instance-collector-"+zone, d.Name)
+		assert.Equal(t, expectedReplicas[i], *d.Spec.Replicas)
+		assert.Equal(t, zone, d.Spec.Selector.MatchLabels[ZoneLabel])
+		assert.Equal(t, zone, d.Spec.Template.Labels[ZoneLabel])
+		assert.NotContains(t, d.Labels, ZoneLabel)
+
+		terms := d.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		assert.Equal(t, []v1.NodeSelectorRequirement{
+			{Key: "node", Operator: v1.NodeSelectorOpIn, Values: []string{"test-node"}},
+			{Key: "topology.kubernetes.io/zone", Operator: v1.NodeSelectorOpIn, Values: []string{zone}},
+		}, terms[0].MatchExpressions)
+	}
+	// the affinity of the collector is not modified
+	assert.Len(t, testAffinityValue.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+}
+
+func TestZoneDeploymentsReplicas(t *testing.T) {
+	replicas := int32(7)
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-instance",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Replicas: &replicas,
+			},
+			ZoneSpread: &v1beta1.ZoneSpread{
+				MinPerZone:  1,
+				Zones:       []string{"zone-a", "zone-b"},
+				TopologyKey: "example.com/zone",
+			},
+		},
+	}
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	deployments, err := ZoneDeployments(params)
+	require.NoError(t, err)
+	require.Len(t, deployments, 2)
+	assert.Equal(t, int32(4), *deployments[0].Spec.Replicas)
+	assert.Equal(t, int32(3), *deployments[1].Spec.Replicas)
+	assert.Equal(t, "example.com/zone", deployments[0].Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key)
+}
+
+func TestZoneSpreadWithoutZones(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				TopologySpreadConstraints: testTopologySpreadConstraintValue,
+			},
+			ZoneSpread: &v1beta1.ZoneSpread{
+				MinPerZone: 1,
+			},
+		},
+	}
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     logger,
+	}
+
+	deployments, err := ZoneDeployments(params)
+	require.NoError(t, err)
+	assert.Empty(t, deployments)
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+	require.Len(t, d.Spec.Template.Spec.TopologySpreadConstraints, 2)
+	assert.Equal(t, testTopologySpreadConstraintValue[0], d.Spec.Template.Spec.TopologySpreadConstraints[0])
+	assert.Equal(t, v1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: v1.DoNotSchedule,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app.kubernetes.io/component":  "opentelemetry-collector",
+				"app.kubernetes.io/instance":   "my-namespace.my-instance",
+				"app.kubernetes.io/managed-by": "opentelemetry-operator",
+				"app.kubernetes.io/part-of":    "opentelemetry",
+			},
+		},
+	}, d.Spec.Template.Spec.TopologySpreadConstraints[1])
+	assert.Len(t, testTopologySpreadConstraintValue, 1)
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// SinkExporter is the exporter replacing the exporters of the candidate collector.
+	SinkExporter = "otlp/collector-test-sink"
+
+	// SinkMetricsPort is the port of the internal metrics of the sink collector, which report the received data.
+	SinkMetricsPort = 8888
+
+	sinkConfig = `receivers:
+  otlp:
+    protocols:
+      grpc: {}
+      http: {}
+exporters:
+  debug: {}
+service:
+  telemetry:
+    metrics:
+      address: 0.0.0.0:8888
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+    metrics:
+      receivers: [otlp]
+      exporters: [debug]
+    logs:
+      receivers: [otlp]
+      exporters: [debug]
+`
+)
+
+// Sink builds the collector receiving the data exported by the candidate collector.
+func Sink(params Params) (*v1beta1.OpenTelemetryCollector, error) {
+	cfg := v1beta1.Config{}
+	if err := yaml.Unmarshal([]byte(sinkConfig), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to build the sink configuration: %w", err)
+	}
+	return &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.CollectorTestSink(params.Test.Name),
+			Namespace: params.Test.Namespace,
+			Labels:    Labels(params.Test),
+			// the test reaches the collector by its default names, whatever the collector name template
+			Annotations: map[string]string{
+				v1beta1.ResourceNameAnnotation: naming.Collector(naming.CollectorTestSink(params.Test.Name)),
+			},
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:   v1beta1.ModeDeployment,
+			Config: cfg,
+		},
+	}, nil
+}
+
+// Candidate builds the collector running the configuration under test, exporting the data to the sink instead of
+// its exporters.
+func Candidate(params Params) (*v1beta1.OpenTelemetryCollector, error) {
+	cfg := v1beta1.Config{}
+	if err := yaml.Unmarshal([]byte(params.Test.Spec.Config), &cfg); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse the config: %s", ErrInvalidTest, err)
+	}
+	if len(cfg.Service.Pipelines) == 0 {
+		return nil, fmt.Errorf("%w: the config has no pipelines", ErrInvalidTest)
+	}
+
+	connectors := map[string]struct{}{}
+	if cfg.Connectors != nil {
+		for name := range cfg.Connectors.Object {
+			connectors[name] = struct{}{}
+		}
+	}
+	for _, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		// the connectors are kept to test the pipelines they connect, the pipelines which only export to
+		// connectors don't export to the sink
+		var exporters []string
+		replaced := false
+		for _, exporter := range pipeline.Exporters {
+			if _, ok := connectors[exporter]; ok {
+				exporters = append(exporters, exporter)
+			} else {
+				replaced = true
+			}
+		}
+		if replaced {
+			exporters = append(exporters, SinkExporter)
+		}
+		pipeline.Exporters = exporters
+	}
+
+	if cfg.Exporters.Object == nil {
+		cfg.Exporters.Object = map[string]interface{}{}
+	}
+	cfg.Exporters.Object[SinkExporter] = map[string]interface{}{
+		"endpoint": fmt.Sprintf("%s:4317", naming.Service(naming.CollectorTestSink(params.Test.Name))),
+		"tls": map[string]interface{}{
+			"insecure": true,
+		},
+	}
+
+	return &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.CollectorTestCandidate(params.Test.Name),
+			Namespace: params.Test.Namespace,
+			Labels:    Labels(params.Test),
+			// the test reaches the collector by its default names, whatever the collector name template
+			Annotations: map[string]string{
+				v1beta1.ResourceNameAnnotation: naming.Collector(naming.CollectorTestCandidate(params.Test.Name)),
+			},
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Image: params.Test.Spec.Image,
+			},
+			Mode:   v1beta1.ModeDeployment,
+			Config: cfg,
+		},
+	}, nil
+}
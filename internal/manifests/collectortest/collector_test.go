@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func testParams(cfg string) Params {
+	return Params{
+		Config: config.New(config.WithTelemetrygenImage("telemetrygen:latest")),
+		Test: v1alpha1.OpenTelemetryCollectorTest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-test",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.OpenTelemetryCollectorTestSpec{
+				Config: cfg,
+				Inputs: []v1alpha1.CollectorTestInput{{Signal: v1alpha1.CollectorTestSignalTraces}},
+			},
+		},
+	}
+}
+
+func TestCandidate(t *testing.T) {
+	params := testParams(`receivers:
+  otlp:
+    protocols:
+      grpc: {}
+processors:
+  batch: {}
+exporters:
+  otlp:
+    endpoint: backend:4317
+  debug: {}
+connectors:
+  spanmetrics: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [otlp, spanmetrics]
+    metrics:
+      receivers: [spanmetrics]
+      exporters: [debug]
+    traces/connected:
+      receivers: [otlp]
+      exporters: [spanmetrics]
+`)
+
+	candidate, err := Candidate(params)
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-test-candidate", candidate.Name)
+	assert.Equal(t, "my-test-candidate-collector", candidate.Annotations[v1beta1.ResourceNameAnnotation])
+	assert.Equal(t, "my-test", candidate.Labels[TestLabel])
+	pipelines := candidate.Spec.Config.Service.Pipelines
+	assert.Equal(t, []string{"spanmetrics", SinkExporter}, pipelines["traces"].Exporters)
+	assert.Equal(t, []string{SinkExporter}, pipelines["metrics"].Exporters)
+	assert.Equal(t, []string{"spanmetrics"}, pipelines["traces/connected"].Exporters)
+	assert.Equal(t, []string{"batch"}, pipelines["traces"].Processors)
+	assert.Equal(t, map[string]interface{}{
+		"endpoint": "my-test-sink-collector:4317",
+		"tls": map[string]interface{}{
+			"insecure": true,
+		},
+	}, candidate.Spec.Config.Exporters.Object[SinkExporter])
+}
+
+func TestCandidateInvalidConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		cfg  string
+	}{
+		{
+			desc: "not yaml",
+			cfg:  "receivers: [",
+		},
+		{
+			desc: "no pipelines",
+			cfg: `receivers:
+  otlp: {}
+exporters:
+  debug: {}
+`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := Candidate(testParams(tt.cfg))
+			assert.ErrorIs(t, err, ErrInvalidTest)
+		})
+	}
+}
+
+func TestSink(t *testing.T) {
+	sink, err := Sink(testParams(""))
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-test-sink", sink.Name)
+	assert.Equal(t, "my-test-sink-collector", sink.Annotations[v1beta1.ResourceNameAnnotation])
+	assert.Len(t, sink.Spec.Config.Service.Pipelines, 3)
+}
+
+func TestBuild(t *testing.T) {
+	params := testParams(`receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`)
+
+	objects, err := Build(params)
+	require.NoError(t, err)
+	assert.Len(t, objects, 2)
+
+	params.CollectorsReady = true
+	objects, err = Build(params)
+	require.NoError(t, err)
+	assert.Len(t, objects, 3)
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const (
+	ComponentOpenTelemetryCollectorTest = "opentelemetry-collector-test"
+
+	// TestLabel is set on the objects generated for a test, with the name of the test as value.
+	TestLabel = "opentelemetry.io/collector-test"
+)
+
+// ErrInvalidTest is returned when the manifests of a test can't be built from its spec.
+var ErrInvalidTest = errors.New("invalid test")
+
+// Params holds the reconciliation-specific parameters of a test.
+type Params struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Config   config.Config
+	Test     v1alpha1.OpenTelemetryCollectorTest
+	// CollectorsReady is true once the candidate and the sink collectors can receive data.
+	CollectorsReady bool
+}
+
+// Build creates the manifests of the collectors of the test, and of the job sending the synthetic data once the
+// collectors are ready.
+func Build(params Params) ([]client.Object, error) {
+	sink, err := Sink(params)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := Candidate(params)
+	if err != nil {
+		return nil, err
+	}
+	objects := []client.Object{sink, candidate}
+	if params.CollectorsReady {
+		objects = append(objects, Job(params))
+	}
+	return objects, nil
+}
+
+// Labels returns the labels of the objects generated for the test.
+func Labels(test v1alpha1.OpenTelemetryCollectorTest) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   test.Name,
+		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/component":  ComponentOpenTelemetryCollectorTest,
+		TestLabel:                      test.Name,
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"fmt"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// jobBackoffLimit is the number of retries of the job, e.g. when the candidate collector is not reachable yet.
+const jobBackoffLimit int32 = 2
+
+// Job builds the job sending the synthetic data to the candidate collector, with a container per input.
+func Job(params Params) *batchv1.Job {
+	backoffLimit := jobBackoffLimit
+	var containers []corev1.Container
+	for i, input := range params.Test.Spec.Inputs {
+		containers = append(containers, corev1.Container{
+			Name:  fmt.Sprintf("%s-%d", input.Signal, i),
+			Image: params.Config.TelemetrygenImage(),
+			Args:  telemetrygenArgs(params.Test, input),
+		})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.CollectorTestJob(params.Test.Name),
+			Namespace: params.Test.Namespace,
+			Labels:    Labels(params.Test),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: Labels(params.Test),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    containers,
+				},
+			},
+		},
+	}
+}
+
+func telemetrygenArgs(test v1alpha1.OpenTelemetryCollectorTest, input v1alpha1.CollectorTestInput) []string {
+	count := input.Count
+	if count == 0 {
+		count = 10
+	}
+	endpoint := input.Endpoint
+	if endpoint == "" {
+		port := 4317
+		if input.Protocol == v1alpha1.CollectorTestProtocolHTTP {
+			port = 4318
+		}
+		endpoint = fmt.Sprintf("%s:%d", naming.Service(naming.CollectorTestCandidate(test.Name)), port)
+	}
+
+	args := []string{
+		string(input.Signal),
+		fmt.Sprintf("--%s=%d", input.Signal, count),
+		fmt.Sprintf("--otlp-endpoint=%s", endpoint),
+		"--otlp-insecure",
+	}
+	if input.Protocol == v1alpha1.CollectorTestProtocolHTTP {
+		args = append(args, "--otlp-http")
+	}
+
+	keys := make([]string, 0, len(input.Attributes))
+	for key := range input.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, fmt.Sprintf("--telemetry-attributes=%s=%q", key, input.Attributes[key]))
+	}
+	return args
+}
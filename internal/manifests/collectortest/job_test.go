@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestJob(t *testing.T) {
+	params := testParams("")
+	params.Test.Spec.Inputs = []v1alpha1.CollectorTestInput{
+		{Signal: v1alpha1.CollectorTestSignalTraces, Count: 5, Protocol: v1alpha1.CollectorTestProtocolGRPC},
+		{Signal: v1alpha1.CollectorTestSignalLogs, Count: 3, Protocol: v1alpha1.CollectorTestProtocolHTTP},
+	}
+
+	job := Job(params)
+
+	assert.Equal(t, "my-test-collector-test", job.Name)
+	containers := job.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "traces-0", containers[0].Name)
+	assert.Equal(t, "telemetrygen:latest", containers[0].Image)
+	assert.Equal(t, "logs-1", containers[1].Name)
+}
+
+func TestTelemetrygenArgs(t *testing.T) {
+	test := testParams("").Test
+	for _, tt := range []struct {
+		desc     string
+		input    v1alpha1.CollectorTestInput
+		expected []string
+	}{
+		{
+			desc:  "defaults",
+			input: v1alpha1.CollectorTestInput{Signal: v1alpha1.CollectorTestSignalTraces},
+			expected: []string{
+				"traces",
+				"--traces=10",
+				"--otlp-endpoint=my-test-candidate-collector:4317",
+				"--otlp-insecure",
+			},
+		},
+		{
+			desc: "http with attributes",
+			input: v1alpha1.CollectorTestInput{
+				Signal:     v1alpha1.CollectorTestSignalMetrics,
+				Count:      2,
+				Protocol:   v1alpha1.CollectorTestProtocolHTTP,
+				Attributes: map[string]string{"env": "test", "app": "checkout"},
+			},
+			expected: []string{
+				"metrics",
+				"--metrics=2",
+				"--otlp-endpoint=my-test-candidate-collector:4318",
+				"--otlp-insecure",
+				"--otlp-http",
+				`--telemetry-attributes=app="checkout"`,
+				`--telemetry-attributes=env="test"`,
+			},
+		},
+		{
+			desc: "custom endpoint",
+			input: v1alpha1.CollectorTestInput{
+				Signal:   v1alpha1.CollectorTestSignalLogs,
+				Count:    1,
+				Endpoint: "my-test-candidate-collector:4319",
+			},
+			expected: []string{
+				"logs",
+				"--logs=1",
+				"--otlp-endpoint=my-test-candidate-collector:4319",
+				"--otlp-insecure",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, telemetrygenArgs(test, tt.input))
+		})
+	}
+}
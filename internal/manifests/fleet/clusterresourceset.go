@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"errors"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	// CollectorFilename is the key of the collector manifest in the ConfigMaps applied by the ClusterResourceSets.
+	CollectorFilename = "opentelemetrycollector.yaml"
+)
+
+var errEmptyClusterSelector = errors.New("the clusterapi provider requires a non-empty clusterSelector")
+
+// ClusterResourceSets returns, for each namespace containing selected Clusters, a ConfigMap holding the collector of
+// the fleet and a ClusterResourceSet applying it to the selected Clusters. The ConfigMaps can also be used as
+// the source of other delivery mechanisms, e.g. Argo CD ApplicationSets.
+func ClusterResourceSets(params Params) ([]client.Object, error) {
+	selector := params.Fleet.Spec.ClusterSelector
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return nil, errEmptyClusterSelector
+	}
+	clusterSelector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(selector)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := collectorManifest(params.Fleet)
+	if err != nil {
+		return nil, err
+	}
+	collectorYAML, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string]bool{}
+	for _, cluster := range params.Clusters {
+		namespaces[cluster.GetNamespace()] = true
+	}
+	var sortedNamespaces []string
+	for namespace := range namespaces {
+		sortedNamespaces = append(sortedNamespaces, namespace)
+	}
+	sort.Strings(sortedNamespaces)
+
+	name := naming.CollectorFleet(params.Fleet.Name)
+	var objects []client.Object
+	for _, namespace := range sortedNamespaces {
+		objects = append(objects, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    Labels(params.Fleet),
+			},
+			Data: map[string]string{
+				CollectorFilename: string(collectorYAML),
+			},
+		})
+
+		crs := &unstructured.Unstructured{}
+		crs.SetGroupVersionKind(ClusterResourceSetGVK)
+		crs.SetName(name)
+		crs.SetNamespace(namespace)
+		crs.SetLabels(Labels(params.Fleet))
+		crs.Object["spec"] = map[string]interface{}{
+			"clusterSelector": runtime.DeepCopyJSONValue(clusterSelector),
+			"strategy":        "Reconcile",
+			"resources": []interface{}{
+				map[string]interface{}{
+					"name": name,
+					"kind": "ConfigMap",
+				},
+			},
+		}
+		objects = append(objects, crs)
+	}
+	return objects, nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// Collector returns the OpenTelemetryCollector rendered from the template of the fleet.
+func Collector(fleet v1alpha1.OpenTelemetryCollectorFleet) (*v1beta1.OpenTelemetryCollector, error) {
+	spec := v1beta1.OpenTelemetryCollectorSpec{}
+	decoder := json.NewDecoder(bytes.NewReader(fleet.Spec.Template.Spec.Raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("the fleet template spec is not a valid OpenTelemetryCollector spec: %w", err)
+	}
+
+	name := fleet.Spec.Template.Metadata.Name
+	if name == "" {
+		name = fleet.Name
+	}
+	return &v1beta1.OpenTelemetryCollector{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.GroupVersion.String(),
+			Kind:       "OpenTelemetryCollector",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   fleet.Spec.Template.Metadata.Namespace,
+			Labels:      fleet.Spec.Template.Metadata.Labels,
+			Annotations: fleet.Spec.Template.Metadata.Annotations,
+		},
+		Spec: spec,
+	}, nil
+}
+
+// collectorManifest returns the OpenTelemetryCollector of the fleet as a generic manifest, as embedded in the objects
+// distributing it to the managed clusters.
+func collectorManifest(fleet v1alpha1.OpenTelemetryCollectorFleet) (map[string]interface{}, error) {
+	collector, err := Collector(fleet)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(collector)
+	if err != nil {
+		return nil, err
+	}
+	manifest := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, err
+	}
+	// the status and server side metadata must not be applied to the managed clusters
+	delete(manifest, "status")
+	if metadata, ok := manifest["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	return manifest, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const (
+	ComponentOpenTelemetryCollectorFleet = "opentelemetry-collector-fleet"
+
+	// FleetLabel is set on the objects generated for a fleet, with the name of the fleet as value.
+	FleetLabel = "opentelemetry.io/collector-fleet"
+)
+
+var (
+	// ManagedClusterGVK is the kind of the Open Cluster Management clusters.
+	ManagedClusterGVK = schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1", Kind: "ManagedCluster"}
+	// ManifestWorkGVK is the kind of the Open Cluster Management resources applying manifests to a cluster.
+	ManifestWorkGVK = schema.GroupVersionKind{Group: "work.open-cluster-management.io", Version: "v1", Kind: "ManifestWork"}
+	// ClusterGVK is the kind of the Cluster API clusters.
+	ClusterGVK = schema.GroupVersionKind{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"}
+	// ClusterResourceSetGVK is the kind of the Cluster API resources applying manifests to the selected clusters.
+	ClusterResourceSetGVK = schema.GroupVersionKind{Group: "addons.cluster.x-k8s.io", Version: "v1beta1", Kind: "ClusterResourceSet"}
+	// ClusterResourceSetBindingGVK is the kind of the Cluster API resources reporting the manifests applied to a cluster.
+	ClusterResourceSetBindingGVK = schema.GroupVersionKind{Group: "addons.cluster.x-k8s.io", Version: "v1beta1", Kind: "ClusterResourceSetBinding"}
+)
+
+// Params holds the reconciliation-specific parameters of a fleet.
+type Params struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Config   config.Config
+	Fleet    v1alpha1.OpenTelemetryCollectorFleet
+	// Clusters are the managed clusters selected by the fleet.
+	Clusters []unstructured.Unstructured
+}
+
+// Build creates the manifests distributing the collector of the fleet to the selected clusters.
+func Build(params Params) ([]client.Object, error) {
+	switch params.Fleet.Spec.Provider {
+	case v1alpha1.FleetClusterProviderClusterAPI:
+		return ClusterResourceSets(params)
+	default:
+		return ManifestWorks(params)
+	}
+}
+
+// ClusterKind returns the kind of the managed clusters selected by the fleet.
+func ClusterKind(fleet v1alpha1.OpenTelemetryCollectorFleet) schema.GroupVersionKind {
+	if fleet.Spec.Provider == v1alpha1.FleetClusterProviderClusterAPI {
+		return ClusterGVK
+	}
+	return ManagedClusterGVK
+}
+
+// Labels returns the labels of the objects generated for the fleet.
+func Labels(fleet v1alpha1.OpenTelemetryCollectorFleet) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   fleet.Name,
+		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/component":  ComponentOpenTelemetryCollectorFleet,
+		FleetLabel:                     fleet.Name,
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func newFleet(provider v1alpha1.FleetClusterProvider, spec string) v1alpha1.OpenTelemetryCollectorFleet {
+	return v1alpha1.OpenTelemetryCollectorFleet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "edge",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorFleetSpec{
+			Provider: provider,
+			ClusterSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"environment": "production"},
+			},
+			Template: v1alpha1.OpenTelemetryCollectorFleetTemplate{
+				Metadata: v1alpha1.FleetTemplateMetadata{
+					Namespace: "observability",
+					Labels:    map[string]string{"team": "platform"},
+				},
+				Spec: runtime.RawExtension{Raw: []byte(spec)},
+			},
+		},
+	}
+}
+
+func newCluster(name, namespace string) unstructured.Unstructured {
+	cluster := unstructured.Unstructured{}
+	cluster.SetName(name)
+	cluster.SetNamespace(namespace)
+	return cluster
+}
+
+const collectorSpec = `{"mode": "daemonset", "config": {"receivers": {"otlp": {"protocols": {"grpc": {}}}}, "exporters": {"debug": {}}, "service": {"pipelines": {"traces": {"receivers": ["otlp"], "exporters": ["debug"]}}}}}`
+
+func TestCollector(t *testing.T) {
+	t.Run("should render the collector from the template", func(t *testing.T) {
+		collector, err := Collector(newFleet(v1alpha1.FleetClusterProviderOCM, collectorSpec))
+		require.NoError(t, err)
+
+		assert.Equal(t, "opentelemetry.io/v1beta1", collector.APIVersion)
+		assert.Equal(t, "OpenTelemetryCollector", collector.Kind)
+		assert.Equal(t, "edge", collector.Name)
+		assert.Equal(t, "observability", collector.Namespace)
+		assert.Equal(t, "platform", collector.Labels["team"])
+		assert.Equal(t, v1beta1.ModeDaemonSet, collector.Spec.Mode)
+		assert.Contains(t, collector.Spec.Config.Receivers.Object, "otlp")
+	})
+
+	t.Run("should fail on unknown fields", func(t *testing.T) {
+		_, err := Collector(newFleet(v1alpha1.FleetClusterProviderOCM, `{"mode": "daemonset", "replica": 2}`))
+		assert.ErrorContains(t, err, "is not a valid OpenTelemetryCollector spec")
+	})
+}
+
+func TestManifestWorks(t *testing.T) {
+	params := Params{
+		Fleet:    newFleet(v1alpha1.FleetClusterProviderOCM, collectorSpec),
+		Clusters: []unstructured.Unstructured{newCluster("cluster-a", ""), newCluster("cluster-b", "")},
+	}
+
+	objects, err := Build(params)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	for i, cluster := range []string{"cluster-a", "cluster-b"} {
+		work, ok := objects[i].(*unstructured.Unstructured)
+		require.True(t, ok)
+		assert.Equal(t, ManifestWorkGVK, work.GroupVersionKind())
+		assert.Equal(t, "edge-collector-fleet", work.GetName())
+		assert.Equal(t, cluster, work.GetNamespace())
+		assert.Equal(t, "edge", work.GetLabels()[FleetLabel])
+
+		manifests, found, err := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Len(t, manifests, 1)
+		manifest := manifests[0].(map[string]interface{})
+		assert.Equal(t, "OpenTelemetryCollector", manifest["kind"])
+		assert.NotContains(t, manifest, "status")
+		assert.NotContains(t, manifest["metadata"], "creationTimestamp")
+	}
+}
+
+func TestClusterResourceSets(t *testing.T) {
+	t.Run("should create a ConfigMap and a ClusterResourceSet per namespace", func(t *testing.T) {
+		params := Params{
+			Fleet: newFleet(v1alpha1.FleetClusterProviderClusterAPI, collectorSpec),
+			Clusters: []unstructured.Unstructured{
+				newCluster("cluster-b", "team-b"),
+				newCluster("cluster-a", "team-a"),
+				newCluster("cluster-c", "team-a"),
+			},
+		}
+
+		objects, err := Build(params)
+		require.NoError(t, err)
+		require.Len(t, objects, 4)
+
+		for i, namespace := range []string{"team-a", "team-b"} {
+			cm, ok := objects[2*i].(*corev1.ConfigMap)
+			require.True(t, ok)
+			assert.Equal(t, "edge-collector-fleet", cm.Name)
+			assert.Equal(t, namespace, cm.Namespace)
+			collector := v1beta1.OpenTelemetryCollector{}
+			require.NoError(t, yaml.Unmarshal([]byte(cm.Data[CollectorFilename]), &collector))
+			assert.Equal(t, "edge", collector.Name)
+			assert.Equal(t, v1beta1.ModeDaemonSet, collector.Spec.Mode)
+
+			crs, ok := objects[2*i+1].(*unstructured.Unstructured)
+			require.True(t, ok)
+			assert.Equal(t, ClusterResourceSetGVK, crs.GroupVersionKind())
+			assert.Equal(t, namespace, crs.GetNamespace())
+			matchLabels, _, _ := unstructured.NestedStringMap(crs.Object, "spec", "clusterSelector", "matchLabels")
+			assert.Equal(t, map[string]string{"environment": "production"}, matchLabels)
+			resources, _, _ := unstructured.NestedSlice(crs.Object, "spec", "resources")
+			assert.Equal(t, []interface{}{map[string]interface{}{"name": "edge-collector-fleet", "kind": "ConfigMap"}}, resources)
+		}
+	})
+
+	t.Run("should require a cluster selector", func(t *testing.T) {
+		fleet := newFleet(v1alpha1.FleetClusterProviderClusterAPI, collectorSpec)
+		fleet.Spec.ClusterSelector = nil
+		_, err := Build(Params{Fleet: fleet})
+		assert.ErrorIs(t, err, errEmptyClusterSelector)
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// ManifestWorks returns a ManifestWork per selected ManagedCluster, applying the collector of the fleet to the cluster.
+// The ManifestWorks are created in the namespace of the ManagedClusters, as expected by Open Cluster Management.
+func ManifestWorks(params Params) ([]client.Object, error) {
+	manifest, err := collectorManifest(params.Fleet)
+	if err != nil {
+		return nil, err
+	}
+
+	var works []client.Object
+	for _, cluster := range params.Clusters {
+		work := &unstructured.Unstructured{}
+		work.SetGroupVersionKind(ManifestWorkGVK)
+		work.SetName(naming.CollectorFleet(params.Fleet.Name))
+		work.SetNamespace(cluster.GetName())
+		work.SetLabels(Labels(params.Fleet))
+		work.Object["spec"] = map[string]interface{}{
+			"workload": map[string]interface{}{
+				"manifests": []interface{}{runtime.DeepCopyJSONValue(manifest)},
+			},
+		}
+		works = append(works, work)
+	}
+	return works, nil
+}
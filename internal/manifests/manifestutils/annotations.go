@@ -23,7 +23,8 @@ import (
 )
 
 // Annotations return the annotations for OpenTelemetryCollector pod.
-func Annotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []string) (map[string]string, error) {
+// The configHash should be calculated using collector.GetCollectorConfigSHA.
+func Annotations(instance v1beta1.OpenTelemetryCollector, configHash string, filterAnnotations []string) map[string]string {
 	// new map every time, so that we don't touch the instance's annotations
 	annotations := map[string]string{}
 
@@ -44,19 +45,15 @@ func Annotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []st
 		}
 	}
 
-	hash, err := GetConfigMapSHA(instance.Spec.Config)
-	if err != nil {
-		return nil, err
-	}
-
 	// make sure sha256 for configMap is always calculated
-	annotations["opentelemetry-operator-config/sha256"] = hash
+	annotations["opentelemetry-operator-config/sha256"] = configHash
 
-	return annotations, nil
+	return annotations
 }
 
 // PodAnnotations return the spec annotations for OpenTelemetryCollector pod.
-func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []string) (map[string]string, error) {
+// The configHash should be calculated using collector.GetCollectorConfigSHA.
+func PodAnnotations(instance v1beta1.OpenTelemetryCollector, configHash string, filterAnnotations []string) map[string]string {
 	// new map every time, so that we don't touch the instance's annotations
 	podAnnotations := map[string]string{}
 	if nil != instance.Spec.PodAnnotations {
@@ -67,10 +64,7 @@ func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations [
 		}
 	}
 
-	annotations, err := Annotations(instance, filterAnnotations)
-	if err != nil {
-		return nil, err
-	}
+	annotations := Annotations(instance, configHash, filterAnnotations)
 	// propagating annotations from metadata.annotations
 	for kMeta, vMeta := range annotations {
 		if _, found := podAnnotations[kMeta]; !found {
@@ -78,14 +72,10 @@ func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations [
 		}
 	}
 
-	hash, err := GetConfigMapSHA(instance.Spec.Config)
-	if err != nil {
-		return nil, err
-	}
 	// make sure sha256 for configMap is always calculated
-	podAnnotations["opentelemetry-operator-config/sha256"] = hash
+	podAnnotations["opentelemetry-operator-config/sha256"] = configHash
 
-	return podAnnotations, nil
+	return podAnnotations
 }
 
 func GetConfigMapSHA(config v1beta1.Config) (string, error) {
@@ -96,3 +86,53 @@ func GetConfigMapSHA(config v1beta1.Config) (string, error) {
 	h := sha256.Sum256(b)
 	return fmt.Sprintf("%x", h), nil
 }
+
+// GetScrapeConfigsSHA returns the hash of the scrape configs of the prometheus receiver of the collector, which are
+// served by the target allocator, or an empty string when there are none.
+func GetScrapeConfigsSHA(instance v1beta1.OpenTelemetryCollector) (string, error) {
+	promConfig, ok := prometheusConfig(instance.Spec.Config.Receivers.Object)
+	if !ok || promConfig["scrape_configs"] == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(promConfig["scrape_configs"])
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return fmt.Sprintf("%x", h), nil
+}
+
+// WithoutScrapeConfigs returns a copy of the receivers without the scrape configs of the prometheus receiver.
+// The receivers are left untouched.
+func WithoutScrapeConfigs(receivers map[string]interface{}) map[string]interface{} {
+	promConfig, ok := prometheusConfig(receivers)
+	if !ok {
+		return receivers
+	}
+	updatedConfig := map[string]interface{}{}
+	for k, v := range promConfig {
+		if k != "scrape_configs" {
+			updatedConfig[k] = v
+		}
+	}
+	updatedPrometheus := map[string]interface{}{}
+	for k, v := range receivers["prometheus"].(map[string]interface{}) {
+		updatedPrometheus[k] = v
+	}
+	updatedPrometheus["config"] = updatedConfig
+	updatedReceivers := map[string]interface{}{}
+	for k, v := range receivers {
+		updatedReceivers[k] = v
+	}
+	updatedReceivers["prometheus"] = updatedPrometheus
+	return updatedReceivers
+}
+
+func prometheusConfig(receivers map[string]interface{}) (map[string]interface{}, bool) {
+	prometheus, ok := receivers["prometheus"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	config, ok := prometheus["config"].(map[string]interface{})
+	return config, ok
+}
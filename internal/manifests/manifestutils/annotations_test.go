@@ -44,10 +44,10 @@ func TestDefaultAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations, err := Annotations(otelcol, []string{})
-	require.NoError(t, err)
-	podAnnotations, err := PodAnnotations(otelcol, []string{})
+	hash, err := GetConfigMapSHA(otelcol.Spec.Config)
 	require.NoError(t, err)
+	annotations := Annotations(otelcol, hash, []string{})
+	podAnnotations := PodAnnotations(otelcol, hash, []string{})
 
 	//verify
 	assert.Equal(t, "true", annotations["prometheus.io/scrape"])
@@ -78,10 +78,10 @@ func TestNonDefaultPodAnnotation(t *testing.T) {
 	}
 
 	// test
-	annotations, err := Annotations(otelcol, []string{})
-	require.NoError(t, err)
-	podAnnotations, err := PodAnnotations(otelcol, []string{})
+	hash, err := GetConfigMapSHA(otelcol.Spec.Config)
 	require.NoError(t, err)
+	annotations := Annotations(otelcol, hash, []string{})
+	podAnnotations := PodAnnotations(otelcol, hash, []string{})
 
 	//verify
 	assert.NotContains(t, annotations, "prometheus.io/scrape", "Prometheus scrape annotation should not exist")
@@ -120,10 +120,10 @@ func TestUserAnnotations(t *testing.T) {
 	}
 
 	// test
-	annotations, err := Annotations(otelcol, []string{})
-	require.NoError(t, err)
-	podAnnotations, err := PodAnnotations(otelcol, []string{})
+	hash, err := GetConfigMapSHA(otelcol.Spec.Config)
 	require.NoError(t, err)
+	annotations := Annotations(otelcol, hash, []string{})
+	podAnnotations := PodAnnotations(otelcol, hash, []string{})
 
 	//verify
 	assert.Equal(t, "false", annotations["prometheus.io/scrape"])
@@ -147,10 +147,10 @@ func TestAnnotationsPropagateDown(t *testing.T) {
 	}
 
 	// test
-	annotations, err := Annotations(otelcol, []string{})
-	require.NoError(t, err)
-	podAnnotations, err := PodAnnotations(otelcol, []string{})
+	hash, err := GetConfigMapSHA(otelcol.Spec.Config)
 	require.NoError(t, err)
+	annotations := Annotations(otelcol, hash, []string{})
+	podAnnotations := PodAnnotations(otelcol, hash, []string{})
 
 	// verify
 	assert.Len(t, annotations, 5)
@@ -174,10 +174,9 @@ func TestAnnotationsFilter(t *testing.T) {
 	}
 
 	// This requires the filter to be in regex match form and not the other simpler wildcard one.
-	annotations, err := Annotations(otelcol, []string{".*\\.bar\\.io"})
+	annotations := Annotations(otelcol, "", []string{".*\\.bar\\.io"})
 
 	// verify
-	require.NoError(t, err)
 	assert.Len(t, annotations, 6)
 	assert.NotContains(t, annotations, "test.bar.io")
 	assert.Equal(t, "1234", annotations["test.io/port"])
@@ -26,3 +26,12 @@ func GetDNSPolicy(hostNetwork bool) corev1.DNSPolicy {
 	}
 	return dnsPolicy
 }
+
+// GetDNSPolicyWithOverride returns the given DNS policy when set, otherwise the default one depending on whether
+// we're using a host network.
+func GetDNSPolicyWithOverride(dnsPolicy corev1.DNSPolicy, hostNetwork bool) corev1.DNSPolicy {
+	if dnsPolicy != "" {
+		return dnsPolicy
+	}
+	return GetDNSPolicy(hostNetwork)
+}
@@ -24,13 +24,17 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
 var (
@@ -55,6 +59,7 @@ var (
 // - HorizontalPodAutoscaler
 // - Route
 // - Secret
+// - Unstructured, for the resources of APIs the operator doesn't depend on
 // In order for the operator to reconcile other types, they must be added here.
 // The function returned takes no arguments but instead uses the existing and desired inputs here. Existing is expected
 // to be set by the controller-runtime package through a client get call.
@@ -166,6 +171,14 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantPr := desired.(*corev1.Secret)
 			mutateSecret(pr, wantPr)
 
+		case *unstructured.Unstructured:
+			u := existing.(*unstructured.Unstructured)
+			wantU := desired.(*unstructured.Unstructured)
+			mutateUnstructured(u, wantU)
+
+		case *v1beta1.OpenTelemetryCollector, *batchv1.Job:
+			// the collectors and the jobs of a test run or an exporter check run once, their spec is not updated
+
 		default:
 			t := reflect.TypeOf(existing).String()
 			return fmt.Errorf("missing mutate implementation for resource type: %s", t)
@@ -188,6 +201,15 @@ func mutateSecret(existing, desired *corev1.Secret) {
 	existing.Data = desired.Data
 }
 
+func mutateUnstructured(existing, desired *unstructured.Unstructured) {
+	for key, value := range desired.Object {
+		if key == "apiVersion" || key == "kind" || key == "metadata" || key == "status" {
+			continue
+		}
+		existing.Object[key] = value
+	}
+}
+
 func mutateConfigMap(existing, desired *corev1.ConfigMap) {
 	existing.BinaryData = desired.BinaryData
 	existing.Data = desired.Data
@@ -263,6 +285,19 @@ func mutatePodMonitor(existing, desired *monitoringv1.PodMonitor) {
 func mutateService(existing, desired *corev1.Service) {
 	existing.Spec.Ports = desired.Spec.Ports
 	existing.Spec.Selector = desired.Spec.Selector
+	if desired.Spec.Type != "" {
+		existing.Spec.Type = desired.Spec.Type
+	}
+	if desired.Spec.InternalTrafficPolicy != nil {
+		existing.Spec.InternalTrafficPolicy = desired.Spec.InternalTrafficPolicy
+	}
+	// the API server defaults the IP families of a Service, so only override them when explicitly requested
+	if len(desired.Spec.IPFamilies) > 0 {
+		existing.Spec.IPFamilies = desired.Spec.IPFamilies
+	}
+	if desired.Spec.IPFamilyPolicy != nil {
+		existing.Spec.IPFamilyPolicy = desired.Spec.IPFamilyPolicy
+	}
 }
 
 func mutateDaemonset(existing, desired *appsv1.DaemonSet) error {
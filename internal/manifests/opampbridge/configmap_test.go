@@ -18,11 +18,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func expectedLabels() map[string]string {
@@ -130,3 +133,27 @@ headers:
 		})
 	}
 }
+
+func TestDesiredConfigMapAuditLog(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableAuditLog.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableAuditLog.ID(), false))
+	})
+
+	params := manifests.Params{
+		Config: config.New(),
+		OpAMPBridge: v1alpha1.OpAMPBridge{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1alpha1.OpAMPBridgeSpec{
+				Endpoint: "ws://opamp-server:4320/v1/opamp",
+			},
+		},
+	}
+
+	actual, err := ConfigMap(params)
+	require.NoError(t, err)
+	assert.Equal(t, "auditLog: true\nendpoint: ws://opamp-server:4320/v1/opamp\n", actual.Data["remoteconfiguration.yaml"])
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
@@ -56,6 +57,32 @@ func TestContainerWithImageOverridden(t *testing.T) {
 	assert.Equal(t, "overridden-image", c.Image)
 }
 
+func TestContainerSecurityContext(t *testing.T) {
+	// prepare
+	runAsNonRoot := true
+	securityContext := &corev1.SecurityContext{
+		RunAsNonRoot: &runAsNonRoot,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+		AppArmorProfile: &corev1.AppArmorProfile{
+			Type: corev1.AppArmorProfileTypeRuntimeDefault,
+		},
+	}
+	opampBridge := v1alpha1.OpAMPBridge{
+		Spec: v1alpha1.OpAMPBridgeSpec{
+			SecurityContext: securityContext,
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, opampBridge)
+
+	// verify
+	assert.Equal(t, securityContext, c.SecurityContext)
+}
+
 func TestContainerVolumes(t *testing.T) {
 	// prepare
 	opampBridge := v1alpha1.OpAMPBridge{
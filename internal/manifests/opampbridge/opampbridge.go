@@ -32,6 +32,8 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		manifests.Factory(ConfigMap),
 		manifests.FactoryWithoutError(ServiceAccount),
 		manifests.FactoryWithoutError(Service),
+		manifests.FactoryWithoutError(AuditLogRole),
+		manifests.FactoryWithoutError(AuditLogRoleBinding),
 	}
 	for _, factory := range resourceFactories {
 		res, err := factory(params)
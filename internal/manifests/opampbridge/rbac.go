@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opampbridge
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// AuditLogRole returns the role allowing the bridge to write the audit logs of the collectors of its namespace, or
+// nil when the audit log isn't enabled.
+func AuditLogRole(params manifests.Params) *rbacv1.Role {
+	if !featuregate.EnableAuditLog.IsEnabled() {
+		return nil
+	}
+	name := naming.OpAMPBridgeAuditLogRole(params.OpAMPBridge.Name)
+	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, []string{})
+
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OpAMPBridge.Namespace,
+			Labels:      labels,
+			Annotations: params.OpAMPBridge.Annotations,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "create", "update"},
+			},
+		},
+	}
+}
+
+// AuditLogRoleBinding binds the AuditLogRole to the service account of the bridge, or returns nil when the audit log
+// isn't enabled.
+func AuditLogRoleBinding(params manifests.Params) *rbacv1.RoleBinding {
+	if !featuregate.EnableAuditLog.IsEnabled() {
+		return nil
+	}
+	name := naming.OpAMPBridgeAuditLogRole(params.OpAMPBridge.Name)
+	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, name, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, []string{})
+
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OpAMPBridge.Namespace,
+			Labels:      labels,
+			Annotations: params.OpAMPBridge.Annotations,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      ServiceAccountName(params.OpAMPBridge),
+				Namespace: params.OpAMPBridge.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind:     "Role",
+			Name:     name,
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opampbridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestAuditLogRole(t *testing.T) {
+	params := manifests.Params{
+		OpAMPBridge: v1alpha1.OpAMPBridge{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+			Spec:       v1alpha1.OpAMPBridgeSpec{ServiceAccount: "my-special-sa"},
+		},
+	}
+	assert.Nil(t, AuditLogRole(params))
+	assert.Nil(t, AuditLogRoleBinding(params))
+
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableAuditLog.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableAuditLog.ID(), false))
+	})
+
+	role := AuditLogRole(params)
+	require.NotNil(t, role)
+	assert.Equal(t, "my-instance-opamp-bridge-audit-log", role.Name)
+	assert.Equal(t, "my-namespace", role.Namespace)
+	require.Len(t, role.Rules, 1)
+	assert.Equal(t, []string{"configmaps"}, role.Rules[0].Resources)
+	assert.ElementsMatch(t, []string{"get", "create", "update"}, role.Rules[0].Verbs)
+
+	binding := AuditLogRoleBinding(params)
+	require.NotNil(t, binding)
+	assert.Equal(t, role.Name, binding.RoleRef.Name)
+	assert.Equal(t, "Role", binding.RoleRef.Kind)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "my-special-sa", binding.Subjects[0].Name)
+	assert.Equal(t, "my-namespace", binding.Subjects[0].Namespace)
+}
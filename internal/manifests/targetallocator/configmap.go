@@ -67,6 +67,14 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 
 		prometheusCRConfig["pod_monitor_selector"] = taSpec.PrometheusCR.PodMonitorSelector
 
+		if taSpec.PrometheusCR.ServiceMonitorNamespaceSelector != nil {
+			prometheusCRConfig["service_monitor_namespace_selector"] = taSpec.PrometheusCR.ServiceMonitorNamespaceSelector
+		}
+
+		if taSpec.PrometheusCR.PodMonitorNamespaceSelector != nil {
+			prometheusCRConfig["pod_monitor_namespace_selector"] = taSpec.PrometheusCR.PodMonitorNamespaceSelector
+		}
+
 		taConfig["prometheus_cr"] = prometheusCRConfig
 	}
 
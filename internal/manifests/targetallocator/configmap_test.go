@@ -157,6 +157,63 @@ prometheus_cr:
 		assert.Equal(t, expectedLabels, actual.Labels)
 		assert.Equal(t, expectedData, actual.Data)
 
+	})
+	t.Run("should return expected target allocator config map with namespace selectors", func(t *testing.T) {
+		expectedLabels["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
+		expectedLabels["app.kubernetes.io/name"] = "my-instance-targetallocator"
+
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_strategy: consistent-hashing
+collector_selector:
+  matchlabels:
+    app.kubernetes.io/component: opentelemetry-collector
+    app.kubernetes.io/instance: default.my-instance
+    app.kubernetes.io/managed-by: opentelemetry-operator
+    app.kubernetes.io/part-of: opentelemetry
+  matchexpressions: []
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+filter_strategy: relabel-config
+prometheus_cr:
+  enabled: true
+  pod_monitor_namespace_selector:
+    matchlabels:
+      tenant: team-a
+    matchexpressions: []
+  pod_monitor_selector: null
+  service_monitor_namespace_selector:
+    matchlabels:
+      tenant: team-b
+    matchexpressions: []
+  service_monitor_selector: null
+`,
+		}
+		targetAllocator = targetAllocatorInstance()
+		targetAllocator.Spec.PrometheusCR.Enabled = true
+		targetAllocator.Spec.PrometheusCR.PodMonitorNamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"tenant": "team-a",
+			},
+		}
+		targetAllocator.Spec.PrometheusCR.ServiceMonitorNamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"tenant": "team-b",
+			},
+		}
+		params.TargetAllocator = targetAllocator
+		actual, err := ConfigMap(params)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "my-instance-targetallocator", actual.Name)
+		assert.Equal(t, expectedLabels, actual.Labels)
+		assert.Equal(t, expectedData, actual.Data)
+
 	})
 	t.Run("should return expected target allocator config map with scrape interval set", func(t *testing.T) {
 		expectedLabels["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
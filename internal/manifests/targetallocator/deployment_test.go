@@ -77,6 +77,12 @@ var runAsGroup int64 = 1000
 var testSecurityContextValue = &v1.PodSecurityContext{
 	RunAsUser:  &runAsUser,
 	RunAsGroup: &runAsGroup,
+	SeccompProfile: &v1.SeccompProfile{
+		Type: v1.SeccompProfileTypeRuntimeDefault,
+	},
+	AppArmorProfile: &v1.AppArmorProfile{
+		Type: v1.AppArmorProfileTypeRuntimeDefault,
+	},
 }
 
 func TestDeploymentSecurityContext(t *testing.T) {
@@ -15,8 +15,13 @@
 // Package naming is for determining the names for components (containers, services, ...).
 package naming
 
+import (
+	"fmt"
+	"hash/fnv"
+)
+
 // ConfigMap builds the name for the config map used in the OpenTelemetryCollector containers.
-// The configHash should be calculated using manifestutils.GetConfigMapSHA.
+// The configHash should be calculated using collector.GetCollectorConfigSHA.
 func ConfigMap(otelcol, configHash string) string {
 	return DNSName(Truncate("%s-collector-%s", 63, otelcol, configHash[:8]))
 }
@@ -41,6 +46,40 @@ func ConfigMapExtra(extraConfigMapName string) string {
 	return DNSName(Truncate("configmap-%s", 63, extraConfigMapName))
 }
 
+// CredentialsSecretVolume returns the name to use for the volume of a Secret holding the credentials of receivers.
+// The Secret names which have to be truncated or changed to be valid volume names get a hash suffix, so that they
+// don't collide.
+func CredentialsSecretVolume(secret string) string {
+	name := fmt.Sprintf("secret-%s", secret)
+	if len(name) <= 63 && DNSName(name) == name {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(secret))
+	return DNSName(Truncate("secret-%s-%08x", 63, secret, h.Sum32()))
+}
+
+// JaegerRemoteSamplingConfigMap returns the name for the config map holding the sampling strategies of the collector.
+func JaegerRemoteSamplingConfigMap(otelcol string) string {
+	return DNSName(Truncate("%s-collector-sampling", 63, otelcol))
+}
+
+// AuditLogConfigMap returns the name for the config map holding the audit log of the mutations of the collector.
+func AuditLogConfigMap(otelcol string) string {
+	return DNSName(Truncate("%s-collector-audit", 63, otelcol))
+}
+
+// UpgradeRollbackConfigMap returns the name for the config map holding the spec the collector is rolled back to if its
+// upgrade fails.
+func UpgradeRollbackConfigMap(otelcol string) string {
+	return DNSName(Truncate("%s-collector-rollback", 63, otelcol))
+}
+
+// JaegerRemoteSamplingVolume returns the name to use for the sampling strategies volume in the pod.
+func JaegerRemoteSamplingVolume() string {
+	return "otc-sampling"
+}
+
 // TAConfigMapVolume returns the name to use for the config map's volume in the TargetAllocator pod.
 func TAConfigMapVolume() string {
 	return "ta-internal"
@@ -56,6 +95,11 @@ func Container() string {
 	return "otc-container"
 }
 
+// SignalContainer returns the name to use for the container running the pipelines of the signal in the pod.
+func SignalContainer(container, signal string) string {
+	return fmt.Sprintf("%s-%s", container, signal)
+}
+
 // TAContainer returns the name to use for the container in the TargetAllocator pod.
 func TAContainer() string {
 	return "ta-container"
@@ -71,6 +115,16 @@ func Collector(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// CollectorZone builds the name of the deployment running the collector in the given zone.
+func CollectorZone(otelcol, zone string) string {
+	return DNSName(Truncate("%s-collector-%s", 63, otelcol, zone))
+}
+
+// CollectorColor builds the name of the deployment of the given color of a collector rolled out blue/green.
+func CollectorColor(otelcol, color string) string {
+	return DNSName(Truncate("%s-collector-%s", 63, otelcol, color))
+}
+
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
@@ -121,6 +175,11 @@ func Service(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// PortGroupService builds the name for the service exposing a port group of the instance.
+func PortGroupService(otelcol, portGroup string) string {
+	return DNSName(Truncate("%s-%s", 63, Service(otelcol), portGroup))
+}
+
 // Ingress builds the ingress name based on the instance.
 func Ingress(otelcol string) string {
 	return DNSName(Truncate("%s-ingress", 63, otelcol))
@@ -180,3 +239,35 @@ func TargetAllocatorServiceMonitor(otelcol string) string {
 func OpAMPBridgeServiceAccount(opampBridge string) string {
 	return DNSName(Truncate("%s-opamp-bridge", 63, opampBridge))
 }
+
+// OpAMPBridgeAuditLogRole builds the name of the role and role binding allowing the bridge to write the audit logs of
+// the collectors.
+func OpAMPBridgeAuditLogRole(opampBridge string) string {
+	return DNSName(Truncate("%s-opamp-bridge-audit-log", 63, opampBridge))
+}
+
+// CollectorFleet builds the name of the objects distributing the collector of a fleet to the managed clusters.
+func CollectorFleet(fleet string) string {
+	return DNSName(Truncate("%s-collector-fleet", 63, fleet))
+}
+
+// CollectorTestCandidate builds the name of the candidate collector of a test.
+func CollectorTestCandidate(test string) string {
+	return DNSName(Truncate("%s-candidate", 63, test))
+}
+
+// CollectorTestSink builds the name of the sink collector of a test.
+func CollectorTestSink(test string) string {
+	return DNSName(Truncate("%s-sink", 63, test))
+}
+
+// CollectorTestJob builds the name of the job sending the synthetic data of a test.
+func CollectorTestJob(test string) string {
+	return DNSName(Truncate("%s-collector-test", 63, test))
+}
+
+// ExporterCheckJob builds the name of the job checking the exporter endpoints of a version of the collector config.
+// The configHash should be calculated using collector.GetCollectorConfigSHA.
+func ExporterCheckJob(otelcol, configHash string) string {
+	return DNSName(Truncate("%s-exporter-check-%s", 63, otelcol, configHash[:8]))
+}
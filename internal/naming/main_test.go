@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package naming
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestCredentialsSecretVolume(t *testing.T) {
+	assert.Equal(t, "secret-postgres-credentials", CredentialsSecretVolume("postgres-credentials"))
+
+	long := strings.Repeat("a", 60)
+	names := map[string]bool{}
+	for _, secret := range []string{long + "-first", long + "-second", "db.credentials", "db-credentials"} {
+		name := CredentialsSecretVolume(secret)
+		assert.Empty(t, validation.IsDNS1123Label(name))
+		assert.False(t, names[name], "the name should be unique")
+		names[name] = true
+	}
+}
@@ -16,6 +16,7 @@ package naming
 
 import (
 	"fmt"
+	"hash/fnv"
 	"regexp"
 	"strings"
 )
@@ -23,8 +24,13 @@ import (
 var (
 	// DNS_LABEL constraints: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-label-names
 	dnsLabelValidation = regexp.MustCompile("^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$")
+	// characters not allowed in the prefix of the port names with a hash suffix
+	portNameInvalidChars = regexp.MustCompile("[^a-z0-9]+")
+	hasLetter            = regexp.MustCompile("[a-z]")
 )
 
+const portHashLen = 6
+
 // PortName defines the port name used in services, ingresses and routes.
 // The port name in pod and ingress spec has to be maximum 15 characters long.
 func PortName(receiverName string, port int32) string {
@@ -42,3 +48,22 @@ func PortName(receiverName string, port int32) string {
 	// matches the pattern and has less than 15 chars -- the candidate name is good to go!
 	return candidate
 }
+
+// PortNameWithHash defines a port name made of the beginning of the component name and of a hash of the component
+// name and port number, so that components with long or similar names get unique and stable port names.
+func PortNameWithHash(componentName string, port int32) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(fmt.Sprintf("%s:%d", componentName, port)))
+	hash := fmt.Sprintf("%08x", h.Sum32())[:portHashLen]
+
+	prefix := portNameInvalidChars.ReplaceAllString(strings.ToLower(componentName), "-")
+	if len(prefix) > 15-portHashLen-1 {
+		prefix = prefix[:15-portHashLen-1]
+	}
+	prefix = strings.Trim(prefix, "-")
+	// the port names have to contain at least one letter
+	if !hasLetter.MatchString(prefix) {
+		prefix = "port"
+	}
+	return fmt.Sprintf("%s-%s", prefix, hash)
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func Test(t *testing.T) {
@@ -66,3 +67,32 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+func TestPortNameWithHash(t *testing.T) {
+	tests := []struct {
+		componentName string
+		port          int32
+		prefix        string
+	}{
+		{componentName: "otlp/internal-traffic", port: 4317, prefix: "otlp-int-"},
+		{componentName: "otlp/internal-traffic-2", port: 4317, prefix: "otlp-int-"},
+		{componentName: "otlp_a", port: 4317, prefix: "otlp-a-"},
+		{componentName: "otlp/a-b", port: 4317, prefix: "otlp-a-b-"},
+		{componentName: "otlp/a_b", port: 4317, prefix: "otlp-a-b-"},
+		{componentName: "12345678/90", port: 4317, prefix: "port-"},
+		{componentName: "Zipkin//Custom", port: 9411, prefix: "zipkin-c-"},
+	}
+
+	names := map[string]bool{}
+	for _, test := range tests {
+		t.Run(test.componentName, func(t *testing.T) {
+			name := PortNameWithHash(test.componentName, test.port)
+			assert.Empty(t, validation.IsValidPortName(name))
+			assert.Regexp(t, "^"+test.prefix+"[0-9a-f]{6}$", name)
+			assert.Equal(t, name, PortNameWithHash(test.componentName, test.port), "the name should be stable")
+			assert.False(t, names[name], "the name should be unique")
+			names[name] = true
+		})
+	}
+	assert.NotEqual(t, PortNameWithHash("otlp", 4317), PortNameWithHash("otlp", 4318))
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// recordMutation records the mutation of the collector from before to after in its audit log, when enabled. The
+// mutation is already applied, failing to record it is only logged.
+func recordMutation(ctx context.Context, params manifests.Params, before, after client.Object, source, message string) {
+	if !featuregate.EnableAuditLog.IsEnabled() {
+		return
+	}
+	if err := auditlog.RecordMutation(ctx, params.Client, before, after, source, message); err != nil {
+		params.Log.Error(err, "failed to record the mutation of the OpenTelemetry CR in its audit log", "source", source)
+	}
+}
+
+// recordDefaulting records the defaultings of the collector by the admission webhook in its audit log, when enabled,
+// and removes them from the collector. The collector is only patched when it wasn't changed since it was read, the
+// defaultings added meanwhile are recorded by the next reconciliation.
+func recordDefaulting(ctx context.Context, params manifests.Params, otelcol *v1beta1.OpenTelemetryCollector) {
+	if !featuregate.EnableAuditLog.IsEnabled() {
+		return
+	}
+	entries, err := auditlog.PendingDefaultings(otelcol)
+	for i := 0; err == nil && i < len(entries); i++ {
+		err = auditlog.Record(ctx, params.Client, otelcol, entries[i])
+	}
+	if err != nil {
+		params.Log.Error(err, "failed to record the defaulting of the OpenTelemetry CR in its audit log")
+		return
+	}
+	if _, ok := otelcol.Annotations[auditlog.DefaultingAnnotation]; !ok {
+		return
+	}
+	recorded := otelcol.DeepCopy()
+	delete(recorded.Annotations, auditlog.DefaultingAnnotation)
+	if err := params.Client.Patch(ctx, recorded, client.MergeFromWithOptions(otelcol, client.MergeFromWithOptimisticLock{})); err != nil {
+		params.Log.V(2).Info("failed to remove the recorded defaultings from the OpenTelemetry CR", "error", err.Error())
+		return
+	}
+	*otelcol = *recorded
+}
+
+// auditLogStatus returns the summary of the audit log of the collector, or nil when it's not enabled or the collector
+// wasn't mutated by the operator.
+func auditLogStatus(ctx context.Context, cli client.Reader, otelcol *v1beta1.OpenTelemetryCollector) (*v1beta1.AuditLogStatus, error) {
+	if !featuregate.EnableAuditLog.IsEnabled() {
+		return nil, nil
+	}
+	entries, err := auditlog.Get(ctx, cli, otelcol)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	last := entries[len(entries)-1]
+	return &v1beta1.AuditLogStatus{
+		ConfigMap:           auditlog.ConfigMapName(otelcol),
+		Mutations:           int32(len(entries)),
+		LastMutationTime:    &last.Time,
+		LastMutationSource:  last.Source,
+		LastMutationMessage: last.Message,
+	}, nil
+}
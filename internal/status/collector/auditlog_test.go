@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func enableAuditLog(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableAuditLog.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableAuditLog.ID(), false))
+	})
+}
+
+func TestAuditLogRollback(t *testing.T) {
+	enableAuditLog(t)
+	otelcol := upgradingCollector(time.Now().Add(-time.Hour))
+	params := rollbackParams(t, otelcol.DeepCopy(), rollbackConfigMap(t), upgradingDeployment(0))
+	ctx := context.Background()
+
+	_, err := checkUpgrade(ctx, params, otelcol)
+	require.NoError(t, err)
+
+	entries, err := auditlog.Get(ctx, params.Client, otelcol)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, auditlog.SourceRollback, entries[0].Source)
+	assert.Contains(t, entries[0].Message, "rolled back to version 0.1.0")
+	assert.Contains(t, entries[0].Patch, `"image":"collector:0.1.0"`)
+
+	status, err := auditLogStatus(ctx, params.Client, otelcol)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, "test-collector-audit", status.ConfigMap)
+	assert.Equal(t, int32(1), status.Mutations)
+	assert.Equal(t, auditlog.SourceRollback, status.LastMutationSource)
+	assert.Equal(t, entries[0].Message, status.LastMutationMessage)
+}
+
+func TestAuditLogDefaulting(t *testing.T) {
+	enableAuditLog(t)
+	otelcol := upgradingCollector(time.Now())
+	require.NoError(t, auditlog.AddDefaulting(otelcol, `{"spec":{"replicas":1}}`))
+	require.NoError(t, auditlog.AddDefaulting(otelcol, `{"spec":{"mode":"deployment"}}`))
+	params := rollbackParams(t, otelcol.DeepCopy())
+	ctx := context.Background()
+	require.NoError(t, params.Client.Get(ctx, client.ObjectKeyFromObject(otelcol), otelcol))
+
+	// the defaultings are recorded once, however many times the collector is reconciled
+	recordDefaulting(ctx, params, otelcol)
+	recordDefaulting(ctx, params, otelcol)
+
+	entries, err := auditlog.Get(ctx, params.Client, otelcol)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, auditlog.SourceDefaulting, entries[0].Source)
+	assert.Equal(t, `{"spec":{"replicas":1}}`, entries[0].Patch)
+	assert.Equal(t, `{"spec":{"mode":"deployment"}}`, entries[1].Patch)
+
+	// the recorded defaultings are removed from the collector
+	assert.NotContains(t, otelcol.Annotations, auditlog.DefaultingAnnotation)
+	stored := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, params.Client.Get(ctx, client.ObjectKeyFromObject(otelcol), stored))
+	assert.NotContains(t, stored.Annotations, auditlog.DefaultingAnnotation)
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	otelcol := upgradingCollector(time.Now().Add(-time.Hour))
+	params := rollbackParams(t, otelcol.DeepCopy(), rollbackConfigMap(t), upgradingDeployment(0))
+	ctx := context.Background()
+
+	_, err := checkUpgrade(ctx, params, otelcol)
+	require.NoError(t, err)
+
+	entries, err := auditlog.Get(ctx, params.Client, otelcol)
+	require.NoError(t, err)
+	assert.Nil(t, entries)
+	status, err := auditLogStatus(ctx, params.Client, otelcol)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const (
+	reasonBlueGreenSwitched = "BlueGreenSwitched"
+
+	defaultVerificationWindow = time.Minute
+)
+
+// checkBlueGreen switches the Services of a collector rolled out blue/green to the deployment previewing the changes,
+// once its replicas stayed ready for the verification window, or right away when the changes are reverted to the
+// previous deployment. It returns the time after which the switch has to be checked again, or zero if the changes are
+// not verified yet.
+func checkBlueGreen(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector) (time.Duration, error) {
+	if !collector.BlueGreenEnabled(*changed) {
+		changed.Status.BlueGreen = nil
+		return 0, nil
+	}
+	current := params
+	current.OtelCol = *changed
+	revision, err := collector.BlueGreenRevision(current)
+	if err != nil {
+		return 0, err
+	}
+	status := changed.Status.BlueGreen
+	if status == nil || status.ActiveRevision == "" {
+		// the first deployment is active once it is ready, the Services select the pods of the deployment of the
+		// previous rollouts too until then
+		active := collector.BlueGreenActiveColor(*changed)
+		ready, err := colorReady(ctx, params, changed, active, revision)
+		if err != nil || !ready {
+			return 0, err
+		}
+		changed.Status.BlueGreen = &v1beta1.BlueGreenStatus{
+			Active:         active,
+			ActiveRevision: revision,
+		}
+		return 0, nil
+	}
+	if status.ActiveRevision == revision {
+		status.PreviewReadySince = nil
+		return 0, nil
+	}
+
+	preview := collector.BlueGreenPreviewColor(*changed)
+	ready, err := colorReady(ctx, params, changed, preview, revision)
+	if err != nil || !ready {
+		status.PreviewReadySince = nil
+		return 0, err
+	}
+
+	window := defaultVerificationWindow
+	if changed.Spec.BlueGreen.VerificationWindow != nil {
+		window = changed.Spec.BlueGreen.VerificationWindow.Duration
+	}
+	if revision == status.PreviousRevision {
+		// the previous deployment was verified before
+		window = 0
+	}
+	if status.PreviewReadySince == nil {
+		now := metav1.Now()
+		status.PreviewReadySince = &now
+	}
+	if elapsed := time.Since(status.PreviewReadySince.Time); elapsed < window {
+		return window - elapsed, nil
+	}
+
+	params.Recorder.Event(changed, eventTypeNormal, reasonBlueGreenSwitched,
+		fmt.Sprintf("switched the Services from the %s deployment to the %s deployment", status.Active, preview))
+	changed.Status.BlueGreen = &v1beta1.BlueGreenStatus{
+		Active:           preview,
+		ActiveRevision:   revision,
+		PreviousRevision: status.ActiveRevision,
+	}
+	return 0, nil
+}
+
+// colorReady returns true when the deployment of the color runs the given revision, and is ready.
+func colorReady(ctx context.Context, params manifests.Params, otelcol *v1beta1.OpenTelemetryCollector, color v1beta1.BlueGreenColor, revision string) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	key := client.ObjectKey{Namespace: otelcol.Namespace, Name: collector.ColorWorkloadName(*otelcol, string(color))}
+	if err := params.Client.Get(ctx, key, deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get the %s deployment: %w", color, err)
+	}
+	return deployment.Annotations[collector.BlueGreenRevisionAnnotation] == revision && collector.DeploymentReady(*deployment), nil
+}
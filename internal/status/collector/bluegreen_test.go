@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+func blueGreenCollector(status *v1beta1.BlueGreenStatus) *v1beta1.OpenTelemetryCollector {
+	return &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:      v1beta1.ModeDeployment,
+			BlueGreen: &v1beta1.BlueGreen{VerificationWindow: &metav1.Duration{Duration: time.Minute}},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{BlueGreen: status},
+	}
+}
+
+func blueGreenDeployment(color, revision string, available int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-collector-" + color,
+			Namespace:   "default",
+			Annotations: map[string]string{collector.BlueGreenRevisionAnnotation: revision},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			ReadyReplicas:     available,
+			AvailableReplicas: available,
+		},
+	}
+}
+
+func blueGreenParams(t *testing.T, objs ...client.Object) manifests.Params {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	return manifests.Params{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		Config:   config.New(),
+	}
+}
+
+func blueGreenRevision(t *testing.T, otelcol *v1beta1.OpenTelemetryCollector) string {
+	revision, err := collector.BlueGreenRevision(manifests.Params{OtelCol: *otelcol, Config: config.New()})
+	require.NoError(t, err)
+	return revision
+}
+
+func TestCheckBlueGreenFirstRollout(t *testing.T) {
+	otelcol := blueGreenCollector(nil)
+	revision := blueGreenRevision(t, otelcol)
+
+	// the Services aren't switched to the first color until its deployment is ready
+	requeueAfter, err := checkBlueGreen(context.Background(), blueGreenParams(t, blueGreenDeployment("blue", revision, 0)), otelcol)
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Nil(t, otelcol.Status.BlueGreen)
+
+	requeueAfter, err = checkBlueGreen(context.Background(), blueGreenParams(t, blueGreenDeployment("blue", revision, 1)), otelcol)
+	require.NoError(t, err)
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, &v1beta1.BlueGreenStatus{Active: v1beta1.BlueGreenColorBlue, ActiveRevision: revision}, otelcol.Status.BlueGreen)
+}
+
+func TestCheckBlueGreenPreviewNotReady(t *testing.T) {
+	otelcol := blueGreenCollector(&v1beta1.BlueGreenStatus{Active: v1beta1.BlueGreenColorBlue, ActiveRevision: "previous"})
+	params := blueGreenParams(t, blueGreenDeployment("green", blueGreenRevision(t, otelcol), 0))
+
+	requeueAfter, err := checkBlueGreen(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, v1beta1.BlueGreenColorBlue, otelcol.Status.BlueGreen.Active)
+	assert.Nil(t, otelcol.Status.BlueGreen.PreviewReadySince)
+}
+
+func TestCheckBlueGreenVerificationWindow(t *testing.T) {
+	otelcol := blueGreenCollector(&v1beta1.BlueGreenStatus{Active: v1beta1.BlueGreenColorBlue, ActiveRevision: "previous"})
+	params := blueGreenParams(t, blueGreenDeployment("green", blueGreenRevision(t, otelcol), 1))
+
+	requeueAfter, err := checkBlueGreen(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	assert.Greater(t, requeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, requeueAfter, time.Minute)
+	assert.Equal(t, v1beta1.BlueGreenColorBlue, otelcol.Status.BlueGreen.Active)
+	assert.NotNil(t, otelcol.Status.BlueGreen.PreviewReadySince)
+}
+
+func TestCheckBlueGreenSwitch(t *testing.T) {
+	readySince := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	otelcol := blueGreenCollector(&v1beta1.BlueGreenStatus{
+		Active:            v1beta1.BlueGreenColorBlue,
+		ActiveRevision:    "previous",
+		PreviewReadySince: &readySince,
+	})
+	revision := blueGreenRevision(t, otelcol)
+	params := blueGreenParams(t, blueGreenDeployment("green", revision, 1))
+
+	requeueAfter, err := checkBlueGreen(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, &v1beta1.BlueGreenStatus{
+		Active:           v1beta1.BlueGreenColorGreen,
+		ActiveRevision:   revision,
+		PreviousRevision: "previous",
+	}, otelcol.Status.BlueGreen)
+}
+
+func TestCheckBlueGreenRollback(t *testing.T) {
+	otelcol := blueGreenCollector(nil)
+	revision := blueGreenRevision(t, otelcol)
+	otelcol.Status.BlueGreen = &v1beta1.BlueGreenStatus{
+		Active:           v1beta1.BlueGreenColorGreen,
+		ActiveRevision:   "faulty",
+		PreviousRevision: revision,
+	}
+	params := blueGreenParams(t, blueGreenDeployment("blue", revision, 1))
+
+	requeueAfter, err := checkBlueGreen(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	// the previous deployment is switched back to without verification window
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, v1beta1.BlueGreenColorBlue, otelcol.Status.BlueGreen.Active)
+	assert.Equal(t, revision, otelcol.Status.BlueGreen.ActiveRevision)
+}
+
+func TestCheckBlueGreenDisabled(t *testing.T) {
+	otelcol := blueGreenCollector(&v1beta1.BlueGreenStatus{Active: v1beta1.BlueGreenColorGreen})
+	otelcol.Spec.BlueGreen = nil
+
+	_, err := checkBlueGreen(context.Background(), blueGreenParams(t), otelcol)
+	require.NoError(t, err)
+
+	assert.Nil(t, otelcol.Status.BlueGreen)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,16 +27,20 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
-	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
-func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector) error {
+func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector, now time.Time) error {
 	if changed.Status.Version == "" {
 		// a version is not set, otherwise let the upgrade mechanism take care of it!
 		changed.Status.Version = version.OpenTelemetryCollector()
 	}
 
+	changed.Status.ActiveConfigSchedule = ""
+	if schedule := collector.ActiveConfigSchedule(*changed, now); schedule != nil {
+		changed.Status.ActiveConfigSchedule = schedule.Name
+	}
+
 	mode := changed.Spec.Mode
 
 	if mode == v1beta1.ModeSidecar {
@@ -44,7 +49,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		return nil
 	}
 
-	name := naming.Collector(changed.Name)
+	name := collector.WorkloadName(*changed)
 
 	// Set the scale selector
 	labels := manifestutils.Labels(changed.ObjectMeta, name, changed.Spec.Image, collector.ComponentOpenTelemetryCollector, []string{})
@@ -57,7 +62,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 	// Set the scale replicas
 	objKey := client.ObjectKey{
 		Namespace: changed.GetNamespace(),
-		Name:      naming.Collector(changed.Name),
+		Name:      collector.WorkloadName(*changed),
 	}
 
 	var replicas int32
@@ -67,14 +72,16 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 
 	switch mode { // nolint:exhaustive
 	case v1beta1.ModeDeployment:
-		obj := &appsv1.Deployment{}
-		if err := cli.Get(ctx, objKey, obj); err != nil {
+		deployments, err := collectorDeployments(ctx, cli, changed)
+		if err != nil {
 			return fmt.Errorf("failed to get deployment status.replicas: %w", err)
 		}
-		replicas = obj.Status.Replicas
-		readyReplicas = obj.Status.ReadyReplicas
+		for _, obj := range deployments {
+			replicas += obj.Status.Replicas
+			readyReplicas += obj.Status.ReadyReplicas
+			statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		}
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
-		statusImage = obj.Spec.Template.Spec.Containers[0].Image
 
 	case v1beta1.ModeStatefulSet:
 		obj := &appsv1.StatefulSet{}
@@ -98,5 +105,28 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 	changed.Status.Image = statusImage
 	changed.Status.Scale.StatusReplicas = statusReplicas
 
-	return nil
+	return updateCrashLoopCondition(ctx, cli, changed, selector)
+}
+
+// collectorDeployments returns the deployment of the collector, its per-zone deployments when it has zones, or the
+// deployment of the active color when it is rolled out blue/green.
+func collectorDeployments(ctx context.Context, cli client.Client, otelcol *v1beta1.OpenTelemetryCollector) ([]appsv1.Deployment, error) {
+	names := []string{collector.WorkloadName(*otelcol)}
+	if collector.BlueGreenEnabled(*otelcol) {
+		names = []string{collector.ColorWorkloadName(*otelcol, string(collector.BlueGreenActiveColor(*otelcol)))}
+	} else if otelcol.Spec.ZoneSpread != nil && len(otelcol.Spec.ZoneSpread.Zones) > 0 {
+		names = nil
+		for _, zone := range otelcol.Spec.ZoneSpread.Zones {
+			names = append(names, collector.ZoneWorkloadName(*otelcol, zone))
+		}
+	}
+	var deployments []appsv1.Deployment
+	for _, name := range names {
+		obj := appsv1.Deployment{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: otelcol.GetNamespace(), Name: name}, &obj); err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, obj)
+	}
+	return deployments, nil
 }
@@ -17,6 +17,7 @@ package collector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -42,7 +43,7 @@ func TestUpdateCollectorStatusUnsupported(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, changed, time.Now())
 	assert.NoError(t, err)
 
 	assert.Equal(t, int32(0), changed.Status.Scale.Replicas, "expected replicas to be 0")
@@ -89,7 +90,7 @@ func TestUpdateCollectorStatusDeploymentMode(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, changed, time.Now())
 	assert.NoError(t, err)
 
 	assert.Equal(t, int32(1), changed.Status.Scale.Replicas, "expected replicas to be 1")
@@ -97,6 +98,57 @@ func TestUpdateCollectorStatusDeploymentMode(t *testing.T) {
 	assert.Equal(t, "app:latest", changed.Status.Image, "expected image to be app:latest")
 }
 
+func TestUpdateCollectorStatusDeploymentModeWithZones(t *testing.T) {
+	ctx := context.TODO()
+	var objects []client.Object
+	for _, zone := range []string{"zone-a", "zone-b"} {
+		objects = append(objects, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-deployment-collector-" + zone,
+				Namespace: "default",
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:      2,
+				ReadyReplicas: 1,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "app",
+								Image: "app:latest",
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	cli := fake.NewClientBuilder().WithObjects(objects...).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-deployment",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			ZoneSpread: &v1beta1.ZoneSpread{
+				MinPerZone: 2,
+				Zones:      []string{"zone-a", "zone-b"},
+			},
+		},
+	}
+
+	err := UpdateCollectorStatus(ctx, cli, changed, time.Now())
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(4), changed.Status.Scale.Replicas)
+	assert.Equal(t, "2/4", changed.Status.Scale.StatusReplicas)
+	assert.Equal(t, "app:latest", changed.Status.Image)
+}
+
 func createMockKubernetesClientStatefulset() client.Client {
 	statefulset := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -137,7 +189,7 @@ func TestUpdateCollectorStatusStatefulset(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, changed, time.Now())
 	assert.NoError(t, err)
 
 	assert.Equal(t, int32(1), changed.Status.Scale.Replicas, "expected replicas to be 1")
@@ -184,7 +236,7 @@ func TestUpdateCollectorStatusDaemonsetMode(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, changed, time.Now())
 	assert.NoError(t, err)
 
 	assert.Contains(t, changed.Status.Scale.Selector, "customLabel=customValue", "expected selector to contain customlabel=customValue")
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const (
+	reasonCrashLoopBackOff     = "CrashLoopBackOff"
+	reasonInvalidConfiguration = "InvalidConfiguration"
+	reasonPortBindFailure      = "PortBindFailure"
+	reasonMissingSecret        = "MissingSecret"
+	reasonContainerConfigError = "ContainerConfigError"
+	reasonPodsRunning          = "PodsRunning"
+
+	// maxLogTailLines is the number of lines of the termination message reported in the condition.
+	maxLogTailLines = 10
+	// maxReportedPods is the number of failing pods named in the condition.
+	maxReportedPods = 5
+)
+
+// crashClassifiers classifies the common failures from the termination message of the collector container.
+var crashClassifiers = []struct {
+	reason  string
+	pattern *regexp.Regexp
+}{
+	{reason: reasonInvalidConfiguration, pattern: regexp.MustCompile(`(?i)(has invalid keys|error decoding|unknown type|invalid configuration|cannot unmarshal|failed to get config|references (receiver|processor|exporter|extension|connector) "[^"]*" which is not configured)`)},
+	{reason: reasonPortBindFailure, pattern: regexp.MustCompile(`(?i)(address already in use|bind: permission denied|cannot assign requested address)`)},
+	{reason: reasonMissingSecret, pattern: regexp.MustCompile(`(?i)secrets? "[^"]*" not found`)},
+}
+
+// updateCrashLoopCondition sets the CrashLooping condition from the state of the collector pods matching the
+// selector, with the classified cause and the tail of the logs of the crashing containers.
+func updateCrashLoopCondition(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector, selector labels.Selector) error {
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(changed.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list the collector pods: %w", err)
+	}
+
+	diagnosis := diagnosePods(*changed, pods.Items)
+	if diagnosis == nil {
+		if meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeCrashLooping) != nil {
+			meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+				Type:               v1beta1.ConditionTypeCrashLooping,
+				Status:             metav1.ConditionFalse,
+				Reason:             reasonPodsRunning,
+				Message:            "no collector pod is crash-looping",
+				ObservedGeneration: changed.Generation,
+			})
+		}
+		return nil
+	}
+	diagnosis.ObservedGeneration = changed.Generation
+	meta.SetStatusCondition(&changed.Status.Conditions, *diagnosis)
+	return nil
+}
+
+// diagnosePods returns the CrashLooping condition for the first failing pod of the collector, or nil if no pod is
+// failing.
+func diagnosePods(otelcol v1beta1.OpenTelemetryCollector, pods []corev1.Pod) *metav1.Condition {
+	// report a stable pod across reconciliations
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	var failing []string
+	var condition *metav1.Condition
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if !collector.IsCollectorContainer(otelcol, status.Name) || status.State.Waiting == nil {
+				continue
+			}
+			reason, details, ok := diagnoseContainer(status)
+			if !ok {
+				continue
+			}
+			failing = append(failing, pod.Name)
+			if condition == nil {
+				condition = &metav1.Condition{
+					Type:   v1beta1.ConditionTypeCrashLooping,
+					Status: metav1.ConditionTrue,
+					Reason: reason,
+					// the message is completed once all the failing pods are known
+					Message: details,
+				}
+			}
+		}
+	}
+	if condition == nil {
+		return nil
+	}
+	names := failing
+	if len(names) > maxReportedPods {
+		names = append(names[:maxReportedPods:maxReportedPods], "...")
+	}
+	condition.Message = fmt.Sprintf("%d collector pod(s) failing (%s): %s", len(failing), strings.Join(names, ", "), condition.Message)
+	return condition
+}
+
+// diagnoseContainer classifies the failure of a waiting collector container.
+func diagnoseContainer(status corev1.ContainerStatus) (string, string, bool) {
+	waiting := status.State.Waiting
+	switch waiting.Reason {
+	case "CreateContainerConfigError":
+		// e.g. a secret referenced by the environment variables does not exist
+		return classify(waiting.Message, reasonContainerConfigError), waiting.Message, true
+	case "CrashLoopBackOff":
+		terminated := status.LastTerminationState.Terminated
+		if terminated == nil {
+			return reasonCrashLoopBackOff, waiting.Message, true
+		}
+		tail := logTail(terminated.Message)
+		details := fmt.Sprintf("the collector exited with code %d (%s)", terminated.ExitCode, terminated.Reason)
+		if tail != "" {
+			details = fmt.Sprintf("%s, last logs:\n%s", details, tail)
+		}
+		return classify(tail, reasonCrashLoopBackOff), details, true
+	}
+	return "", "", false
+}
+
+func classify(message string, fallback string) string {
+	for _, classifier := range crashClassifiers {
+		if classifier.pattern.MatchString(message) {
+			return classifier.reason
+		}
+	}
+	return fallback
+}
+
+// logTail returns the last lines of the termination message, which holds the tail of the logs of the container.
+func logTail(message string) string {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	if len(lines) > maxLogTailLines {
+		lines = lines[len(lines)-maxLogTailLines:]
+	}
+	return strings.Join(lines, "\n")
+}
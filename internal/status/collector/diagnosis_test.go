@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func crashingPod(name string, message string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"app": "collector"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "otc-container",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				},
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", Message: message},
+				},
+			}},
+		},
+	}
+}
+
+func TestDiagnosePods(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		pods           []corev1.Pod
+		expectedReason string
+		expectedInMsg  string
+	}{
+		{
+			desc:           "invalid configuration",
+			pods:           []corev1.Pod{crashingPod("pod-1", "Error: failed to get config: cannot unmarshal the configuration: 1 error(s) decoding:\n\n* error decoding 'receivers': unknown type: \"otlpp\"")},
+			expectedReason: reasonInvalidConfiguration,
+			expectedInMsg:  `unknown type: "otlpp"`,
+		},
+		{
+			desc:           "port bind",
+			pods:           []corev1.Pod{crashingPod("pod-1", "listen tcp 0.0.0.0:4317: bind: address already in use")},
+			expectedReason: reasonPortBindFailure,
+			expectedInMsg:  "exited with code 1",
+		},
+		{
+			desc:           "unknown failure",
+			pods:           []corev1.Pod{crashingPod("pod-1", "panic: runtime error")},
+			expectedReason: reasonCrashLoopBackOff,
+			expectedInMsg:  "panic: runtime error",
+		},
+		{
+			desc: "missing secret",
+			pods: []corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-1"},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "otc-container",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CreateContainerConfigError", Message: `secret "exporter-token" not found`},
+						},
+					}},
+				},
+			}},
+			expectedReason: reasonMissingSecret,
+			expectedInMsg:  "exporter-token",
+		},
+		{
+			desc:           "multiple pods",
+			pods:           []corev1.Pod{crashingPod("pod-2", "bind: address already in use"), crashingPod("pod-1", "has invalid keys: endpont")},
+			expectedReason: reasonInvalidConfiguration,
+			expectedInMsg:  "2 collector pod(s) failing (pod-1, pod-2)",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			condition := diagnosePods(v1beta1.OpenTelemetryCollector{}, tt.pods)
+			require.NotNil(t, condition)
+			assert.Equal(t, v1beta1.ConditionTypeCrashLooping, condition.Type)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Contains(t, condition.Message, tt.expectedInMsg)
+		})
+	}
+}
+
+func TestDiagnosePodsRunning(t *testing.T) {
+	running := corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "otc-container",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+	assert.Nil(t, diagnosePods(v1beta1.OpenTelemetryCollector{}, []corev1.Pod{running}))
+	assert.Nil(t, diagnosePods(v1beta1.OpenTelemetryCollector{}, nil))
+}
+
+func TestLogTail(t *testing.T) {
+	message := ""
+	for i := 0; i < 20; i++ {
+		message += "line\n"
+	}
+	message += "last line"
+	tail := logTail(message)
+	assert.Len(t, strings.Split(tail, "\n"), maxLogTailLines)
+	assert.Contains(t, tail, "last line")
+}
+
+func TestUpdateCrashLoopCondition(t *testing.T) {
+	pod := crashingPod("pod-1", "bind: address already in use")
+	cli := fake.NewClientBuilder().WithObjects(&pod).Build()
+	selector := labels.SelectorFromSet(labels.Set{"app": "collector"})
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+
+	// crash-looping
+	require.NoError(t, updateCrashLoopCondition(context.Background(), cli, changed, selector))
+	condition := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeCrashLooping)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, reasonPortBindFailure, condition.Reason)
+
+	// recovered
+	require.NoError(t, cli.Delete(context.Background(), &pod))
+	require.NoError(t, updateCrashLoopCondition(context.Background(), cli, changed, selector))
+	condition = meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeCrashLooping)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonPodsRunning, condition.Reason)
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const (
+	reasonEndpointsReachable   = "EndpointsReachable"
+	reasonEndpointsUnreachable = "EndpointsUnreachable"
+	reasonCheckInProgress      = "CheckInProgress"
+)
+
+// updateExporterCheckCondition sets the ExportersReachable condition from the job checking the exporter endpoints of
+// the current config, if any. The job is named after the hash of the config rendered for the collector, with its
+// config schedule and config variables applied, and of the timeout of the check.
+func updateExporterCheckCondition(ctx context.Context, cli client.Client, cfg config.Config, changed *v1beta1.OpenTelemetryCollector, rendered v1beta1.OpenTelemetryCollector) error {
+	if changed.Spec.ExporterCheck == nil || !changed.Spec.ExporterCheck.Enabled {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.ConditionTypeExportersReachable)
+		return nil
+	}
+
+	name, err := collector.ExporterCheckJobName(cfg, rendered)
+	if err != nil {
+		return err
+	}
+	job := &batchv1.Job{}
+	key := client.ObjectKey{Namespace: changed.Namespace, Name: name}
+	if err := cli.Get(ctx, key, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the config has no endpoint to check, or the job is not created yet
+			return nil
+		}
+		return fmt.Errorf("failed to get the exporter check job: %w", err)
+	}
+
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionTypeExportersReachable,
+		Status:             metav1.ConditionUnknown,
+		Reason:             reasonCheckInProgress,
+		Message:            "the exporter endpoints are being checked",
+		ObservedGeneration: changed.Generation,
+	}
+	switch {
+	case exporterCheckJobCondition(job, batchv1.JobComplete):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonEndpointsReachable
+		condition.Message = "all the exporter endpoints are reachable"
+	case exporterCheckJobCondition(job, batchv1.JobFailed):
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonEndpointsUnreachable
+		condition.Message = "some exporter endpoints are unreachable"
+		if msg := exporterCheckTerminationMessage(ctx, cli, job); msg != "" {
+			condition.Message = msg
+		}
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+	return nil
+}
+
+func exporterCheckJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// exporterCheckTerminationMessage returns the unreachable endpoints written by a pod of the job, if any.
+func exporterCheckTerminationMessage(ctx context.Context, cli client.Client, job *batchv1.Job) string {
+	if job.Spec.Selector == nil {
+		return ""
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return ""
+	}
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return ""
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return strings.TrimSpace(status.State.Terminated.Message)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+func TestUpdateExporterCheckCondition(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			ExporterCheck: &v1beta1.ExporterCheck{Enabled: true},
+		},
+	}
+	jobName, err := collector.ExporterCheckJobName(config.New(), *otelcol)
+	require.NoError(t, err)
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": jobName}}
+
+	for _, tt := range []struct {
+		name           string
+		jobConditions  []batchv1.JobCondition
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+		expectedMsg    string
+	}{
+		{
+			name:           "in progress",
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: reasonCheckInProgress,
+			expectedMsg:    "the exporter endpoints are being checked",
+		},
+		{
+			name:           "complete",
+			jobConditions:  []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: reasonEndpointsReachable,
+			expectedMsg:    "all the exporter endpoints are reachable",
+		},
+		{
+			name:           "failed",
+			jobConditions:  []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: reasonEndpointsUnreachable,
+			expectedMsg:    "unreachable: tempo.observability:4317",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: jobName, Namespace: "default"},
+				Spec:       batchv1.JobSpec{Selector: selector},
+				Status:     batchv1.JobStatus{Conditions: tt.jobConditions},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: jobName + "-abcde", Namespace: "default", Labels: selector.MatchLabels},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  "unreachable: tempo.observability:4317\n",
+						}},
+					}},
+				},
+			}
+			cli := fake.NewClientBuilder().WithObjects(job, pod).Build()
+			changed := otelcol.DeepCopy()
+
+			require.NoError(t, updateExporterCheckCondition(context.Background(), cli, config.New(), changed, *otelcol))
+
+			condition := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeExportersReachable)
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectedMsg, condition.Message)
+		})
+	}
+
+	t.Run("no job", func(t *testing.T) {
+		changed := otelcol.DeepCopy()
+		require.NoError(t, updateExporterCheckCondition(context.Background(), fake.NewFakeClient(), config.New(), changed, *otelcol))
+		assert.Empty(t, changed.Status.Conditions)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		changed := otelcol.DeepCopy()
+		changed.Spec.ExporterCheck = nil
+		meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+			Type:   v1beta1.ConditionTypeExportersReachable,
+			Status: metav1.ConditionTrue,
+			Reason: reasonEndpointsReachable,
+		})
+		require.NoError(t, updateExporterCheckCondition(context.Background(), fake.NewFakeClient(), config.New(), changed, *otelcol))
+		assert.Empty(t, changed.Status.Conditions)
+	})
+}
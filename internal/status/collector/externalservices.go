@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+const (
+	reasonPortsExposed         = "PortsExposed"
+	reasonPortsMissing         = "PortsMissing"
+	reasonServicePortsMismatch = "ServicePortsMismatch"
+)
+
+// updateServicePortsCondition sets the ServicePortsExposed condition of the collectors whose Services are managed
+// outside of the operator, from the ports exposed by the Services selected by spec.skipServiceCreation.serviceSelector.
+// A warning event is emitted when ports start missing or the missing ports change, rather than on every
+// reconciliation.
+func updateServicePortsCondition(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector) error {
+	skipServiceCreation := changed.Spec.SkipServiceCreation
+	if !skipServiceCreation.Enabled || skipServiceCreation.ServiceSelector == nil {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.ConditionTypeServicePortsExposed)
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(skipServiceCreation.ServiceSelector)
+	if err != nil {
+		return fmt.Errorf("invalid service selector: %w", err)
+	}
+	serviceList := &corev1.ServiceList{}
+	err = params.Client.List(ctx, serviceList, &client.ListOptions{
+		Namespace:     changed.Namespace,
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing Services: %w", err)
+	}
+	expected, err := collector.ServicePorts(params)
+	if err != nil {
+		return err
+	}
+
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionTypeServicePortsExposed,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonPortsExposed,
+		Message:            "the Services selected by spec.skipServiceCreation.serviceSelector expose all the ports",
+		ObservedGeneration: changed.Generation,
+	}
+	if missing := collector.MissingServicePorts(expected, serviceList.Items); len(missing) > 0 {
+		var portNames []string
+		for _, port := range missing {
+			portNames = append(portNames, fmt.Sprintf("%s (%d/%s)", port.Name, port.Port, port.Protocol))
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonPortsMissing
+		condition.Message = fmt.Sprintf("the Services selected by spec.skipServiceCreation.serviceSelector don't expose the ports: %s", strings.Join(portNames, ", "))
+
+		previous := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeServicePortsExposed)
+		if previous == nil || previous.Status != condition.Status || previous.Message != condition.Message {
+			params.Recorder.Event(changed, eventTypeWarning, reasonServicePortsMismatch, condition.Message)
+		}
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+	return nil
+}
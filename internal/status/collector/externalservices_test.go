@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestUpdateServicePortsCondition(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": nil}}}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				}},
+			},
+			SkipServiceCreation: v1beta1.SkipServiceCreation{
+				Enabled:         true,
+				ServiceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "external"}},
+			},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default", Labels: map[string]string{"app": "external"}},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 4318}}},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	recorder := record.NewFakeRecorder(10)
+	params := manifests.Params{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build(),
+		Recorder: recorder,
+		Config:   config.New(),
+		OtelCol:  *otelcol,
+		Log:      logr.Discard(),
+	}
+
+	// the missing ports are reported once
+	require.NoError(t, updateServicePortsCondition(context.Background(), params, otelcol))
+	condition := meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionTypeServicePortsExposed)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, "otlp-grpc (4317/")
+	assert.Len(t, recorder.Events, 1)
+
+	require.NoError(t, updateServicePortsCondition(context.Background(), params, otelcol))
+	assert.Len(t, recorder.Events, 1)
+
+	// the ports are exposed
+	require.NoError(t, params.Client.Get(context.Background(), client.ObjectKeyFromObject(service), service))
+	service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{Name: "grpc", Port: 4317})
+	require.NoError(t, params.Client.Update(context.Background(), service))
+	require.NoError(t, updateServicePortsCondition(context.Background(), params, otelcol))
+	condition = meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionTypeServicePortsExposed)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Len(t, recorder.Events, 1)
+
+	// the condition is removed with skipServiceCreation
+	otelcol.Spec.SkipServiceCreation = v1beta1.SkipServiceCreation{}
+	require.NoError(t, updateServicePortsCondition(context.Background(), params, otelcol))
+	assert.Nil(t, meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionTypeServicePortsExposed))
+}
@@ -17,12 +17,15 @@ package collector
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
@@ -37,36 +40,77 @@ const (
 	reasonInfo          = "Info"
 )
 
-// HandleReconcileStatus handles updating the status of the CRDs managed by the operator.
-func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifests.Params, otelcol v1beta1.OpenTelemetryCollector, err error) (ctrl.Result, error) {
+// HandleReconcileStatus handles updating the status of the CRDs managed by the operator, with the config schedule
+// active at the time the collector was reconciled.
+func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifests.Params, otelcol v1beta1.OpenTelemetryCollector, now time.Time, err error) (ctrl.Result, error) {
 	log.V(2).Info("updating collector status")
 	if err != nil {
 		params.Recorder.Event(&otelcol, eventTypeWarning, reasonError, err.Error())
 		return ctrl.Result{}, err
 	}
+	recordDefaulting(ctx, params, &otelcol)
 	changed := otelcol.DeepCopy()
 
 	up := &collectorupgrade.VersionUpgrade{
-		Log:      params.Log,
-		Version:  version.Get(),
-		Client:   params.Client,
-		Recorder: params.Recorder,
+		Log:             params.Log,
+		Version:         version.Get(),
+		Client:          params.Client,
+		Recorder:        params.Recorder,
+		Window:          params.Config.UpgradeWindow(),
+		RollbackTimeout: params.Config.UpgradeRollbackTimeout(),
 	}
 	upgraded, upgradeErr := up.ManagedInstance(ctx, *changed)
 	if upgradeErr != nil {
 		// don't fail to allow setting the status
 		log.V(2).Error(upgradeErr, "failed to upgrade the OpenTelemetry CR")
 	}
+	if !reflect.DeepEqual(upgraded.Annotations, otelcol.Annotations) {
+		// the upgrade recorded the state to roll back to
+		annotated := otelcol.DeepCopy()
+		annotated.Annotations = upgraded.Annotations
+		if err := params.Client.Patch(ctx, annotated, client.MergeFrom(&otelcol)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to apply the upgrade state to the OpenTelemetry CR: %w", err)
+		}
+		recordMutation(ctx, params, &otelcol, annotated, auditlog.SourceUpgrade,
+			fmt.Sprintf("recorded the state to roll back the upgrade from version %s to version %s", otelcol.Status.Version, upgraded.Status.Version))
+	}
 	changed = &upgraded
-	statusErr := UpdateCollectorStatus(ctx, params.Client, changed)
+	statusErr := UpdateCollectorStatus(ctx, params.Client, changed, now)
+	if statusErr == nil {
+		statusErr = updateExporterCheckCondition(ctx, params.Client, params.Config, changed, params.OtelCol)
+	}
 	if statusErr != nil {
 		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
 		return ctrl.Result{}, statusErr
 	}
+	if servicePortsErr := updateServicePortsCondition(ctx, params, changed); servicePortsErr != nil {
+		// don't fail to allow setting the status
+		log.V(2).Error(servicePortsErr, "failed to validate the externally managed Services of the OpenTelemetry CR")
+	}
+	requeueAfter, rollbackErr := checkUpgrade(ctx, params, changed)
+	if rollbackErr != nil {
+		// don't fail to allow setting the status
+		log.V(2).Error(rollbackErr, "failed to check the upgrade of the OpenTelemetry CR")
+	}
+	switchAfter, blueGreenErr := checkBlueGreen(ctx, params, changed)
+	if blueGreenErr != nil {
+		// don't fail to allow setting the status
+		log.V(2).Error(blueGreenErr, "failed to check the blue/green rollout of the OpenTelemetry CR")
+	}
+	if switchAfter > 0 && (requeueAfter == 0 || switchAfter < requeueAfter) {
+		requeueAfter = switchAfter
+	}
+	auditLog, auditLogErr := auditLogStatus(ctx, params.Client, changed)
+	if auditLogErr != nil {
+		// don't fail to allow setting the status
+		log.V(2).Error(auditLogErr, "failed to read the audit log of the OpenTelemetry CR")
+	} else {
+		changed.Status.AuditLog = auditLog
+	}
 	statusPatch := client.MergeFrom(&otelcol)
 	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
 	}
 	params.Recorder.Event(changed, eventTypeNormal, reasonInfo, "applied status changes")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
+)
+
+const (
+	reasonUpgradeRolledBack = "UpgradeRolledBack"
+	reasonUpgradeSucceeded  = "UpgradeSucceeded"
+)
+
+// checkUpgrade rolls back the last upgrade of the given instance if its workload did not become ready within the
+// rollback timeout. It returns the time after which the upgrade has to be checked again, or zero if no upgrade is in
+// progress anymore.
+func checkUpgrade(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector) (time.Duration, error) {
+	started, ok := collectorupgrade.UpgradeStarted(*changed)
+	if !ok {
+		return 0, nil
+	}
+	ready, details, err := workloadReady(ctx, params.Client, changed)
+	if err != nil {
+		return 0, err
+	}
+
+	original := changed.DeepCopy()
+	timeout := params.Config.UpgradeRollbackTimeout()
+	elapsed := time.Since(started)
+	source, message := auditlog.SourceUpgrade, fmt.Sprintf("completed the upgrade to version %s", changed.Status.Version)
+	switch {
+	case ready || timeout <= 0:
+		if err := collectorupgrade.CompleteUpgrade(ctx, params.Client, changed); err != nil {
+			return 0, err
+		}
+		degraded := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionTypeDegraded)
+		if ready && degraded != nil && degraded.Reason == reasonUpgradeRolledBack {
+			meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+				Type:               v1beta1.ConditionTypeDegraded,
+				Status:             metav1.ConditionFalse,
+				Reason:             reasonUpgradeSucceeded,
+				Message:            fmt.Sprintf("the collector is ready after the upgrade to version %s", changed.Status.Version),
+				ObservedGeneration: changed.Generation,
+			})
+		}
+	case elapsed < timeout:
+		return timeout - elapsed, nil
+	default:
+		upgradedVersion := changed.Status.Version
+		version, err := collectorupgrade.Rollback(ctx, params.Client, changed)
+		if err != nil {
+			if completeErr := collectorupgrade.CompleteUpgrade(ctx, params.Client, changed); completeErr != nil {
+				return 0, completeErr
+			}
+			params.Recorder.Event(changed, eventTypeWarning, reasonError, fmt.Sprintf("failed to roll back the upgrade: %s", err))
+			source, message = auditlog.SourceRollback, fmt.Sprintf("failed to roll back the upgrade: %s", err)
+			break
+		}
+		msg := fmt.Sprintf("the collector was not ready %s after the upgrade to version %s (%s), rolled back to version %s. Remove the %s annotation to retry the upgrade",
+			timeout, upgradedVersion, details, version, collectorupgrade.SkipUpgradeAnnotation)
+		meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+			Type:               v1beta1.ConditionTypeDegraded,
+			Status:             metav1.ConditionTrue,
+			Reason:             reasonUpgradeRolledBack,
+			Message:            msg,
+			ObservedGeneration: changed.Generation,
+		})
+		params.Recorder.Event(changed, eventTypeWarning, reasonUpgradeRolledBack, msg)
+		source, message = auditlog.SourceRollback, msg
+	}
+
+	// the status is patched by the caller
+	patched := original.DeepCopy()
+	patched.Annotations = changed.Annotations
+	patched.Spec = changed.Spec
+	if err := params.Client.Patch(ctx, patched, client.MergeFrom(original)); err != nil {
+		return 0, fmt.Errorf("failed to apply the upgrade state to the OpenTelemetry CR: %w", err)
+	}
+	recordMutation(ctx, params, original, patched, source, message)
+	return 0, nil
+}
+
+// workloadReady returns whether the workload of the given instance rolled out and is ready, with details about the
+// rollout otherwise.
+func workloadReady(ctx context.Context, cli client.Client, otelcol *v1beta1.OpenTelemetryCollector) (bool, string, error) {
+	objKey := client.ObjectKey{
+		Namespace: otelcol.GetNamespace(),
+		Name:      collector.WorkloadName(*otelcol),
+	}
+
+	switch otelcol.Spec.Mode { // nolint:exhaustive
+	case v1beta1.ModeDeployment:
+		deployments, err := collectorDeployments(ctx, cli, otelcol)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get deployment: %w", err)
+		}
+		ready := true
+		var desired, available int32
+		for _, obj := range deployments {
+			objDesired := int32(1)
+			if obj.Spec.Replicas != nil {
+				objDesired = *obj.Spec.Replicas
+			}
+			ready = ready && collector.DeploymentReady(obj)
+			desired += objDesired
+			available += obj.Status.AvailableReplicas
+		}
+		return ready, fmt.Sprintf("%d/%d updated replicas available", available, desired), nil
+
+	case v1beta1.ModeStatefulSet:
+		obj := &appsv1.StatefulSet{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return false, "", fmt.Errorf("failed to get statefulSet: %w", err)
+		}
+		desired := int32(1)
+		if obj.Spec.Replicas != nil {
+			desired = *obj.Spec.Replicas
+		}
+		ready := obj.Status.ObservedGeneration >= obj.Generation && obj.Status.UpdatedReplicas == desired &&
+			obj.Status.ReadyReplicas == desired
+		return ready, fmt.Sprintf("%d/%d updated replicas ready", obj.Status.ReadyReplicas, desired), nil
+
+	case v1beta1.ModeDaemonSet:
+		obj := &appsv1.DaemonSet{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return false, "", fmt.Errorf("failed to get daemonSet: %w", err)
+		}
+		desired := obj.Status.DesiredNumberScheduled
+		ready := obj.Status.ObservedGeneration >= obj.Generation && obj.Status.UpdatedNumberScheduled == desired &&
+			obj.Status.NumberReady == desired
+		return ready, fmt.Sprintf("%d/%d updated pods ready", obj.Status.NumberReady, desired), nil
+	}
+
+	// the sidecars are rolled out with the workloads they are injected into
+	return true, "", nil
+}
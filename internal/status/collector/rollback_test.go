@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
+)
+
+func upgradingCollector(started time.Time) *v1beta1.OpenTelemetryCollector {
+	return &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			Annotations: map[string]string{
+				collectorupgrade.UpgradeStartedAnnotation:  started.UTC().Format(time.RFC3339),
+				collectorupgrade.RollbackVersionAnnotation: "0.1.0",
+			},
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{
+			Version: "0.2.0",
+		},
+	}
+}
+
+func rollbackConfigMap(t *testing.T) *corev1.ConfigMap {
+	previous := v1beta1.OpenTelemetryCollectorSpec{
+		Mode: v1beta1.ModeDeployment,
+		OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+			Image: "collector:0.1.0",
+		},
+	}
+	raw, err := json.Marshal(previous)
+	require.NoError(t, err)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.UpgradeRollbackConfigMap("test"),
+			Namespace: "default",
+		},
+		Data: map[string]string{collectorupgrade.RollbackSpecKey: string(raw)},
+	}
+}
+
+func upgradingDeployment(available int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-collector",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          1,
+			UpdatedReplicas:   1,
+			ReadyReplicas:     available,
+			AvailableReplicas: available,
+		},
+	}
+}
+
+func rollbackParams(t *testing.T, objs ...client.Object) manifests.Params {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return manifests.Params{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		Config:   config.New(config.WithUpgradeRollbackTimeout(10 * time.Minute)),
+	}
+}
+
+func TestCheckUpgradeWithinTimeout(t *testing.T) {
+	otelcol := upgradingCollector(time.Now().Add(-time.Minute))
+	params := rollbackParams(t, otelcol.DeepCopy(), rollbackConfigMap(t), upgradingDeployment(0))
+
+	requeueAfter, err := checkUpgrade(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	assert.Greater(t, requeueAfter, time.Duration(0))
+	assert.LessOrEqual(t, requeueAfter, 9*time.Minute)
+	assert.Equal(t, "0.2.0", otelcol.Status.Version)
+	assert.Contains(t, otelcol.Annotations, collectorupgrade.UpgradeStartedAnnotation)
+}
+
+func TestCheckUpgradeReady(t *testing.T) {
+	otelcol := upgradingCollector(time.Now().Add(-time.Minute))
+	params := rollbackParams(t, otelcol.DeepCopy(), rollbackConfigMap(t), upgradingDeployment(1))
+
+	requeueAfter, err := checkUpgrade(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, "0.2.0", otelcol.Status.Version)
+	assert.Empty(t, otelcol.Status.Conditions)
+
+	persisted := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, params.Client.Get(context.Background(), client.ObjectKeyFromObject(otelcol), persisted))
+	assert.NotContains(t, persisted.Annotations, collectorupgrade.UpgradeStartedAnnotation)
+	assert.NotContains(t, persisted.Annotations, collectorupgrade.RollbackVersionAnnotation)
+	assert.NotContains(t, persisted.Annotations, collectorupgrade.SkipUpgradeAnnotation)
+	err = params.Client.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: naming.UpgradeRollbackConfigMap("test")}, &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err), "the rollback state should be deleted")
+}
+
+func TestCheckUpgradeRollback(t *testing.T) {
+	otelcol := upgradingCollector(time.Now().Add(-time.Hour))
+	params := rollbackParams(t, otelcol.DeepCopy(), rollbackConfigMap(t), upgradingDeployment(0))
+
+	requeueAfter, err := checkUpgrade(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	assert.Zero(t, requeueAfter)
+	assert.Equal(t, "0.1.0", otelcol.Status.Version)
+	degraded := meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionTypeDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, reasonUpgradeRolledBack, degraded.Reason)
+	assert.Contains(t, degraded.Message, "0/1 updated replicas available")
+
+	persisted := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, params.Client.Get(context.Background(), client.ObjectKeyFromObject(otelcol), persisted))
+	assert.Equal(t, "collector:0.1.0", persisted.Spec.Image)
+	assert.Equal(t, "true", persisted.Annotations[collectorupgrade.SkipUpgradeAnnotation])
+	assert.NotContains(t, persisted.Annotations, collectorupgrade.UpgradeStartedAnnotation)
+	err = params.Client.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: naming.UpgradeRollbackConfigMap("test")}, &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err), "the rollback state should be deleted")
+}
+
+func TestCheckUpgradeSucceedsAfterRollback(t *testing.T) {
+	otelcol := upgradingCollector(time.Now().Add(-time.Minute))
+	otelcol.Status.Conditions = []metav1.Condition{{
+		Type:   v1beta1.ConditionTypeDegraded,
+		Status: metav1.ConditionTrue,
+		Reason: reasonUpgradeRolledBack,
+	}}
+	params := rollbackParams(t, otelcol.DeepCopy(), rollbackConfigMap(t), upgradingDeployment(1))
+
+	_, err := checkUpgrade(context.Background(), params, otelcol)
+	require.NoError(t, err)
+
+	degraded := meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionTypeDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionFalse, degraded.Status)
+	assert.Equal(t, reasonUpgradeSucceeded, degraded.Reason)
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collectortest"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	eventTypeNormal  = "Normal"
+	eventTypeWarning = "Warning"
+
+	reasonError  = "Error"
+	reasonPassed = "Passed"
+	reasonFailed = "Failed"
+
+	// defaultTimeout is the timeout of the tests which don't set one.
+	defaultTimeout = 5 * time.Minute
+	// pollInterval is the interval the progress of a running test is checked at, as the data received by the sink
+	// is not watched.
+	pollInterval = 5 * time.Second
+)
+
+// HandleReconcileStatus handles updating the status of the tests managed by the operator, from the collectors and
+// the job of the test, and from the data received by the sink.
+func HandleReconcileStatus(ctx context.Context, log logr.Logger, params collectortest.Params, scrape Scraper, err error) (ctrl.Result, error) {
+	log.V(2).Info("updating collector test status")
+	changed := params.Test.DeepCopy()
+	if changed.Status.StartTime == nil {
+		now := metav1.Now()
+		changed.Status.StartTime = &now
+		changed.Status.Phase = v1alpha1.CollectorTestPhaseRunning
+	}
+
+	if err != nil {
+		if errors.Is(err, collectortest.ErrInvalidTest) {
+			complete(params, changed, v1alpha1.CollectorTestPhaseFailed, err.Error())
+			return ctrl.Result{}, patchStatus(ctx, params, changed)
+		}
+		params.Recorder.Event(&params.Test, eventTypeWarning, reasonError, err.Error())
+		if patchErr := patchStatus(ctx, params, changed); patchErr != nil {
+			log.Error(patchErr, "failed to apply status changes")
+		}
+		return ctrl.Result{}, err
+	}
+
+	if statusErr := updateTestStatus(ctx, params, scrape, changed); statusErr != nil {
+		params.Recorder.Event(changed, eventTypeWarning, reasonError, statusErr.Error())
+		return ctrl.Result{}, statusErr
+	}
+	if err := patchStatus(ctx, params, changed); err != nil {
+		return ctrl.Result{}, err
+	}
+	if isCompleted(changed.Status.Phase) {
+		// the resources of the test are cleaned up in the next reconciliation
+		return ctrl.Result{Requeue: true}, nil
+	}
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
+
+func updateTestStatus(ctx context.Context, params collectortest.Params, scrape Scraper, changed *v1alpha1.OpenTelemetryCollectorTest) error {
+	timeout := defaultTimeout
+	if changed.Spec.Timeout != nil {
+		timeout = changed.Spec.Timeout.Duration
+	}
+	timedOut := time.Since(changed.Status.StartTime.Time) > timeout
+
+	if !params.CollectorsReady {
+		if timedOut {
+			msg := fmt.Sprintf("the collectors did not become ready within %s", timeout)
+			if diagnosis := candidateDiagnosis(ctx, params); diagnosis != "" {
+				msg = fmt.Sprintf("%s: %s", msg, diagnosis)
+			}
+			complete(params, changed, v1alpha1.CollectorTestPhaseFailed, msg)
+			return nil
+		}
+		changed.Status.Message = "waiting for the collectors to become ready"
+		return nil
+	}
+
+	job := &batchv1.Job{}
+	if err := params.Client.Get(ctx, client.ObjectKey{Namespace: changed.Namespace, Name: naming.CollectorTestJob(changed.Name)}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			changed.Status.Message = "sending the synthetic data"
+			return nil
+		}
+		return fmt.Errorf("failed to get the job sending the synthetic data: %w", err)
+	}
+	if failed := jobCondition(job, batchv1.JobFailed); failed != nil {
+		complete(params, changed, v1alpha1.CollectorTestPhaseFailed, fmt.Sprintf("the synthetic data could not be sent to the candidate collector: %s", failed.Message))
+		return nil
+	}
+	if jobCondition(job, batchv1.JobComplete) == nil {
+		if timedOut {
+			complete(params, changed, v1alpha1.CollectorTestPhaseFailed, fmt.Sprintf("the synthetic data was not sent within %s", timeout))
+			return nil
+		}
+		changed.Status.Message = "sending the synthetic data"
+		return nil
+	}
+
+	received, err := scrape(ctx, *changed)
+	if err != nil {
+		if timedOut {
+			complete(params, changed, v1alpha1.CollectorTestPhaseFailed, fmt.Sprintf("the data received by the sink could not be read: %s", err))
+			return nil
+		}
+		changed.Status.Message = fmt.Sprintf("reading the data received by the sink: %s", err)
+		return nil
+	}
+	results, failed, passed := Evaluate(*changed, received)
+	// the data is still being processed while the received items change between two polls
+	stable := reflect.DeepEqual(results, changed.Status.Results)
+	changed.Status.Results = results
+	switch {
+	case failed:
+		complete(params, changed, v1alpha1.CollectorTestPhaseFailed, "the sink received more data than expected")
+	case passed && stable:
+		complete(params, changed, v1alpha1.CollectorTestPhasePassed, "all the expectations are met")
+	case timedOut && !passed:
+		complete(params, changed, v1alpha1.CollectorTestPhaseFailed, fmt.Sprintf("the expectations were not met within %s", timeout))
+	case timedOut:
+		complete(params, changed, v1alpha1.CollectorTestPhasePassed, "all the expectations are met")
+	default:
+		changed.Status.Message = "waiting for the sink to receive the data"
+	}
+	return nil
+}
+
+// candidateDiagnosis returns the cause of the crashes of the candidate collector, if any.
+func candidateDiagnosis(ctx context.Context, params collectortest.Params) string {
+	candidate := &v1beta1.OpenTelemetryCollector{}
+	key := client.ObjectKey{Namespace: params.Test.Namespace, Name: naming.CollectorTestCandidate(params.Test.Name)}
+	if err := params.Client.Get(ctx, key, candidate); err != nil {
+		return ""
+	}
+	condition := meta.FindStatusCondition(candidate.Status.Conditions, v1beta1.ConditionTypeCrashLooping)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return ""
+	}
+	return condition.Message
+}
+
+func jobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		if job.Status.Conditions[i].Type == conditionType && job.Status.Conditions[i].Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func complete(params collectortest.Params, changed *v1alpha1.OpenTelemetryCollectorTest, phase v1alpha1.CollectorTestPhase, msg string) {
+	now := metav1.Now()
+	changed.Status.Phase = phase
+	changed.Status.Message = msg
+	changed.Status.CompletionTime = &now
+	if phase == v1alpha1.CollectorTestPhasePassed {
+		params.Recorder.Event(changed, eventTypeNormal, reasonPassed, msg)
+	} else {
+		params.Recorder.Event(changed, eventTypeWarning, reasonFailed, msg)
+	}
+}
+
+// IsCompleted returns whether the test passed or failed.
+func IsCompleted(test v1alpha1.OpenTelemetryCollectorTest) bool {
+	return isCompleted(test.Status.Phase)
+}
+
+func isCompleted(phase v1alpha1.CollectorTestPhase) bool {
+	return phase == v1alpha1.CollectorTestPhasePassed || phase == v1alpha1.CollectorTestPhaseFailed
+}
+
+func patchStatus(ctx context.Context, params collectortest.Params, changed *v1alpha1.OpenTelemetryCollectorTest) error {
+	statusPatch := client.MergeFrom(&params.Test)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpenTelemetryCollectorTest CR: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collectortest"
+)
+
+func newParams(t *testing.T, test *v1alpha1.OpenTelemetryCollectorTest, objs ...client.Object) collectortest.Params {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	objs = append(objs, test)
+	return collectortest.Params{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(test).WithObjects(objs...).Build(),
+		Recorder: record.NewFakeRecorder(10),
+		Scheme:   scheme,
+		Log:      logr.Discard(),
+		Test:     *test,
+	}
+}
+
+func newTest() *v1alpha1.OpenTelemetryCollectorTest {
+	return &v1alpha1.OpenTelemetryCollectorTest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-test",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.OpenTelemetryCollectorTestSpec{
+			Inputs: []v1alpha1.CollectorTestInput{{Signal: v1alpha1.CollectorTestSignalTraces}},
+		},
+	}
+}
+
+func completedJob(conditionType batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-test-collector-test",
+			Namespace: "default",
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}},
+		},
+	}
+}
+
+func staticScraper(received map[v1alpha1.CollectorTestSignal]int64) Scraper {
+	return func(context.Context, v1alpha1.OpenTelemetryCollectorTest) (map[v1alpha1.CollectorTestSignal]int64, error) {
+		return received, nil
+	}
+}
+
+func getTest(t *testing.T, params collectortest.Params) v1alpha1.OpenTelemetryCollectorTest {
+	test := v1alpha1.OpenTelemetryCollectorTest{}
+	require.NoError(t, params.Client.Get(context.Background(), client.ObjectKeyFromObject(&params.Test), &test))
+	return test
+}
+
+func TestHandleReconcileStatusWaitingForCollectors(t *testing.T) {
+	params := newParams(t, newTest())
+
+	res, err := HandleReconcileStatus(context.Background(), logr.Discard(), params, staticScraper(nil), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, pollInterval, res.RequeueAfter)
+	test := getTest(t, params)
+	assert.Equal(t, v1alpha1.CollectorTestPhaseRunning, test.Status.Phase)
+	assert.NotNil(t, test.Status.StartTime)
+}
+
+func TestHandleReconcileStatusTimeout(t *testing.T) {
+	test := newTest()
+	test.Spec.Timeout = &metav1.Duration{Duration: 0}
+	started := metav1.NewTime(time.Now().Add(-time.Second))
+	test.Status.StartTime = &started
+	params := newParams(t, test)
+
+	_, err := HandleReconcileStatus(context.Background(), logr.Discard(), params, staticScraper(nil), nil)
+	require.NoError(t, err)
+
+	updated := getTest(t, params)
+	assert.Equal(t, v1alpha1.CollectorTestPhaseFailed, updated.Status.Phase)
+	assert.Contains(t, updated.Status.Message, "did not become ready")
+	assert.NotNil(t, updated.Status.CompletionTime)
+}
+
+func TestHandleReconcileStatusInvalidTest(t *testing.T) {
+	params := newParams(t, newTest())
+
+	res, err := HandleReconcileStatus(context.Background(), logr.Discard(), params, staticScraper(nil), collectortest.ErrInvalidTest)
+	require.NoError(t, err)
+
+	assert.Zero(t, res.RequeueAfter)
+	assert.Equal(t, v1alpha1.CollectorTestPhaseFailed, getTest(t, params).Status.Phase)
+}
+
+func TestHandleReconcileStatusJobFailed(t *testing.T) {
+	params := newParams(t, newTest(), completedJob(batchv1.JobFailed))
+	params.CollectorsReady = true
+
+	res, err := HandleReconcileStatus(context.Background(), logr.Discard(), params, staticScraper(nil), nil)
+	require.NoError(t, err)
+
+	assert.True(t, res.Requeue)
+	updated := getTest(t, params)
+	assert.Equal(t, v1alpha1.CollectorTestPhaseFailed, updated.Status.Phase)
+	assert.Contains(t, updated.Status.Message, "BackoffLimitExceeded")
+}
+
+func TestHandleReconcileStatusPassed(t *testing.T) {
+	params := newParams(t, newTest(), completedJob(batchv1.JobComplete))
+	params.CollectorsReady = true
+	scrape := staticScraper(map[v1alpha1.CollectorTestSignal]int64{v1alpha1.CollectorTestSignalTraces: 10})
+
+	// the test passes once the received items are the same in two polls
+	_, err := HandleReconcileStatus(context.Background(), logr.Discard(), params, scrape, nil)
+	require.NoError(t, err)
+	updated := getTest(t, params)
+	assert.Equal(t, v1alpha1.CollectorTestPhaseRunning, updated.Status.Phase)
+	require.Len(t, updated.Status.Results, 1)
+
+	params.Test = updated
+	_, err = HandleReconcileStatus(context.Background(), logr.Discard(), params, scrape, nil)
+	require.NoError(t, err)
+	updated = getTest(t, params)
+	assert.Equal(t, v1alpha1.CollectorTestPhasePassed, updated.Status.Phase)
+	assert.True(t, updated.Status.Results[0].Passed)
+	assert.Equal(t, int64(10), updated.Status.Results[0].ReceivedItems)
+	assert.True(t, IsCompleted(updated))
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collectortest"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// receivedItemsMetrics are the internal metrics of the collector counting the items accepted by the receivers.
+var receivedItemsMetrics = map[string]v1alpha1.CollectorTestSignal{
+	"otelcol_receiver_accepted_spans":         v1alpha1.CollectorTestSignalTraces,
+	"otelcol_receiver_accepted_metric_points": v1alpha1.CollectorTestSignalMetrics,
+	"otelcol_receiver_accepted_log_records":   v1alpha1.CollectorTestSignalLogs,
+}
+
+// Scraper returns the number of items received by the sink collector of a test, per signal.
+type Scraper func(ctx context.Context, test v1alpha1.OpenTelemetryCollectorTest) (map[v1alpha1.CollectorTestSignal]int64, error)
+
+// ScrapeSink reads the number of items received by the sink collector from its internal metrics.
+func ScrapeSink(ctx context.Context, test v1alpha1.OpenTelemetryCollectorTest) (map[v1alpha1.CollectorTestSignal]int64, error) {
+	url := fmt.Sprintf("http://%s.%s.svc:%d/metrics", naming.MonitoringService(naming.CollectorTestSink(test.Name)), test.Namespace, collectortest.SinkMetricsPort)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape the sink metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape the sink metrics: unexpected status %s", resp.Status)
+	}
+	return ReceivedItems(resp.Body)
+}
+
+// ReceivedItems sums the items accepted by the receivers from internal metrics of a collector, in the Prometheus
+// text format.
+func ReceivedItems(metrics io.Reader) (map[v1alpha1.CollectorTestSignal]int64, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the sink metrics: %w", err)
+	}
+
+	received := map[v1alpha1.CollectorTestSignal]int64{}
+	for name, family := range families {
+		signal, ok := receivedItemsMetrics[strings.TrimSuffix(name, "_total")]
+		if !ok {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			received[signal] += int64(metric.GetCounter().GetValue())
+		}
+	}
+	return received, nil
+}
+
+// Evaluate returns the results of the expectations of the test for the received items. The test failed if a maximum
+// is exceeded, as the received items can only grow, and passed when all the expectations are met.
+func Evaluate(test v1alpha1.OpenTelemetryCollectorTest, received map[v1alpha1.CollectorTestSignal]int64) ([]v1alpha1.CollectorTestResult, bool, bool) {
+	var results []v1alpha1.CollectorTestResult
+	failed, passed := false, true
+	for _, expectation := range expectations(test) {
+		count := received[expectation.Signal]
+		result := v1alpha1.CollectorTestResult{
+			Signal:        expectation.Signal,
+			ReceivedItems: count,
+			Passed:        true,
+		}
+		expected := []string{}
+		if expectation.MinItems != nil {
+			expected = append(expected, fmt.Sprintf("at least %d", *expectation.MinItems))
+			if count < *expectation.MinItems {
+				result.Passed = false
+			}
+		}
+		if expectation.MaxItems != nil {
+			expected = append(expected, fmt.Sprintf("at most %d", *expectation.MaxItems))
+			if count > *expectation.MaxItems {
+				result.Passed = false
+				failed = true
+			}
+		}
+		if len(expected) == 0 {
+			expected = append(expected, "any number of")
+		}
+		result.Message = fmt.Sprintf("expected %s items, received %d", strings.Join(expected, " and "), count)
+		passed = passed && result.Passed
+		results = append(results, result)
+	}
+	return results, failed, passed
+}
+
+// expectations returns the expectations of the test, defaulting to receiving data for every signal of the inputs.
+func expectations(test v1alpha1.OpenTelemetryCollectorTest) []v1alpha1.CollectorTestExpectation {
+	if len(test.Spec.Expectations) > 0 {
+		return test.Spec.Expectations
+	}
+	var defaults []v1alpha1.CollectorTestExpectation
+	seen := map[v1alpha1.CollectorTestSignal]bool{}
+	for _, input := range test.Spec.Inputs {
+		if seen[input.Signal] {
+			continue
+		}
+		seen[input.Signal] = true
+		minItems := int64(1)
+		defaults = append(defaults, v1alpha1.CollectorTestExpectation{Signal: input.Signal, MinItems: &minItems})
+	}
+	return defaults
+}
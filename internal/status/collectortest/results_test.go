@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectortest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestReceivedItems(t *testing.T) {
+	metrics := `# HELP otelcol_receiver_accepted_spans Number of spans successfully pushed into the pipeline.
+# TYPE otelcol_receiver_accepted_spans counter
+otelcol_receiver_accepted_spans{receiver="otlp",transport="grpc"} 7
+otelcol_receiver_accepted_spans{receiver="otlp",transport="http"} 3
+# HELP otelcol_receiver_accepted_log_records_total Number of log records successfully pushed into the pipeline.
+# TYPE otelcol_receiver_accepted_log_records_total counter
+otelcol_receiver_accepted_log_records_total{receiver="otlp",transport="grpc"} 4
+# HELP otelcol_process_uptime Uptime of the process
+# TYPE otelcol_process_uptime counter
+otelcol_process_uptime 12.5
+`
+	received, err := ReceivedItems(strings.NewReader(metrics))
+	require.NoError(t, err)
+	assert.Equal(t, map[v1alpha1.CollectorTestSignal]int64{
+		v1alpha1.CollectorTestSignalTraces: 10,
+		v1alpha1.CollectorTestSignalLogs:   4,
+	}, received)
+}
+
+func TestEvaluate(t *testing.T) {
+	zero, five := int64(0), int64(5)
+	for _, tt := range []struct {
+		desc           string
+		spec           v1alpha1.OpenTelemetryCollectorTestSpec
+		received       map[v1alpha1.CollectorTestSignal]int64
+		expectedFailed bool
+		expectedPassed bool
+	}{
+		{
+			desc: "default expectations met",
+			spec: v1alpha1.OpenTelemetryCollectorTestSpec{
+				Inputs: []v1alpha1.CollectorTestInput{{Signal: v1alpha1.CollectorTestSignalTraces}, {Signal: v1alpha1.CollectorTestSignalTraces}},
+			},
+			received:       map[v1alpha1.CollectorTestSignal]int64{v1alpha1.CollectorTestSignalTraces: 1},
+			expectedPassed: true,
+		},
+		{
+			desc: "default expectations not met yet",
+			spec: v1alpha1.OpenTelemetryCollectorTestSpec{
+				Inputs: []v1alpha1.CollectorTestInput{{Signal: v1alpha1.CollectorTestSignalTraces}, {Signal: v1alpha1.CollectorTestSignalLogs}},
+			},
+			received: map[v1alpha1.CollectorTestSignal]int64{v1alpha1.CollectorTestSignalTraces: 1},
+		},
+		{
+			desc: "maximum exceeded",
+			spec: v1alpha1.OpenTelemetryCollectorTestSpec{
+				Inputs:       []v1alpha1.CollectorTestInput{{Signal: v1alpha1.CollectorTestSignalLogs}},
+				Expectations: []v1alpha1.CollectorTestExpectation{{Signal: v1alpha1.CollectorTestSignalLogs, MaxItems: &zero}},
+			},
+			received:       map[v1alpha1.CollectorTestSignal]int64{v1alpha1.CollectorTestSignalLogs: 2},
+			expectedFailed: true,
+		},
+		{
+			desc: "range met",
+			spec: v1alpha1.OpenTelemetryCollectorTestSpec{
+				Inputs:       []v1alpha1.CollectorTestInput{{Signal: v1alpha1.CollectorTestSignalMetrics}},
+				Expectations: []v1alpha1.CollectorTestExpectation{{Signal: v1alpha1.CollectorTestSignalMetrics, MinItems: &five, MaxItems: &five}},
+			},
+			received:       map[v1alpha1.CollectorTestSignal]int64{v1alpha1.CollectorTestSignalMetrics: 5},
+			expectedPassed: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			test := v1alpha1.OpenTelemetryCollectorTest{Spec: tt.spec}
+			results, failed, passed := Evaluate(test, tt.received)
+			assert.Equal(t, tt.expectedFailed, failed)
+			assert.Equal(t, tt.expectedPassed, passed)
+			for _, result := range results {
+				assert.Equal(t, tt.received[result.Signal], result.ReceivedItems)
+				assert.NotEmpty(t, result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	fleetmanifests "github.com/open-telemetry/opentelemetry-operator/internal/manifests/fleet"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	reasonRolloutComplete   = "RolloutComplete"
+	reasonRolloutInProgress = "RolloutInProgress"
+	reasonClusterDegraded   = "ClusterDegraded"
+	reasonNoClusterSelected = "NoClusterSelected"
+)
+
+// UpdateFleetStatus computes the rollout status of the fleet from the objects distributing the collector to the
+// selected clusters.
+func UpdateFleetStatus(ctx context.Context, cli client.Client, changed *v1alpha1.OpenTelemetryCollectorFleet, clusters []unstructured.Unstructured) error {
+	var statuses []v1alpha1.FleetClusterStatus
+	for _, cluster := range clusters {
+		status, err := clusterStatus(ctx, cli, *changed, cluster)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, status)
+	}
+	setRolloutStatus(changed, statuses)
+	return nil
+}
+
+func clusterStatus(ctx context.Context, cli client.Client, fleet v1alpha1.OpenTelemetryCollectorFleet, cluster unstructured.Unstructured) (v1alpha1.FleetClusterStatus, error) {
+	status := v1alpha1.FleetClusterStatus{
+		Name:      cluster.GetName(),
+		Namespace: cluster.GetNamespace(),
+		State:     v1alpha1.FleetClusterStatePending,
+	}
+	name := naming.CollectorFleet(fleet.Name)
+
+	obj := &unstructured.Unstructured{}
+	key := types.NamespacedName{Namespace: cluster.GetName(), Name: name}
+	if fleet.Spec.Provider == v1alpha1.FleetClusterProviderClusterAPI {
+		// the bindings of a cluster are named after the cluster
+		obj.SetGroupVersionKind(fleetmanifests.ClusterResourceSetBindingGVK)
+		key = types.NamespacedName{Namespace: cluster.GetNamespace(), Name: cluster.GetName()}
+	} else {
+		obj.SetGroupVersionKind(fleetmanifests.ManifestWorkGVK)
+	}
+	if err := cli.Get(ctx, key, obj); apierrors.IsNotFound(err) {
+		return status, nil
+	} else if err != nil {
+		return status, fmt.Errorf("failed to get the rollout status of cluster %s: %w", cluster.GetName(), err)
+	}
+
+	if fleet.Spec.Provider == v1alpha1.FleetClusterProviderClusterAPI {
+		status.State, status.Message = clusterResourceSetBindingState(obj, name)
+	} else {
+		status.State, status.Message = manifestWorkState(obj)
+	}
+	return status, nil
+}
+
+// manifestWorkState returns the state of the collector from the conditions reported by the ManifestWork.
+func manifestWorkState(work *unstructured.Unstructured) (v1alpha1.FleetClusterState, string) {
+	conditions, _, _ := unstructured.NestedSlice(work.Object, "status", "conditions")
+	byType := map[string]map[string]interface{}{}
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]interface{}); ok {
+			conditionType, _ := condition["type"].(string)
+			byType[conditionType] = condition
+		}
+	}
+	status := func(conditionType string) string {
+		s, _ := byType[conditionType]["status"].(string)
+		return s
+	}
+	message := func(conditionType string) string {
+		m, _ := byType[conditionType]["message"].(string)
+		return m
+	}
+
+	switch {
+	case status("Degraded") == string(metav1.ConditionTrue):
+		return v1alpha1.FleetClusterStateDegraded, message("Degraded")
+	case status("Applied") == string(metav1.ConditionFalse):
+		return v1alpha1.FleetClusterStateDegraded, message("Applied")
+	case status("Available") == string(metav1.ConditionTrue):
+		return v1alpha1.FleetClusterStateAvailable, ""
+	case status("Applied") == string(metav1.ConditionTrue):
+		return v1alpha1.FleetClusterStateApplied, message("Available")
+	}
+	return v1alpha1.FleetClusterStatePending, ""
+}
+
+// clusterResourceSetBindingState returns the state of the collector from the resources of the ClusterResourceSet
+// reported as applied by the binding of the cluster.
+func clusterResourceSetBindingState(binding *unstructured.Unstructured, clusterResourceSet string) (v1alpha1.FleetClusterState, string) {
+	bindings, _, _ := unstructured.NestedSlice(binding.Object, "spec", "bindings")
+	for _, b := range bindings {
+		resourceSetBinding, ok := b.(map[string]interface{})
+		if !ok || resourceSetBinding["clusterResourceSetName"] != clusterResourceSet {
+			continue
+		}
+		resources, _, _ := unstructured.NestedSlice(resourceSetBinding, "resources")
+		for _, r := range resources {
+			if resource, ok := r.(map[string]interface{}); ok {
+				if applied, _ := resource["applied"].(bool); applied {
+					return v1alpha1.FleetClusterStateApplied, ""
+				}
+			}
+		}
+	}
+	return v1alpha1.FleetClusterStatePending, ""
+}
+
+// isReady returns whether the collector runs in the cluster. The availability of the collector is only reported
+// by Open Cluster Management, the collectors applied with Cluster API are considered ready.
+func isReady(fleet v1alpha1.OpenTelemetryCollectorFleet, status v1alpha1.FleetClusterStatus) bool {
+	if fleet.Spec.Provider == v1alpha1.FleetClusterProviderClusterAPI {
+		return status.State == v1alpha1.FleetClusterStateApplied
+	}
+	return status.State == v1alpha1.FleetClusterStateAvailable
+}
+
+func setRolloutStatus(fleet *v1alpha1.OpenTelemetryCollectorFleet, statuses []v1alpha1.FleetClusterStatus) {
+	var applied, available, ready int32
+	var notReady, degraded []string
+	for _, status := range statuses {
+		switch status.State {
+		case v1alpha1.FleetClusterStateAvailable:
+			applied++
+			available++
+		case v1alpha1.FleetClusterStateApplied:
+			applied++
+		case v1alpha1.FleetClusterStateDegraded:
+			degraded = append(degraded, status.Name)
+		}
+		if isReady(*fleet, status) {
+			ready++
+		} else {
+			notReady = append(notReady, status.Name)
+		}
+	}
+
+	fleet.Status.ObservedGeneration = fleet.Generation
+	fleet.Status.Clusters = statuses
+	fleet.Status.SelectedClusters = int32(len(statuses))
+	fleet.Status.AppliedClusters = applied
+	fleet.Status.AvailableClusters = available
+	fleet.Status.Rollout = fmt.Sprintf("%d/%d", ready, len(statuses))
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.FleetConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonRolloutComplete,
+		Message:            "the collector runs in all the selected clusters",
+		ObservedGeneration: fleet.Generation,
+	}
+	switch {
+	case len(statuses) == 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonNoClusterSelected
+		condition.Message = "no cluster is selected by the fleet"
+	case len(degraded) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonClusterDegraded
+		condition.Message = fmt.Sprintf("the collector is degraded in the clusters: %s", strings.Join(degraded, ", "))
+	case len(notReady) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonRolloutInProgress
+		condition.Message = fmt.Sprintf("the collector doesn't run yet in the clusters: %s", strings.Join(notReady, ", "))
+	}
+	meta.SetStatusCondition(&fleet.Status.Conditions, condition)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func manifestWork(conditions ...map[string]interface{}) *unstructured.Unstructured {
+	var items []interface{}
+	for _, c := range conditions {
+		items = append(items, c)
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"conditions": items},
+	}}
+}
+
+func condition(conditionType, status, message string) map[string]interface{} {
+	return map[string]interface{}{"type": conditionType, "status": status, "message": message}
+}
+
+func TestManifestWorkState(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		work            *unstructured.Unstructured
+		expectedState   v1alpha1.FleetClusterState
+		expectedMessage string
+	}{
+		{
+			name:          "no condition",
+			work:          manifestWork(),
+			expectedState: v1alpha1.FleetClusterStatePending,
+		},
+		{
+			name:            "applied",
+			work:            manifestWork(condition("Applied", "True", ""), condition("Available", "False", "not all the resources are available")),
+			expectedState:   v1alpha1.FleetClusterStateApplied,
+			expectedMessage: "not all the resources are available",
+		},
+		{
+			name:          "available",
+			work:          manifestWork(condition("Applied", "True", ""), condition("Available", "True", "")),
+			expectedState: v1alpha1.FleetClusterStateAvailable,
+		},
+		{
+			name:            "failed to apply",
+			work:            manifestWork(condition("Applied", "False", "no matches for kind OpenTelemetryCollector")),
+			expectedState:   v1alpha1.FleetClusterStateDegraded,
+			expectedMessage: "no matches for kind OpenTelemetryCollector",
+		},
+		{
+			name:            "degraded",
+			work:            manifestWork(condition("Applied", "True", ""), condition("Available", "True", ""), condition("Degraded", "True", "collector is crashing")),
+			expectedState:   v1alpha1.FleetClusterStateDegraded,
+			expectedMessage: "collector is crashing",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			state, message := manifestWorkState(tt.work)
+			assert.Equal(t, tt.expectedState, state)
+			assert.Equal(t, tt.expectedMessage, message)
+		})
+	}
+}
+
+func TestClusterResourceSetBindingState(t *testing.T) {
+	binding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"bindings": []interface{}{
+				map[string]interface{}{
+					"clusterResourceSetName": "other",
+					"resources":              []interface{}{map[string]interface{}{"name": "other", "kind": "ConfigMap", "applied": true}},
+				},
+				map[string]interface{}{
+					"clusterResourceSetName": "edge-collector-fleet",
+					"resources":              []interface{}{map[string]interface{}{"name": "edge-collector-fleet", "kind": "ConfigMap", "applied": false}},
+				},
+			},
+		},
+	}}
+	state, _ := clusterResourceSetBindingState(binding, "edge-collector-fleet")
+	assert.Equal(t, v1alpha1.FleetClusterStatePending, state)
+
+	state, _ = clusterResourceSetBindingState(binding, "other")
+	assert.Equal(t, v1alpha1.FleetClusterStateApplied, state)
+}
+
+func TestSetRolloutStatus(t *testing.T) {
+	t.Run("rollout in progress", func(t *testing.T) {
+		fleet := &v1alpha1.OpenTelemetryCollectorFleet{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+		setRolloutStatus(fleet, []v1alpha1.FleetClusterStatus{
+			{Name: "cluster-a", State: v1alpha1.FleetClusterStateAvailable},
+			{Name: "cluster-b", State: v1alpha1.FleetClusterStateApplied},
+			{Name: "cluster-c", State: v1alpha1.FleetClusterStatePending},
+		})
+
+		assert.Equal(t, int64(2), fleet.Status.ObservedGeneration)
+		assert.Equal(t, "1/3", fleet.Status.Rollout)
+		assert.Equal(t, int32(3), fleet.Status.SelectedClusters)
+		assert.Equal(t, int32(2), fleet.Status.AppliedClusters)
+		assert.Equal(t, int32(1), fleet.Status.AvailableClusters)
+		ready := meta.FindStatusCondition(fleet.Status.Conditions, v1alpha1.FleetConditionReady)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, reasonRolloutInProgress, ready.Reason)
+		assert.Contains(t, ready.Message, "cluster-b, cluster-c")
+	})
+
+	t.Run("rollout complete with cluster api", func(t *testing.T) {
+		fleet := &v1alpha1.OpenTelemetryCollectorFleet{
+			Spec: v1alpha1.OpenTelemetryCollectorFleetSpec{Provider: v1alpha1.FleetClusterProviderClusterAPI},
+		}
+		setRolloutStatus(fleet, []v1alpha1.FleetClusterStatus{
+			{Name: "cluster-a", State: v1alpha1.FleetClusterStateApplied},
+		})
+
+		assert.Equal(t, "1/1", fleet.Status.Rollout)
+		assert.True(t, meta.IsStatusConditionTrue(fleet.Status.Conditions, v1alpha1.FleetConditionReady))
+	})
+
+	t.Run("degraded cluster", func(t *testing.T) {
+		fleet := &v1alpha1.OpenTelemetryCollectorFleet{}
+		setRolloutStatus(fleet, []v1alpha1.FleetClusterStatus{
+			{Name: "cluster-a", State: v1alpha1.FleetClusterStateDegraded},
+		})
+
+		ready := meta.FindStatusCondition(fleet.Status.Conditions, v1alpha1.FleetConditionReady)
+		assert.Equal(t, reasonClusterDegraded, ready.Reason)
+	})
+
+	t.Run("no cluster selected", func(t *testing.T) {
+		fleet := &v1alpha1.OpenTelemetryCollectorFleet{}
+		setRolloutStatus(fleet, nil)
+
+		assert.Equal(t, "0/0", fleet.Status.Rollout)
+		ready := meta.FindStatusCondition(fleet.Status.Conditions, v1alpha1.FleetConditionReady)
+		assert.Equal(t, reasonNoClusterSelected, ready.Reason)
+	})
+}
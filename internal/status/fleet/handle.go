@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	fleetmanifests "github.com/open-telemetry/opentelemetry-operator/internal/manifests/fleet"
+)
+
+const (
+	eventTypeWarning = "Warning"
+
+	reasonError         = "Error"
+	reasonStatusFailure = "StatusFailure"
+
+	// statusRefreshInterval is the interval the rollout status is refreshed at, as the operator doesn't watch the
+	// objects reporting the status of the managed clusters.
+	statusRefreshInterval = time.Minute
+)
+
+// HandleReconcileStatus handles updating the status of the fleets managed by the operator.
+func HandleReconcileStatus(ctx context.Context, log logr.Logger, params fleetmanifests.Params, err error) (ctrl.Result, error) {
+	log.V(2).Info("updating fleet status")
+	changed := params.Fleet.DeepCopy()
+	if err != nil {
+		params.Recorder.Event(&params.Fleet, eventTypeWarning, reasonError, err.Error())
+		meta.SetStatusCondition(&changed.Status.Conditions, metav1.Condition{
+			Type:               v1alpha1.FleetConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             reasonError,
+			Message:            err.Error(),
+			ObservedGeneration: changed.Generation,
+		})
+		if patchErr := patchStatus(ctx, params, changed); patchErr != nil {
+			log.Error(patchErr, "failed to apply status changes")
+		}
+		return ctrl.Result{}, err
+	}
+
+	statusErr := UpdateFleetStatus(ctx, params.Client, changed, params.Clusters)
+	if statusErr != nil {
+		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
+		return ctrl.Result{}, statusErr
+	}
+	if err := patchStatus(ctx, params, changed); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: statusRefreshInterval}, nil
+}
+
+func patchStatus(ctx context.Context, params fleetmanifests.Params, changed *v1alpha1.OpenTelemetryCollectorFleet) error {
+	statusPatch := client.MergeFrom(&params.Fleet)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return fmt.Errorf("failed to apply status changes to the OpenTelemetryCollectorFleet CR: %w", err)
+	}
+	return nil
+}
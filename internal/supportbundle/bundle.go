@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package supportbundle contains the support bundles of the managed instances, gzipped tar archives gathering the
+// state of an instance to attach to the issues filed about it.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ErrorsFile is the file of the bundle listing the parts of the state which couldn't be gathered.
+const ErrorsFile = "errors.txt"
+
+// Bundle is a support bundle being gathered. The parts of the state which can't be gathered are listed in the
+// errors file instead of failing the whole bundle.
+type Bundle struct {
+	files  []file
+	errors []string
+}
+
+type file struct {
+	name    string
+	content []byte
+}
+
+// Add adds a file to the bundle.
+func (b *Bundle) Add(name string, content []byte) {
+	b.files = append(b.files, file{name: name, content: content})
+}
+
+// AddYAML adds a file holding the YAML representation of the object to the bundle.
+func (b *Bundle) AddYAML(name string, obj interface{}) {
+	content, err := yaml.Marshal(obj)
+	if err != nil {
+		b.AddError(name, err)
+		return
+	}
+	b.Add(name, content)
+}
+
+// AddError records that the file couldn't be gathered.
+func (b *Bundle) AddError(name string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %s", name, err))
+}
+
+// Files returns the names of the files of the bundle.
+func (b *Bundle) Files() []string {
+	var names []string
+	for _, f := range b.files {
+		names = append(names, f.name)
+	}
+	if len(b.errors) > 0 {
+		names = append(names, ErrorsFile)
+	}
+	return names
+}
+
+// Write writes the bundle as a gzipped tar archive, with the files in the given directory.
+func (b *Bundle) Write(w io.Writer, dir string, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	files := b.files
+	if len(b.errors) > 0 {
+		files = append(files, file{name: ErrorsFile, content: []byte(strings.Join(b.errors, "\n") + "\n")})
+	}
+	for _, f := range files {
+		header := &tar.Header{
+			Name:    dir + "/" + f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.content)),
+			ModTime: modTime,
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write the header of %s: %w", f.name, err)
+		}
+		if _, err := archive.Write(f.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
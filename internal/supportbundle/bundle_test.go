@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readArchive(t *testing.T, r io.Reader) map[string]string {
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	archive := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(archive)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	b := &Bundle{}
+	b.Add("config.yaml", []byte("receivers: {}\n"))
+	b.AddYAML("status.yaml", map[string]string{"version": "0.104.0"})
+	b.AddError("events.yaml", errors.New("forbidden"))
+	assert.Equal(t, []string{"config.yaml", "status.yaml", ErrorsFile}, b.Files())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, b.Write(buf, "default-simplest", time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, map[string]string{
+		"default-simplest/config.yaml": "receivers: {}\n",
+		"default-simplest/status.yaml": "version: 0.104.0\n",
+		"default-simplest/errors.txt":  "events.yaml: forbidden\n",
+	}, readArchive(t, buf))
+}
@@ -19,18 +19,24 @@ import (
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
 	"time"
+	// the time zones of the config schedules are loaded from the embedded database, as the image has none
+	_ "time/tzdata"
 
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/spf13/pflag"
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -40,6 +46,8 @@ import (
 	k8sapiflag "k8s.io/component-base/cli/flag"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -109,6 +117,8 @@ func main() {
 		metricsAddr                      string
 		probeAddr                        string
 		pprofAddr                        string
+		debugAddr                        string
+		debugCertDir                     string
 		enableLeaderElection             bool
 		createRBACPermissions            bool
 		enableMultiInstrumentation       bool
@@ -120,9 +130,13 @@ func main() {
 		enableNodeJSInstrumentation      bool
 		enableJavaInstrumentation        bool
 		enableCRMetrics                  bool
+		enablePipelineDefaults           bool
 		collectorImage                   string
+		collectorConfigProviders         []string
 		targetAllocatorImage             string
 		operatorOpAMPBridgeImage         string
+		telemetrygenImage                string
+		exporterCheckImage               string
 		autoInstrumentationJava          string
 		autoInstrumentationNodeJS        string
 		autoInstrumentationPython        string
@@ -138,11 +152,18 @@ func main() {
 		encodeLevelKey                   string
 		encodeTimeKey                    string
 		encodeLevelFormat                string
+		upgradeWindow                    string
+		upgradeWindowDuration            time.Duration
+		upgradeRollbackTimeout           time.Duration
+		reconcileFairness                config.ReconcileFairness
+		collectorNameTemplate            string
 	)
 
 	pflag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-addr", ":8081", "The address the probe endpoint binds to.")
 	pflag.StringVar(&pprofAddr, "pprof-addr", "", "The address to expose the pprof server. Default is empty string which disables the pprof server.")
+	pflag.StringVar(&debugAddr, "debug-addr", "", "The address to expose the debug endpoints of the collectors, serving their support bundles at /support-bundle/{namespace}/{name} and their effective configs at /effective-config/{namespace}/{name}. The debug server is served over TLS only, as the requests carry the bearer tokens of the users. Default is empty string which disables the debug server.")
+	pflag.StringVar(&debugCertDir, "debug-cert-dir", filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"), "The directory holding the tls.crt and tls.key files of the certificate of the debug server. Defaults to the one of the webhook server.")
 	pflag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -156,10 +177,14 @@ func main() {
 	pflag.BoolVar(&enableNodeJSInstrumentation, constants.FlagNodeJS, true, "Controls whether the operator supports nodejs auto-instrumentation")
 	pflag.BoolVar(&enableJavaInstrumentation, constants.FlagJava, true, "Controls whether the operator supports java auto-instrumentation")
 	pflag.BoolVar(&enableCRMetrics, constants.FlagCRMetrics, false, "Controls whether exposing the CR metrics is enabled")
+	pflag.BoolVar(&enablePipelineDefaults, "enable-pipeline-defaults", false, "Controls whether the operator adds a batch processor, and the sending_queue and retry_on_failure settings of the OTLP exporters, to the collector pipelines lacking them")
 
 	stringFlagOrEnv(&collectorImage, "collector-image", "RELATED_IMAGE_COLLECTOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringSliceVar(&collectorConfigProviders, "collector-config-providers", []string{"env", "file", "http", "https", "yaml"}, "Comma-separated list of the confmap providers available in the default OpenTelemetry collector image, used to validate the spec.configProviders of the collectors.")
 	stringFlagOrEnv(&targetAllocatorImage, "target-allocator-image", "RELATED_IMAGE_TARGET_ALLOCATOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&operatorOpAMPBridgeImage, "operator-opamp-bridge-image", "RELATED_IMAGE_OPERATOR_OPAMP_BRIDGE", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/operator-opamp-bridge:%s", v.OperatorOpAMPBridge), "The default OpenTelemetry Operator OpAMP Bridge image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&telemetrygenImage, "telemetrygen-image", "RELATED_IMAGE_TELEMETRYGEN", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-contrib/telemetrygen:v%s", v.OpenTelemetryCollector), "The image sending synthetic data to the collectors in the OpenTelemetryCollectorTests.")
+	stringFlagOrEnv(&exporterCheckImage, "exporter-check-image", "RELATED_IMAGE_EXPORTER_CHECK", "docker.io/library/busybox:1.36", "The image checking the reachability of the exporter endpoints of the collectors, which has to provide sh and nc.")
 	stringFlagOrEnv(&autoInstrumentationJava, "auto-instrumentation-java-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_JAVA", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:%s", v.AutoInstrumentationJava), "The default OpenTelemetry Java instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationNodeJS, "auto-instrumentation-nodejs-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_NODEJS", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-nodejs:%s", v.AutoInstrumentationNodeJS), "The default OpenTelemetry NodeJS instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationPython, "auto-instrumentation-python-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_PYTHON", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-python:%s", v.AutoInstrumentationPython), "The default OpenTelemetry Python instrumentation image. This image is used when no image is specified in the CustomResource.")
@@ -176,6 +201,13 @@ func main() {
 	pflag.StringVar(&encodeTimeKey, "zap-time-key", "timestamp", "The time key to be used in the customized Log Encoder")
 	pflag.StringVar(&encodeLevelFormat, "zap-level-format", "uppercase", "The level format to be used in the customized Log Encoder")
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook endpoint binds to.")
+	pflag.StringVar(&upgradeWindow, "upgrade-window", "", "Cron expression of the maintenance window during which the managed instances are upgraded. Upgrades are applied at any time when empty. Example: --upgrade-window='0 2 * * 6'")
+	pflag.DurationVar(&upgradeWindowDuration, "upgrade-window-duration", time.Hour, "Duration of the maintenance window set with --upgrade-window")
+	pflag.DurationVar(&upgradeRollbackTimeout, "upgrade-rollback-timeout", 10*time.Minute, "Time the managed instances have to become ready after an upgrade before the upgrade is rolled back. Set to 0 to disable the rollbacks")
+	pflag.Float64Var(&reconcileFairness.NamespaceQPS, "reconcile-namespace-qps", 0, "Rate per second of the reconciliations of the collectors triggered by changes allowed per namespace, the changes above it being delayed. Set to 0 to disable the rate limits")
+	pflag.IntVar(&reconcileFairness.NamespaceBurst, "reconcile-namespace-burst", 10, "Number of reconciliations of the collectors allowed per namespace above the rate set with --reconcile-namespace-qps")
+	pflag.StringSliceVar(&reconcileFairness.PriorityNamespaces, "reconcile-priority-namespaces", nil, "Comma-separated list of the namespaces whose collectors are reconciled first, and which aren't rate limited. A name ending with * matches the namespaces starting with the rest of the name. Example: --reconcile-priority-namespaces=kube-system,openshift-*")
+	pflag.StringVar(&collectorNameTemplate, "collector-name-template", "", "Go template of the name the Deployments, Services and ConfigMaps generated for the new collectors are named after, in place of <name>-collector, executed with the .Name, .Namespace and .Mode of the collectors. Example: --collector-name-template='otel-{{ .Namespace }}-{{ .Name }}'")
 	pflag.Parse()
 
 	opts.EncoderConfigOptions = append(opts.EncoderConfigOptions, func(ec *zapcore.EncoderConfig) {
@@ -193,6 +225,8 @@ func main() {
 		"opentelemetry-collector", collectorImage,
 		"opentelemetry-targetallocator", targetAllocatorImage,
 		"operator-opamp-bridge", operatorOpAMPBridgeImage,
+		"telemetrygen", telemetrygenImage,
+		"exporter-check", exporterCheckImage,
 		"auto-instrumentation-java", autoInstrumentationJava,
 		"auto-instrumentation-nodejs", autoInstrumentationNodeJS,
 		"auto-instrumentation-python", autoInstrumentationPython,
@@ -208,6 +242,7 @@ func main() {
 		"labels-filter", labelsFilter,
 		"annotations-filter", annotationsFilter,
 		"enable-multi-instrumentation", enableMultiInstrumentation,
+		"enable-pipeline-defaults", enablePipelineDefaults,
 		"enable-apache-httpd-instrumentation", enableApacheHttpdInstrumentation,
 		"enable-dotnet-instrumentation", enableDotNetInstrumentation,
 		"enable-go-instrumentation", enableGoInstrumentation,
@@ -219,6 +254,13 @@ func main() {
 		"zap-level-key", encodeLevelKey,
 		"zap-time-key", encodeTimeKey,
 		"zap-level-format", encodeLevelFormat,
+		"upgrade-window", upgradeWindow,
+		"upgrade-window-duration", upgradeWindowDuration,
+		"upgrade-rollback-timeout", upgradeRollbackTimeout,
+		"reconcile-namespace-qps", reconcileFairness.NamespaceQPS,
+		"reconcile-namespace-burst", reconcileFairness.NamespaceBurst,
+		"reconcile-priority-namespaces", reconcileFairness.PriorityNamespaces,
+		"collector-name-template", collectorNameTemplate,
 	)
 
 	restConfig := ctrl.GetConfigOrDie()
@@ -262,6 +304,16 @@ func main() {
 		}),
 		Cache: cache.Options{
 			DefaultNamespaces: namespaces,
+			ByObject: map[client.Object]cache.ByObject{
+				// only the injected pods are cached, to report them in the status of their Instrumentation
+				&corev1.Pod{}: {Label: labels.SelectorFromSet(labels.Set{instrumentation.InjectedPodLabel: "true"})},
+			},
+		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// the collector pods are only read to diagnose crashes, avoid caching all the pods of the cluster
+				DisableFor: []client.Object{&corev1.Pod{}},
+			},
 		},
 	}
 
@@ -286,11 +338,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	window, err := config.NewUpgradeWindow(upgradeWindow, upgradeWindowDuration)
+	if err != nil {
+		setupLog.Error(err, "invalid upgrade window")
+		os.Exit(1)
+	}
+
+	nameTemplate, err := config.NewCollectorNameTemplate(collectorNameTemplate)
+	if err != nil {
+		setupLog.Error(err, "invalid collector name template")
+		os.Exit(1)
+	}
+
 	cfg := config.New(
 		config.WithLogger(ctrl.Log.WithName("config")),
 		config.WithVersion(v),
 		config.WithCollectorImage(collectorImage),
 		config.WithEnableMultiInstrumentation(enableMultiInstrumentation),
+		config.WithEnablePipelineDefaults(enablePipelineDefaults),
 		config.WithEnableApacheHttpdInstrumentation(enableApacheHttpdInstrumentation),
 		config.WithEnableDotNetInstrumentation(enableDotNetInstrumentation),
 		config.WithEnableGoInstrumentation(enableGoInstrumentation),
@@ -298,8 +363,11 @@ func main() {
 		config.WithEnablePythonInstrumentation(enablePythonInstrumentation),
 		config.WithEnableNodeJSInstrumentation(enableNodeJSInstrumentation),
 		config.WithEnableJavaInstrumentation(enableJavaInstrumentation),
+		config.WithCollectorConfigProviders(collectorConfigProviders),
 		config.WithTargetAllocatorImage(targetAllocatorImage),
 		config.WithOperatorOpAMPBridgeImage(operatorOpAMPBridgeImage),
+		config.WithTelemetrygenImage(telemetrygenImage),
+		config.WithExporterCheckImage(exporterCheckImage),
 		config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
 		config.WithAutoInstrumentationNodeJSImage(autoInstrumentationNodeJS),
 		config.WithAutoInstrumentationPythonImage(autoInstrumentationPython),
@@ -310,6 +378,10 @@ func main() {
 		config.WithAutoDetect(ad),
 		config.WithLabelFilters(labelsFilter),
 		config.WithAnnotationFilters(annotationsFilter),
+		config.WithUpgradeWindow(window),
+		config.WithUpgradeRollbackTimeout(upgradeRollbackTimeout),
+		config.WithReconcileFairness(reconcileFairness),
+		config.WithCollectorNameTemplate(nameTemplate),
 	)
 	err = cfg.AutoDetect()
 	if err != nil {
@@ -353,17 +425,55 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = controllers.NewReconciler(controllers.Params{
+	collectorReconciler := controllers.NewReconciler(controllers.Params{
 		Client:   mgr.GetClient(),
 		Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
 		Scheme:   mgr.GetScheme(),
 		Config:   cfg,
 		Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
-	}).SetupWithManager(mgr); err != nil {
+	})
+	if err = collectorReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollector")
 		os.Exit(1)
 	}
 
+	if debugAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("GET /support-bundle/{namespace}/{name}", collectorReconciler.SupportBundleHandler(mgr.GetAPIReader()))
+		mux.Handle("GET /effective-config/{namespace}/{name}", collectorReconciler.EffectiveConfigHandler())
+		// the requests carry the bearer tokens of the users, the debug server is only served over TLS
+		certWatcher, certErr := certwatcher.New(filepath.Join(debugCertDir, "tls.crt"), filepath.Join(debugCertDir, "tls.key"))
+		if certErr != nil {
+			setupLog.Error(certErr, "unable to load the certificate of the debug server")
+			os.Exit(1)
+		}
+		if err = mgr.Add(certWatcher); err != nil {
+			setupLog.Error(err, "unable to add the certificate watcher of the debug server")
+			os.Exit(1)
+		}
+		debugTLSConfig := &tls.Config{GetCertificate: certWatcher.GetCertificate}
+		for _, opt := range optionsTlSOptsFuncs {
+			opt(debugTLSConfig)
+		}
+		debugListener, listenErr := tls.Listen("tcp", debugAddr, debugTLSConfig)
+		if listenErr != nil {
+			setupLog.Error(listenErr, "unable to listen on the debug address", "address", debugAddr)
+			os.Exit(1)
+		}
+		if err = mgr.Add(&manager.Server{
+			Name: "debug",
+			Server: &http.Server{
+				Addr:              debugAddr,
+				Handler:           mux,
+				ReadHeaderTimeout: 10 * time.Second,
+			},
+			Listener: debugListener,
+		}); err != nil {
+			setupLog.Error(err, "unable to add the debug server")
+			os.Exit(1)
+		}
+	}
+
 	if err = controllers.NewOpAMPBridgeReconciler(controllers.OpAMPBridgeReconcilerParams{
 		Client:   mgr.GetClient(),
 		Log:      ctrl.Log.WithName("controllers").WithName("OpAMPBridge"),
@@ -375,6 +485,41 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controllers.NewInstrumentationReconciler(controllers.InstrumentationReconcilerParams{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Instrumentation"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Instrumentation")
+		os.Exit(1)
+	}
+
+	if featuregate.EnableCollectorFleet.IsEnabled() {
+		if err = controllers.NewOpenTelemetryCollectorFleetReconciler(controllers.OpenTelemetryCollectorFleetReconcilerParams{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollectorFleet"),
+			Scheme:   mgr.GetScheme(),
+			Config:   cfg,
+			Recorder: mgr.GetEventRecorderFor("opentelemetry-collector-fleet"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollectorFleet")
+			os.Exit(1)
+		}
+	}
+
+	if featuregate.EnableCollectorTest.IsEnabled() {
+		if err = controllers.NewOpenTelemetryCollectorTestReconciler(controllers.OpenTelemetryCollectorTestReconcilerParams{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollectorTest"),
+			Scheme:   mgr.GetScheme(),
+			Config:   cfg,
+			Recorder: mgr.GetEventRecorderFor("opentelemetry-collector-test"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollectorTest")
+			os.Exit(1)
+		}
+	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		var crdMetrics *otelv1beta1.Metrics
 
@@ -399,6 +544,7 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Instrumentation")
 			os.Exit(1)
 		}
+		mgr.GetWebhookServer().Register("GET /effective-config/{namespace}/{name}", collectorReconciler.EffectiveConfigHandler())
 		decoder := admission.NewDecoder(mgr.GetScheme())
 		mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
 			Handler: podmutation.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), decoder, mgr.GetClient(),
@@ -437,12 +583,14 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config, v v
 	// adds the upgrade mechanism to be executed once the manager is ready
 	err := mgr.Add(manager.RunnableFunc(func(c context.Context) error {
 		up := &collectorupgrade.VersionUpgrade{
-			Log:      ctrl.Log.WithName("collector-upgrade"),
-			Version:  v,
-			Client:   mgr.GetClient(),
-			Recorder: record.NewFakeRecorder(collectorupgrade.RecordBufferSize),
+			Log:             ctrl.Log.WithName("collector-upgrade"),
+			Version:         v,
+			Client:          mgr.GetClient(),
+			Recorder:        record.NewFakeRecorder(collectorupgrade.RecordBufferSize),
+			Window:          cfg.UpgradeWindow(),
+			RollbackTimeout: cfg.UpgradeRollbackTimeout(),
 		}
-		return up.ManagedInstances(c)
+		return up.Run(c)
 	}))
 	if err != nil {
 		return fmt.Errorf("failed to upgrade OpenTelemetryCollector instances: %w", err)
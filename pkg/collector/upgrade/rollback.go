@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// The rollback state is kept out of the objects owned by the instances by the default annotations filter of the
+// operator config. The spec the instance is rolled back to is too large for an annotation, it's kept in a ConfigMap
+// owned by the instance.
+const (
+	// UpgradeStartedAnnotation holds the time an upgrade was applied to the instance, while the operator waits for
+	// the instance to become ready.
+	UpgradeStartedAnnotation = "operator.opentelemetry.io/upgrade-started"
+	// RollbackVersionAnnotation holds the version the instance is rolled back to if the upgrade fails.
+	RollbackVersionAnnotation = "operator.opentelemetry.io/upgrade-rollback-version"
+
+	// ComponentUpgradeRollback is the component label of the ConfigMaps holding the spec the instances are rolled
+	// back to. It differs from the one of the collector ConfigMaps, which keeps them out of the previous config
+	// versions pruned by the reconciliation.
+	ComponentUpgradeRollback = "opentelemetry-upgrade-rollback"
+	// RollbackSpecKey is the key of the rollback ConfigMaps holding the spec the instance is rolled back to, in JSON.
+	RollbackSpecKey = "spec.json"
+)
+
+// trackUpgrade records the state of the instance prior to the upgrade, so that the upgrade can be rolled back.
+func trackUpgrade(ctx context.Context, c client.Client, upgraded *v1beta1.OpenTelemetryCollector, original v1beta1.OpenTelemetryCollector, now time.Time) error {
+	// keep the state prior to the first upgrade if the previous one is still in progress
+	if _, ok := UpgradeStarted(original); ok {
+		return nil
+	}
+
+	spec := original.Spec.DeepCopy()
+	if spec.Image == "" {
+		// the default image changes with the operator, pin the one the instance was running
+		spec.Image = original.Status.Image
+	}
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to record the state prior to the upgrade: %w", err)
+	}
+	if err := saveRollbackSpec(ctx, c, &original, raw); err != nil {
+		return fmt.Errorf("failed to record the state prior to the upgrade: %w", err)
+	}
+
+	annotations := make(map[string]string, len(upgraded.Annotations)+2)
+	for k, v := range upgraded.Annotations {
+		annotations[k] = v
+	}
+	annotations[UpgradeStartedAnnotation] = now.UTC().Format(time.RFC3339)
+	annotations[RollbackVersionAnnotation] = original.Status.Version
+	upgraded.Annotations = annotations
+	return nil
+}
+
+// saveRollbackSpec writes the spec to the rollback ConfigMap of the instance, creating it when it doesn't exist yet.
+func saveRollbackSpec(ctx context.Context, c client.Client, otelcol *v1beta1.OpenTelemetryCollector, raw []byte) error {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: otelcol.Namespace, Name: naming.UpgradeRollbackConfigMap(otelcol.Name)}
+	err := c.Get(ctx, key, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "opentelemetry-operator",
+					"app.kubernetes.io/instance":   naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name),
+					"app.kubernetes.io/part-of":    "opentelemetry",
+					"app.kubernetes.io/component":  ComponentUpgradeRollback,
+					"app.kubernetes.io/name":       key.Name,
+				},
+			},
+			Data: map[string]string{RollbackSpecKey: string(raw)},
+		}
+		// the ConfigMap is deleted with the instance
+		if err := controllerutil.SetOwnerReference(otelcol, cm, c.Scheme()); err != nil {
+			return err
+		}
+		return c.Create(ctx, cm)
+	}
+	cm.Data = map[string]string{RollbackSpecKey: string(raw)}
+	return c.Update(ctx, cm)
+}
+
+// UpgradeStarted returns when the last upgrade was applied to the given instance, if the operator is still waiting
+// for the instance to become ready.
+func UpgradeStarted(otelcol v1beta1.OpenTelemetryCollector) (time.Time, bool) {
+	value, ok := otelcol.Annotations[UpgradeStartedAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	started, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return started, true
+}
+
+// CompleteUpgrade removes the state recorded to roll back the last upgrade of the given instance.
+func CompleteUpgrade(ctx context.Context, c client.Client, otelcol *v1beta1.OpenTelemetryCollector) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.UpgradeRollbackConfigMap(otelcol.Name),
+			Namespace: otelcol.Namespace,
+		},
+	}
+	if err := c.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the state prior to the upgrade: %w", err)
+	}
+	if otelcol.Annotations == nil {
+		return nil
+	}
+	annotations := make(map[string]string, len(otelcol.Annotations))
+	for k, v := range otelcol.Annotations {
+		if k == UpgradeStartedAnnotation || k == RollbackVersionAnnotation {
+			continue
+		}
+		annotations[k] = v
+	}
+	otelcol.Annotations = annotations
+	return nil
+}
+
+// Rollback restores the spec and the version the given instance had prior to its last upgrade, and returns that
+// version. The instance is opted out of the managed upgrades, so that the failed upgrade isn't applied again.
+func Rollback(ctx context.Context, c client.Client, otelcol *v1beta1.OpenTelemetryCollector) (string, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: otelcol.Namespace, Name: naming.UpgradeRollbackConfigMap(otelcol.Name)}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("the state prior to the upgrade was not recorded")
+		}
+		return "", fmt.Errorf("failed to get the state prior to the upgrade: %w", err)
+	}
+	raw, ok := cm.Data[RollbackSpecKey]
+	if !ok {
+		return "", fmt.Errorf("the state prior to the upgrade was not recorded")
+	}
+	spec := v1beta1.OpenTelemetryCollectorSpec{}
+	if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+		return "", fmt.Errorf("failed to read the state prior to the upgrade: %w", err)
+	}
+	version := otelcol.Annotations[RollbackVersionAnnotation]
+
+	if err := CompleteUpgrade(ctx, c, otelcol); err != nil {
+		return "", err
+	}
+	if otelcol.Annotations == nil {
+		otelcol.Annotations = map[string]string{}
+	}
+	otelcol.Annotations[SkipUpgradeAnnotation] = "true"
+	otelcol.Spec = spec
+	otelcol.Status.Version = version
+	return version, nil
+}
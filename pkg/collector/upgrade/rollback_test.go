@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
+)
+
+func TestUpgradeRecordsRollbackState(t *testing.T) {
+	// the rollback state is owned by the instance, which has to exist
+	nsn := types.NamespacedName{Name: "rollback-instance", Namespace: "default"}
+	existing := makeOtelcol(nsn, v1alpha1.ManagementStateManaged)
+	require.NoError(t, k8sClient.Create(context.Background(), &existing))
+	t.Cleanup(func() {
+		require.NoError(t, k8sClient.Delete(context.Background(), &existing))
+	})
+	existing.Status.Version = "0.8.0"
+	existing.Status.Image = "collector:0.8.0"
+	original := convertTov1beta1(t, existing)
+
+	currentV := version.Get()
+	currentV.OpenTelemetryCollector = upgrade.Latest.String()
+	up := &upgrade.VersionUpgrade{
+		Log:             logger,
+		Version:         currentV,
+		Client:          k8sClient,
+		Recorder:        record.NewFakeRecorder(upgrade.RecordBufferSize),
+		RollbackTimeout: time.Minute,
+	}
+
+	// test
+	upgraded, err := up.ManagedInstance(context.Background(), original)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, upgrade.Latest.String(), upgraded.Status.Version)
+	started, ok := upgrade.UpgradeStarted(upgraded)
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now(), started, time.Minute)
+	assert.Equal(t, "0.8.0", upgraded.Annotations[upgrade.RollbackVersionAnnotation])
+	assert.NotContains(t, original.Annotations, upgrade.UpgradeStartedAnnotation)
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: naming.UpgradeRollbackConfigMap(nsn.Name)}, cm))
+	assert.Contains(t, cm.Data[upgrade.RollbackSpecKey], "collector:0.8.0")
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, existing.UID, cm.OwnerReferences[0].UID)
+
+	// rolling back restores the previous state, with the image it was running
+	version, err := upgrade.Rollback(context.Background(), k8sClient, &upgraded)
+	require.NoError(t, err)
+	assert.Equal(t, "0.8.0", version)
+	assert.Equal(t, "0.8.0", upgraded.Status.Version)
+	assert.Equal(t, "collector:0.8.0", upgraded.Spec.Image)
+	assert.Equal(t, "true", upgraded.Annotations[upgrade.SkipUpgradeAnnotation])
+	_, ok = upgrade.UpgradeStarted(upgraded)
+	assert.False(t, ok)
+	assert.NotContains(t, upgraded.Annotations, upgrade.RollbackVersionAnnotation)
+	err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: nsn.Namespace, Name: naming.UpgradeRollbackConfigMap(nsn.Name)}, cm)
+	assert.True(t, apierrors.IsNotFound(err), "the rollback state should be deleted")
+}
+
+func TestUpgradeWithoutRollbackTimeout(t *testing.T) {
+	nsn := types.NamespacedName{Name: "my-instance", Namespace: "default"}
+	existing := makeOtelcol(nsn, v1alpha1.ManagementStateManaged)
+	existing.Status.Version = "0.8.0"
+
+	currentV := version.Get()
+	currentV.OpenTelemetryCollector = upgrade.Latest.String()
+	up := &upgrade.VersionUpgrade{
+		Log:      logger,
+		Version:  currentV,
+		Client:   k8sClient,
+		Recorder: record.NewFakeRecorder(upgrade.RecordBufferSize),
+	}
+
+	upgraded, err := up.ManagedInstance(context.Background(), convertTov1beta1(t, existing))
+	require.NoError(t, err)
+
+	_, ok := upgrade.UpgradeStarted(upgraded)
+	assert.False(t, ok)
+	_, err = upgrade.Rollback(context.Background(), k8sClient, &upgraded)
+	assert.Error(t, err)
+}
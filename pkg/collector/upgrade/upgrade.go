@@ -19,6 +19,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	semver "github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
@@ -27,7 +29,10 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/auditlog"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 type VersionUpgrade struct {
@@ -35,10 +40,45 @@ type VersionUpgrade struct {
 	Recorder record.EventRecorder
 	Version  version.Version
 	Log      logr.Logger
+	// Window restricts the upgrades to a maintenance window. The zero value allows upgrades at any time.
+	Window config.UpgradeWindow
+	// RollbackTimeout is the time an upgraded instance has to become ready before the upgrade is rolled back.
+	// The upgrades are not recorded for rollbacks when zero.
+	RollbackTimeout time.Duration
 }
 
 const RecordBufferSize int = 10
 
+// SkipUpgradeAnnotation opts an instance out of the managed upgrades when set to "true".
+const SkipUpgradeAnnotation = "operator.opentelemetry.io/skip-upgrade"
+
+// Run upgrades the managed instances. When the upgrades are restricted to a maintenance window, the instances are
+// upgraded again at every opening of the window, until the context is done.
+func (u VersionUpgrade) Run(ctx context.Context) error {
+	if err := u.ManagedInstances(ctx); err != nil || !u.Window.IsScheduled() {
+		return err
+	}
+	for {
+		next := u.Window.NextOpening(time.Now())
+		if next.IsZero() {
+			u.Log.Info("the upgrade window does not open anymore, stopping the managed upgrades")
+			return nil
+		}
+		u.Log.V(1).Info("waiting for the upgrade window to open", "at", next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		if err := u.ManagedInstances(ctx); err != nil {
+			u.Log.Error(err, "failed to upgrade managed instances")
+		}
+	}
+}
+
 // ManagedInstances finds all the otelcol instances for the current operator and upgrades them, if necessary.
 func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 	u.Log.Info("looking for managed instances to upgrade")
@@ -66,6 +106,11 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 			itemLogger.Info("skipping instance upgrade due to UpgradeStrategy")
 			continue
 		}
+
+		if skipUpgrade(original) {
+			itemLogger.Info("skipping instance upgrade due to annotation", "annotation", SkipUpgradeAnnotation)
+			continue
+		}
 		upgraded, err := u.ManagedInstance(ctx, original)
 		if err != nil {
 			const msg = "automated update not possible. Configuration must be corrected manually and CR instance must be re-created."
@@ -91,6 +136,12 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 			}
 
 			itemLogger.Info("instance upgraded", "version", upgraded.Status.Version)
+			if featuregate.EnableAuditLog.IsEnabled() {
+				msg := fmt.Sprintf("upgraded from version %s to version %s", original.Status.Version, upgraded.Status.Version)
+				if err := auditlog.RecordMutation(ctx, u.Client, &original, &upgraded, auditlog.SourceUpgrade, msg); err != nil {
+					itemLogger.Error(err, "failed to record the upgrade of the instance in its audit log")
+				}
+			}
 		}
 	}
 
@@ -102,12 +153,35 @@ func (u VersionUpgrade) ManagedInstances(ctx context.Context) error {
 }
 
 // ManagedInstance performs the necessary changes to bring the given otelcol instance to the current version.
-func (u VersionUpgrade) ManagedInstance(_ context.Context, otelcol v1beta1.OpenTelemetryCollector) (v1beta1.OpenTelemetryCollector, error) {
+func (u VersionUpgrade) ManagedInstance(ctx context.Context, otelcol v1beta1.OpenTelemetryCollector) (v1beta1.OpenTelemetryCollector, error) {
 	// this is likely a new instance, assume it's already up to date
 	if otelcol.Status.Version == "" {
 		return otelcol, nil
 	}
 
+	if skipUpgrade(otelcol) {
+		u.Log.V(4).Info("skipping upgrade for OpenTelemetry Collector instance due to annotation", "name", otelcol.Name, "namespace", otelcol.Namespace, "annotation", SkipUpgradeAnnotation)
+		return otelcol, nil
+	}
+
+	if !u.Window.IsOpen(time.Now()) {
+		u.Log.V(4).Info("skipping upgrade for OpenTelemetry Collector instance outside of the upgrade window", "name", otelcol.Name, "namespace", otelcol.Namespace, "next", u.Window.NextOpening(time.Now()))
+		return otelcol, nil
+	}
+
+	upgraded, err := u.upgradeInstance(otelcol)
+	if err != nil {
+		return upgraded, err
+	}
+	if u.RollbackTimeout > 0 && upgraded.Status.Version != otelcol.Status.Version {
+		if err := trackUpgrade(ctx, u.Client, &upgraded, otelcol, time.Now()); err != nil {
+			return otelcol, err
+		}
+	}
+	return upgraded, nil
+}
+
+func (u VersionUpgrade) upgradeInstance(otelcol v1beta1.OpenTelemetryCollector) (v1beta1.OpenTelemetryCollector, error) {
 	instanceV, err := semver.NewVersion(otelcol.Status.Version)
 	if err != nil {
 		u.Log.Error(err, "failed to parse version for OpenTelemetry Collector instance", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
@@ -147,9 +221,12 @@ func (u VersionUpgrade) ManagedInstance(_ context.Context, otelcol v1beta1.OpenT
 				}
 				upgradedV1alpha1.Status.Version = available.String()
 
+				// the conditions are not part of the v1alpha1 status
+				conditions := otelcol.Status.Conditions
 				if err := upgradedV1alpha1.ConvertTo(&otelcol); err != nil {
 					return otelcol, err
 				}
+				otelcol.Status.Conditions = conditions
 				u.Log.V(1).Info("step upgrade", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", available.String())
 			} else {
 
@@ -171,3 +248,7 @@ func (u VersionUpgrade) ManagedInstance(_ context.Context, otelcol v1beta1.OpenT
 	u.Log.V(1).Info("final version", "name", otelcol.Name, "namespace", otelcol.Namespace, "version", otelcol.Status.Version)
 	return otelcol, nil
 }
+
+func skipUpgrade(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return strings.EqualFold(otelcol.Annotations[SkipUpgradeAnnotation], "true")
+}
@@ -17,6 +17,7 @@ package upgrade_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 )
@@ -161,6 +163,53 @@ func TestVersionsShouldNotBeChanged(t *testing.T) {
 	}
 }
 
+func TestUpgradeSkipped(t *testing.T) {
+	const beginV = "0.8.0"
+	nsn := types.NamespacedName{Name: "my-instance", Namespace: "default"}
+
+	// a window which only opened in the past
+	closed, err := config.NewUpgradeWindow("0 2 1 1 * 2000", time.Hour)
+	require.NoError(t, err)
+	open, err := config.NewUpgradeWindow("* * * * *", time.Hour)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		desc        string
+		annotations map[string]string
+		window      config.UpgradeWindow
+		expectedV   string
+	}{
+		{"annotation", map[string]string{upgrade.SkipUpgradeAnnotation: "true"}, config.UpgradeWindow{}, beginV},
+		{"annotation-false", map[string]string{upgrade.SkipUpgradeAnnotation: "false"}, config.UpgradeWindow{}, upgrade.Latest.String()},
+		{"closed-window", nil, closed, beginV},
+		{"open-window", nil, open, upgrade.Latest.String()},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			existing := makeOtelcol(nsn, v1alpha1.ManagementStateManaged)
+			existing.Annotations = tt.annotations
+			existing.Status.Version = beginV
+
+			currentV := version.Get()
+			currentV.OpenTelemetryCollector = upgrade.Latest.String()
+			up := &upgrade.VersionUpgrade{
+				Log:      logger,
+				Version:  currentV,
+				Client:   k8sClient,
+				Recorder: record.NewFakeRecorder(upgrade.RecordBufferSize),
+				Window:   tt.window,
+			}
+
+			// test
+			res, err := up.ManagedInstance(context.Background(), convertTov1beta1(t, existing))
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedV, res.Status.Version)
+		})
+	}
+}
+
 const collectorCfg = `---
 receivers:
   otlp:
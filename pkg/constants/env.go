@@ -31,6 +31,19 @@ const (
 	AnnotationDefaultAutoInstrumentationApacheHttpd = InstrumentationPrefix + "default-auto-instrumentation-apache-httpd-image"
 	AnnotationDefaultAutoInstrumentationNginx       = InstrumentationPrefix + "default-auto-instrumentation-nginx-image"
 
+	AnnotationDefaultExporterEndpoint          = InstrumentationPrefix + "default-exporter-endpoint"
+	AnnotationDefaultExporterEndpointCollector = InstrumentationPrefix + "default-exporter-endpoint-collector"
+
+	AnnotationInjectJava        = InstrumentationPrefix + "inject-java"
+	AnnotationInjectNodeJS      = InstrumentationPrefix + "inject-nodejs"
+	AnnotationInjectPython      = InstrumentationPrefix + "inject-python"
+	AnnotationInjectDotNet      = InstrumentationPrefix + "inject-dotnet"
+	AnnotationInjectGo          = InstrumentationPrefix + "inject-go"
+	AnnotationInjectApacheHttpd = InstrumentationPrefix + "inject-apache-httpd"
+	AnnotationInjectNginx       = InstrumentationPrefix + "inject-nginx"
+
+	AnnotationCollectorResourceName = "operator.opentelemetry.io/collector-resource-name"
+
 	EnvPodName  = "OTEL_RESOURCE_ATTRIBUTES_POD_NAME"
 	EnvPodUID   = "OTEL_RESOURCE_ATTRIBUTES_POD_UID"
 	EnvPodIP    = "OTEL_POD_IP"
@@ -40,6 +40,46 @@ var (
 		featuregate.WithRegisterDescription("enables feature to set GOMEMLIMIT and GOMAXPROCS automatically"),
 		featuregate.WithRegisterFromVersion("v0.100.0"),
 	)
+	// EnableCollectorFleet is the feature gate that enables the OpenTelemetryCollectorFleet controller, used in hub
+	// clusters to roll out collectors to the managed clusters.
+	EnableCollectorFleet = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.fleet",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the OpenTelemetryCollectorFleet controller to roll out collectors to managed clusters"),
+		featuregate.WithRegisterFromVersion("v0.104.0"),
+	)
+	// EnableCollectorTest is the feature gate that enables the OpenTelemetryCollectorTest controller, which runs
+	// candidate collector configurations against synthetic data.
+	EnableCollectorTest = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.test",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the OpenTelemetryCollectorTest controller to validate collector configurations in-cluster"),
+		featuregate.WithRegisterFromVersion("v0.104.0"),
+	)
+	// EnableConfigVars is the feature gate that enables the resolution of the ${var:NAME} references of the collector
+	// configs from the OpenTelemetryConfigVars.
+	EnableConfigVars = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.configvars",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables the resolution of the collector config variables from the OpenTelemetryConfigVars"),
+		featuregate.WithRegisterFromVersion("v0.104.0"),
+	)
+	// EnableConfigAnchors is the feature gate that renders the repeated sections of the collector configs as YAML
+	// anchors and aliases, to keep the ConfigMaps of large configs small.
+	EnableConfigAnchors = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.configanchors",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("renders the repeated sections of the collector configs as YAML anchors and aliases"),
+		featuregate.WithRegisterFromVersion("v0.104.0"),
+	)
+	// EnableAuditLog is the feature gate that records the mutations of the collectors made by the operator, such as the
+	// upgrades and the defaulting, in an audit log ConfigMap summarized in their status.
+	EnableAuditLog = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.auditlog",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("records the mutations of the collectors made by the operator in an audit log"),
+		featuregate.WithRegisterFromVersion("v0.104.0"),
+	)
 )
 
 // Flags creates a new FlagSet that represents the available featuregate flags using the supplied featuregate registry.
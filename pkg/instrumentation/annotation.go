@@ -18,29 +18,31 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
 
 const (
 	// annotationInjectJava indicates whether java auto-instrumentation should be injected or not.
 	// Possible values are "true", "false" or "<Instrumentation>" name.
 	annotationInjectContainerName             = "instrumentation.opentelemetry.io/container-names"
-	annotationInjectJava                      = "instrumentation.opentelemetry.io/inject-java"
+	annotationInjectJava                      = constants.AnnotationInjectJava
 	annotationInjectJavaContainersName        = "instrumentation.opentelemetry.io/java-container-names"
-	annotationInjectNodeJS                    = "instrumentation.opentelemetry.io/inject-nodejs"
+	annotationInjectNodeJS                    = constants.AnnotationInjectNodeJS
 	annotationInjectNodeJSContainersName      = "instrumentation.opentelemetry.io/nodejs-container-names"
-	annotationInjectPython                    = "instrumentation.opentelemetry.io/inject-python"
+	annotationInjectPython                    = constants.AnnotationInjectPython
 	annotationInjectPythonContainersName      = "instrumentation.opentelemetry.io/python-container-names"
-	annotationInjectDotNet                    = "instrumentation.opentelemetry.io/inject-dotnet"
+	annotationInjectDotNet                    = constants.AnnotationInjectDotNet
 	annotationDotNetRuntime                   = "instrumentation.opentelemetry.io/otel-dotnet-auto-runtime"
 	annotationInjectDotnetContainersName      = "instrumentation.opentelemetry.io/dotnet-container-names"
-	annotationInjectGo                        = "instrumentation.opentelemetry.io/inject-go"
+	annotationInjectGo                        = constants.AnnotationInjectGo
 	annotationInjectGoContainersName          = "instrumentation.opentelemetry.io/go-container-names"
 	annotationGoExecPath                      = "instrumentation.opentelemetry.io/otel-go-auto-target-exe"
 	annotationInjectSdk                       = "instrumentation.opentelemetry.io/inject-sdk"
 	annotationInjectSdkContainersName         = "instrumentation.opentelemetry.io/sdk-container-names"
-	annotationInjectApacheHttpd               = "instrumentation.opentelemetry.io/inject-apache-httpd"
+	annotationInjectApacheHttpd               = constants.AnnotationInjectApacheHttpd
 	annotationInjectApacheHttpdContainersName = "instrumentation.opentelemetry.io/apache-httpd-container-names"
-	annotationInjectNginx                     = "instrumentation.opentelemetry.io/inject-nginx"
+	annotationInjectNginx                     = constants.AnnotationInjectNginx
 	annotationInjectNginxContainersName       = "instrumentation.opentelemetry.io/inject-nginx-container-names"
 )
 
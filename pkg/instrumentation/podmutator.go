@@ -365,6 +365,12 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	// we should inject the instrumentation.
 	modifiedPod := pod
 	modifiedPod = pm.sdkInjector.inject(ctx, insts, ns, modifiedPod, pm.config)
+	if HasInjectedAgent(modifiedPod) {
+		if modifiedPod.Labels == nil {
+			modifiedPod.Labels = map[string]string{}
+		}
+		modifiedPod.Labels[InjectedPodLabel] = "true"
+	}
 
 	return modifiedPod, nil
 }
@@ -380,15 +386,8 @@ func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns cor
 		return pm.selectInstrumentationInstanceFromNamespace(ctx, ns)
 	}
 
-	var instNamespacedName types.NamespacedName
-	if instNamespace, instName, namespaced := strings.Cut(instValue, "/"); namespaced {
-		instNamespacedName = types.NamespacedName{Name: instName, Namespace: instNamespace}
-	} else {
-		instNamespacedName = types.NamespacedName{Name: instValue, Namespace: ns.Name}
-	}
-
 	otelInst := &v1alpha1.Instrumentation{}
-	err := pm.Client.Get(ctx, instNamespacedName, otelInst)
+	err := pm.Client.Get(ctx, instrumentationNamespacedName(instValue, ns.Name), otelInst)
 	if err != nil {
 		return nil, err
 	}
@@ -396,6 +395,15 @@ func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns cor
 	return otelInst, nil
 }
 
+// instrumentationNamespacedName returns the Instrumentation referenced by the value of an inject annotation, either
+// as <name>, in the namespace of the pod, or as <namespace>/<name>.
+func instrumentationNamespacedName(instValue, namespace string) types.NamespacedName {
+	if instNamespace, instName, namespaced := strings.Cut(instValue, "/"); namespaced {
+		return types.NamespacedName{Name: instName, Namespace: instNamespace}
+	}
+	return types.NamespacedName{Name: instValue, Namespace: namespace}
+}
+
 func (pm *instPodMutator) selectInstrumentationInstanceFromNamespace(ctx context.Context, ns corev1.Namespace) (*v1alpha1.Instrumentation, error) {
 	var otelInsts v1alpha1.InstrumentationList
 	if err := pm.Client.List(ctx, &otelInsts, client.InNamespace(ns.Name)); err != nil {
@@ -122,6 +122,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectJava: "true",
 					},
@@ -326,6 +327,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectJava:          "true",
 						annotationInjectContainerName: "app1,app2",
@@ -700,6 +702,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectNodeJS: "true",
 					},
@@ -888,6 +891,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectNodeJS:        "true",
 						annotationInjectContainerName: "app1,app2",
@@ -1242,6 +1246,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectPython: "true",
 					},
@@ -1446,6 +1451,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectPython:        "true",
 						annotationInjectContainerName: "app1,app2",
@@ -1821,6 +1827,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectDotNet:  "true",
 						annotationDotNetRuntime: dotNetRuntimeLinuxMusl,
@@ -2021,7 +2028,9 @@ func TestMutatePod(t *testing.T) {
 				},
 			},
 			expected: corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{},
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
+				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
 						{
@@ -2222,6 +2231,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectDotNet:        "true",
 						annotationInjectContainerName: "app1,app2",
@@ -2606,6 +2616,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectGo:   "true",
 						annotationGoExecPath: "/app",
@@ -2835,6 +2846,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectApacheHttpd: "true",
 					},
@@ -3085,7 +3097,8 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "my-nginx-6c44bcbdd",
+					Name:   "my-nginx-6c44bcbdd",
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectNginx: "true",
 					},
@@ -3518,6 +3531,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectDotNet:               "true",
 						annotationInjectJava:                 "true",
@@ -4302,6 +4316,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectDotNet:               "true",
 						annotationInjectJava:                 "true",
@@ -5359,6 +5374,7 @@ func TestMutatePod(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{InjectedPodLabel: "true"},
 					Annotations: map[string]string{
 						annotationInjectDotNet: "true",
 					},
@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// injectedLanguages are the auto-instrumentations reported, with their inject annotation and the container
+// running their agent. The SDK injection isn't reported, as it doesn't run an agent.
+var injectedLanguages = []struct {
+	language   string
+	annotation string
+	container  string
+}{
+	{language: "java", annotation: annotationInjectJava, container: javaInitContainerName},
+	{language: "nodejs", annotation: annotationInjectNodeJS, container: nodejsInitContainerName},
+	{language: "python", annotation: annotationInjectPython, container: pythonInitContainerName},
+	{language: "dotnet", annotation: annotationInjectDotNet, container: dotnetInitContainerName},
+	{language: "go", annotation: annotationInjectGo, container: sideCarName},
+	{language: "apache-httpd", annotation: annotationInjectApacheHttpd, container: apacheAgentInitContainerName},
+	{language: "nginx", annotation: annotationInjectNginx, container: nginxAgentInitContainerName},
+}
+
+const (
+	// InjectedPodLabel is the label set on the pods injected with an auto-instrumentation agent. The operator only
+	// caches the pods with the label, to report them in the status of their Instrumentation.
+	InjectedPodLabel = "instrumentation.opentelemetry.io/injected"
+	// InjectedPodIndex is the field index of the pods running an auto-instrumentation agent. The pods are indexed
+	// under injectedPodIndexValue, and under the Instrumentations their annotations reference with a namespace.
+	InjectedPodIndex      = "instrumentation.opentelemetry.io/injected"
+	injectedPodIndexValue = "injected"
+)
+
+// InjectedAgent is an auto-instrumentation agent running in a pod, with the Instrumentation it was injected from.
+type InjectedAgent struct {
+	Instrumentation types.NamespacedName
+	Language        string
+	Image           string
+}
+
+// InjectedAgents returns the auto-instrumentation agents running in the pod. Their Instrumentation is resolved from
+// the inject annotations of the pod and its namespace, the same way as when the pod was injected.
+func InjectedAgents(ctx context.Context, c client.Client, pod corev1.Pod) ([]InjectedAgent, error) {
+	var ns *corev1.Namespace
+	var agents []InjectedAgent
+	for _, lang := range injectedLanguages {
+		image, ok := agentImage(pod, lang.container)
+		if !ok {
+			continue
+		}
+		if ns == nil {
+			ns = &corev1.Namespace{}
+			if err := c.Get(ctx, types.NamespacedName{Name: pod.Namespace}, ns); err != nil {
+				return nil, err
+			}
+		}
+		inst, ok, err := injectedInstrumentation(ctx, c, *ns, pod, lang.annotation)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			agents = append(agents, InjectedAgent{Instrumentation: inst, Language: lang.language, Image: image})
+		}
+	}
+	return agents, nil
+}
+
+// injectedInstrumentation returns the Instrumentation selected by the inject annotation, if it can be determined.
+func injectedInstrumentation(ctx context.Context, c client.Client, ns corev1.Namespace, pod corev1.Pod, annotation string) (types.NamespacedName, bool, error) {
+	instValue := annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotation)
+	if len(instValue) == 0 || strings.EqualFold(instValue, "false") {
+		return types.NamespacedName{}, false, nil
+	}
+	if !strings.EqualFold(instValue, "true") {
+		return instrumentationNamespacedName(instValue, ns.Name), true, nil
+	}
+	var otelInsts v1alpha1.InstrumentationList
+	if err := c.List(ctx, &otelInsts, client.InNamespace(ns.Name)); err != nil {
+		return types.NamespacedName{}, false, err
+	}
+	if len(otelInsts.Items) != 1 {
+		return types.NamespacedName{}, false, nil
+	}
+	return client.ObjectKeyFromObject(&otelInsts.Items[0]), true, nil
+}
+
+// InjectedPodIndexValues returns the values the pod is indexed with under InjectedPodIndex.
+func InjectedPodIndexValues(obj client.Object) []string {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || !HasInjectedAgent(*pod) {
+		return nil
+	}
+	values := []string{injectedPodIndexValue}
+	for _, lang := range injectedLanguages {
+		if value := pod.Annotations[lang.annotation]; strings.Contains(value, "/") {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// InjectedPods returns the pods which may be injected with the Instrumentation: the injected pods of its namespace
+// and of the namespaces referencing it, and the pods of the other namespaces referencing it. The pods are listed with
+// the InjectedPodIndex, from a reader which only caches the pods with the InjectedPodLabel.
+func InjectedPods(ctx context.Context, c client.Reader, instrumentation types.NamespacedName) ([]corev1.Pod, error) {
+	reference := instrumentation.String()
+	namespaces := map[string]struct{}{instrumentation.Namespace: {}}
+	var nsList corev1.NamespaceList
+	if err := c.List(ctx, &nsList); err != nil {
+		return nil, err
+	}
+	for _, ns := range nsList.Items {
+		for _, lang := range injectedLanguages {
+			if ns.Annotations[lang.annotation] == reference {
+				namespaces[ns.Name] = struct{}{}
+			}
+		}
+	}
+
+	pods := map[types.NamespacedName]corev1.Pod{}
+	for namespace := range namespaces {
+		var podList corev1.PodList
+		if err := c.List(ctx, &podList, client.InNamespace(namespace), client.MatchingFields{InjectedPodIndex: injectedPodIndexValue}); err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			pods[client.ObjectKeyFromObject(&pod)] = pod
+		}
+	}
+	var podList corev1.PodList
+	if err := c.List(ctx, &podList, client.MatchingFields{InjectedPodIndex: reference}); err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		pods[client.ObjectKeyFromObject(&pod)] = pod
+	}
+
+	result := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+// HasInjectedAgent returns true when an auto-instrumentation agent runs in the pod.
+func HasInjectedAgent(pod corev1.Pod) bool {
+	for _, lang := range injectedLanguages {
+		if _, ok := agentImage(pod, lang.container); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func agentImage(pod corev1.Pod, container string) (string, bool) {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == container {
+			return c.Image, true
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return c.Image, true
+		}
+	}
+	return "", false
+}
+
+// InjectionReport summarizes the workloads injected with the Instrumentation among the given pods, ordered so that
+// the report is stable. It returns nil when no pod is injected with the Instrumentation.
+func InjectionReport(ctx context.Context, c client.Client, instrumentation types.NamespacedName, pods []corev1.Pod) (*v1alpha1.InstrumentationInjection, error) {
+	namespaces := map[string]struct{}{}
+	workloads := map[v1alpha1.InjectedWorkload]int32{}
+	images := map[v1alpha1.InjectedAgent]int32{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed || !HasInjectedAgent(pod) {
+			continue
+		}
+		agents, err := InjectedAgents(ctx, c, pod)
+		if err != nil {
+			return nil, err
+		}
+		injected := false
+		for _, agent := range agents {
+			if agent.Instrumentation != instrumentation {
+				continue
+			}
+			injected = true
+			images[v1alpha1.InjectedAgent{Language: agent.Language, Image: agent.Image}]++
+		}
+		if !injected {
+			continue
+		}
+		kind, name := workload(pod)
+		namespaces[pod.Namespace] = struct{}{}
+		workloads[v1alpha1.InjectedWorkload{Namespace: pod.Namespace, Kind: kind, Name: name}]++
+	}
+	if len(workloads) == 0 {
+		return nil, nil
+	}
+
+	report := &v1alpha1.InstrumentationInjection{}
+	for namespace := range namespaces {
+		report.Namespaces = append(report.Namespaces, namespace)
+	}
+	sort.Strings(report.Namespaces)
+	for w, count := range workloads {
+		w.Pods = count
+		report.Workloads = append(report.Workloads, w)
+	}
+	sort.Slice(report.Workloads, func(i, j int) bool {
+		a, b := report.Workloads[i], report.Workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	for a, count := range images {
+		a.Pods = count
+		report.Agents = append(report.Agents, a)
+	}
+	sort.Slice(report.Agents, func(i, j int) bool {
+		a, b := report.Agents[i], report.Agents[j]
+		if a.Language != b.Language {
+			return a.Language < b.Language
+		}
+		return a.Image < b.Image
+	})
+	return report, nil
+}
+
+// workload returns the kind and name of the workload of the pod. The pods of a Deployment are reported with the
+// Deployment, rather than their ReplicaSet.
+func workload(pod corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if hash, ok := pod.Labels["pod-template-hash"]; ok && owner.Kind == "ReplicaSet" && strings.HasSuffix(owner.Name, "-"+hash) {
+		return "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+	}
+	return owner.Kind, owner.Name
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectionReport(t *testing.T) {
+	injectedPod := func(namespace, name string, annotations map[string]string, owner *metav1.OwnerReference, container, image string) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Annotations: annotations,
+				Labels:      map[string]string{"pod-template-hash": "5d8f"},
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: container, Image: image}},
+				Containers:     []corev1.Container{{Name: "app"}},
+			},
+		}
+		if owner != nil {
+			pod.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return pod
+	}
+	replicaSet := &metav1.OwnerReference{Kind: "ReplicaSet", Name: "app-5d8f", Controller: ptr.To(true)}
+	statefulSet := &metav1.OwnerReference{Kind: "StatefulSet", Name: "db", Controller: ptr.To(true)}
+
+	pods := []corev1.Pod{
+		injectedPod("apps", "app-5d8f-1", map[string]string{annotationInjectJava: "my-inst"}, replicaSet, javaInitContainerName, "java:1"),
+		injectedPod("apps", "app-5d8f-2", map[string]string{annotationInjectJava: "my-inst"}, replicaSet, javaInitContainerName, "java:2"),
+		injectedPod("other", "db-0", map[string]string{annotationInjectPython: "apps/my-inst"}, statefulSet, pythonInitContainerName, "python:1"),
+		injectedPod("other", "other-0", map[string]string{annotationInjectPython: "other-inst"}, statefulSet, pythonInitContainerName, "python:1"),
+		injectedPod("defaulted", "standalone", nil, nil, nodejsInitContainerName, "nodejs:1"),
+		// not injected, as the agent container is missing
+		injectedPod("apps", "not-injected", map[string]string{annotationInjectJava: "my-inst"}, nil, "init", "init:1"),
+	}
+	completed := injectedPod("apps", "completed", map[string]string{annotationInjectJava: "my-inst"}, nil, javaInitContainerName, "java:0")
+	completed.Status.Phase = corev1.PodSucceeded
+	pods = append(pods, completed)
+
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "defaulted",
+			Annotations: map[string]string{annotationInjectNodeJS: "true"},
+		}},
+		&v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "defaulted", Name: "default-inst"}},
+	).Build()
+
+	t.Run("should report the workloads injected with the instrumentation", func(t *testing.T) {
+		report, err := InjectionReport(context.Background(), c, types.NamespacedName{Namespace: "apps", Name: "my-inst"}, pods)
+		require.NoError(t, err)
+
+		expected := &v1alpha1.InstrumentationInjection{
+			Namespaces: []string{"apps", "other"},
+			Workloads: []v1alpha1.InjectedWorkload{
+				{Namespace: "apps", Kind: "Deployment", Name: "app", Pods: 2},
+				{Namespace: "other", Kind: "StatefulSet", Name: "db", Pods: 1},
+			},
+			Agents: []v1alpha1.InjectedAgent{
+				{Language: "java", Image: "java:1", Pods: 1},
+				{Language: "java", Image: "java:2", Pods: 1},
+				{Language: "python", Image: "python:1", Pods: 1},
+			},
+		}
+		assert.Equal(t, expected, report)
+	})
+
+	t.Run("should resolve the instrumentation selected by the namespace", func(t *testing.T) {
+		report, err := InjectionReport(context.Background(), c, types.NamespacedName{Namespace: "defaulted", Name: "default-inst"}, pods)
+		require.NoError(t, err)
+
+		expected := &v1alpha1.InstrumentationInjection{
+			Namespaces: []string{"defaulted"},
+			Workloads:  []v1alpha1.InjectedWorkload{{Namespace: "defaulted", Kind: "Pod", Name: "standalone", Pods: 1}},
+			Agents:     []v1alpha1.InjectedAgent{{Language: "nodejs", Image: "nodejs:1", Pods: 1}},
+		}
+		assert.Equal(t, expected, report)
+	})
+
+	t.Run("should not report an instrumentation without injected workloads", func(t *testing.T) {
+		report, err := InjectionReport(context.Background(), c, types.NamespacedName{Namespace: "apps", Name: "unused"}, pods)
+		require.NoError(t, err)
+		assert.Nil(t, report)
+	})
+}
+
+func TestInjectedPods(t *testing.T) {
+	injectedPod := func(namespace, name string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: javaInitContainerName, Image: "java:1"}},
+				Containers:     []corev1.Container{{Name: "app"}},
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme).
+		WithIndex(&corev1.Pod{}, InjectedPodIndex, InjectedPodIndexValues).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "referencing",
+				Annotations: map[string]string{annotationInjectJava: "apps/my-inst"},
+			}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			injectedPod("apps", "app", map[string]string{annotationInjectJava: "my-inst"}),
+			injectedPod("referencing", "app", nil),
+			injectedPod("other", "explicit", map[string]string{annotationInjectJava: "apps/my-inst"}),
+			injectedPod("other", "unrelated", map[string]string{annotationInjectJava: "other-inst"}),
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "not-injected"}},
+		).Build()
+
+	pods, err := InjectedPods(context.Background(), c, types.NamespacedName{Namespace: "apps", Name: "my-inst"})
+	require.NoError(t, err)
+
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"apps/app", "referencing/app", "other/explicit"}, names)
+}
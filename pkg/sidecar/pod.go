@@ -17,6 +17,7 @@ package sidecar
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -24,31 +25,36 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/parser/receiver"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 const (
 	injectedLabel = "sidecar.opentelemetry.io/injected"
-	confEnvVar    = "OTEL_CONFIG"
 )
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
-	otelColCfg, err := collector.ReplaceConfig(otelcol, nil)
+	otelColCfg, err := collector.ReplaceConfig(cfg, otelcol, nil)
 	if err != nil {
 		return pod, err
 	}
 
-	container := collector.Container(cfg, logger, otelcol, false)
-	container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
+	container, err := collector.Container(cfg, logger, otelcol, false)
+	if err != nil {
+		return pod, err
+	}
+	container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", collector.ConfigEnvVar))
 
-	container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
+	container.Env = append(container.Env, corev1.EnvVar{Name: collector.ConfigEnvVar, Value: otelColCfg})
 	if !hasResourceAttributeEnvVar(container.Env) {
 		container.Env = append(container.Env, attributes...)
 	}
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, otelcol.Spec.InitContainers...)
 	pod.Spec.Containers = append(pod.Spec.Containers, container)
 	pod.Spec.Volumes = append(pod.Spec.Volumes, otelcol.Spec.Volumes...)
+	// the sidecar mounts the Secrets holding the credentials of the receivers
+	pod.Spec.Volumes = append(pod.Spec.Volumes, collector.CredentialSecretVolumes(logger, otelcol)...)
 
 	if pod.Labels == nil {
 		pod.Labels = map[string]string{}
@@ -64,13 +70,37 @@ func remove(pod corev1.Pod) corev1.Pod {
 		return pod
 	}
 
+	// the volumes of the Secrets holding the credentials of the receivers are removed with the sidecar, unless the
+	// other containers mount them as well
+	credentialVolumes := map[string]bool{}
 	var containers []corev1.Container
 	for _, container := range pod.Spec.Containers {
 		if container.Name != naming.Container() {
 			containers = append(containers, container)
+			continue
+		}
+		for _, volumeMount := range container.VolumeMounts {
+			if strings.HasPrefix(volumeMount.MountPath, receiver.CredentialsMountPath+"/") {
+				credentialVolumes[volumeMount.Name] = true
+			}
+		}
+	}
+	for _, container := range append(containers, pod.Spec.InitContainers...) {
+		for _, volumeMount := range container.VolumeMounts {
+			delete(credentialVolumes, volumeMount.Name)
 		}
 	}
 	pod.Spec.Containers = containers
+
+	if len(credentialVolumes) > 0 {
+		var volumes []corev1.Volume
+		for _, volume := range pod.Spec.Volumes {
+			if !credentialVolumes[volume.Name] {
+				volumes = append(volumes, volume)
+			}
+		}
+		pod.Spec.Volumes = volumes
+	}
 	return pod
 }
 
@@ -30,6 +30,56 @@ import (
 
 var logger = logf.Log.WithName("unit-tests")
 
+func TestAddSidecarWithCredentialSecrets(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "my-app"}},
+		},
+	}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "otelcol-sample", Namespace: "some-app"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"redis": map[string]interface{}{
+						"endpoint": "redis:6379",
+						"password": "${file:/etc/otelcol-secrets/redis-credentials/password}",
+					},
+				}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"debug": map[string]interface{}{},
+				}},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"metrics": {
+							Receivers: []string{"redis"},
+							Exporters: []string{"debug"},
+						},
+					},
+				},
+			},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, changed.Spec.Containers, 2)
+	volumes := map[string]corev1.Volume{}
+	for _, volume := range changed.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	// every volume mounted by the sidecar is part of the pod
+	for _, mount := range changed.Spec.Containers[1].VolumeMounts {
+		assert.Contains(t, volumes, mount.Name)
+	}
+	require.Contains(t, volumes, "secret-redis-credentials")
+	assert.Equal(t, "redis-credentials", volumes["secret-redis-credentials"].Secret.SecretName)
+}
+
 func TestAddSidecarWhenNoSidecarExists(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -111,6 +161,7 @@ func TestAddSidecarWhenNoSidecarExists(t *testing.T) {
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}, changed.Spec.Containers[1])
 }
 
@@ -156,6 +207,29 @@ func TestRemoveSidecar(t *testing.T) {
 	assert.Len(t, changed.Spec.Containers, 1)
 }
 
+func TestRemoveSidecarWithCredentialSecrets(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app", VolumeMounts: []corev1.VolumeMount{{Name: "secret-shared", MountPath: "/etc/shared"}}},
+				{Name: naming.Container(), VolumeMounts: []corev1.VolumeMount{
+					{Name: "secret-postgres", MountPath: "/etc/otelcol-secrets/postgres"},
+					{Name: "secret-shared", MountPath: "/etc/otelcol-secrets/shared"},
+				}},
+			},
+			Volumes: []corev1.Volume{{Name: "app-data"}, {Name: "secret-postgres"}, {Name: "secret-shared"}},
+		},
+	}
+
+	// test
+	changed := remove(pod)
+
+	// verify
+	assert.Len(t, changed.Spec.Containers, 1)
+	assert.Equal(t, []corev1.Volume{{Name: "app-data"}, {Name: "secret-shared"}}, changed.Spec.Volumes)
+}
+
 func TestRemoveNonExistingSidecar(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{